@@ -0,0 +1,218 @@
+package intuit
+
+import (
+	"encoding/json"
+)
+
+/*
+TypedAccount is an account decoded into the struct for its type, holding the fields CAD reports only for that type, as returned by Accounts, Account and the discover calls:
+
+	for _, a := range accounts {
+		switch a := a.(type) {
+		case *intuit.CreditAccount:
+			fmt.Println(a.AccountNickname, a.CreditAvailableAmount)
+		case *intuit.LoanAccount:
+			fmt.Println(a.AccountNickname, a.PayoffAmount)
+		}
+	}
+
+Each is one of *BankingAccount, *CreditAccount, *LoanAccount, *InvestmentAccount, *RewardsAccount or *OtherAccount, and embeds the fields common to all accounts.
+*/
+type TypedAccount interface {
+	Type() AccountType
+
+	// The fields common to all accounts.
+	Common() *CustomerAccount
+}
+
+/*
+Return the account itself, to satisfy TypedAccount.
+*/
+func (a *CustomerAccount) Common() *CustomerAccount {
+	return a
+}
+
+type BankingAccount struct {
+	CustomerAccount
+	PostedDate             Date    `json:"postedDate"`
+	AvailableBalanceAmount Amount  `json:"availableBalanceAmount"`
+	InterestType           string  `json:"interestType,omitempty"`
+	OriginationDate        Date    `json:"originationDate"`
+	OpenDate               Date    `json:"openDate"`
+	PeriodInterestRate     float64 `json:"periodInterestRate,omitempty"`
+	PeriodDepositAmount    Amount  `json:"periodDepositAmount,omitempty"`
+	PeriodInterestAmount   Amount  `json:"periodInterestAmount,omitempty"`
+	InterestAmountYtd      Amount  `json:"interestAmountYtd,omitempty"`
+	InterestPriorAmountYtd Amount  `json:"interestPriorAmountYtd,omitempty"`
+	MaturityDate           Date    `json:"maturityDate"`
+	MaturityAmount         Amount  `json:"maturityAmount,omitempty"`
+}
+
+type CreditAccount struct {
+	CustomerAccount
+	CreditAvailableAmount      Amount  `json:"creditAvailableAmount"`
+	CreditMaxAmount            Amount  `json:"creditMaxAmount"`
+	CashAdvanceAvailableAmount Amount  `json:"cashAdvanceAvailableAmount,omitempty"`
+	CashAdvanceMaxAmount       Amount  `json:"cashAdvanceMaxAmount,omitempty"`
+	CashAdvanceBalance         Amount  `json:"cashAdvanceBalance,omitempty"`
+	CashAdvanceInterestRate    float64 `json:"cashAdvanceInterestRate,omitempty"`
+	CurrentBalance             Amount  `json:"currentBalance"`
+	PaymentMinAmount           Amount  `json:"paymentMinAmount"`
+	PaymentDueDate             Date    `json:"paymentDueDate"`
+	PreviousBalance            Amount  `json:"previousBalance,omitempty"`
+	StatementEndDate           Date    `json:"statementEndDate"`
+	StatementPurchaseAmount    Amount  `json:"statementPurchaseAmount,omitempty"`
+	StatementFinanceAmount     Amount  `json:"statementFinanceAmount,omitempty"`
+	PastDueAmount              Amount  `json:"pastDueAmount,omitempty"`
+	LastPaymentAmount          Amount  `json:"lastPaymentAmount,omitempty"`
+	LastPaymentDate            Date    `json:"lastPaymentDate"`
+	StatementCloseBalance      Amount  `json:"statementCloseBalance,omitempty"`
+	StatementLateFeeAmount     Amount  `json:"statementLateFeeAmount,omitempty"`
+}
+
+type LoanAccount struct {
+	CustomerAccount
+	PostedDate             Date    `json:"postedDate"`
+	Term                   string  `json:"term,omitempty"`
+	HolderName             string  `json:"holderName,omitempty"`
+	LateFeeAmount          Amount  `json:"lateFeeAmount,omitempty"`
+	PayoffAmount           Amount  `json:"payoffAmount,omitempty"`
+	PayoffAmountDate       Date    `json:"payoffAmountDate"`
+	OriginalMaturityDate   Date    `json:"originalMaturityDate"`
+	PrincipalBalance       Amount  `json:"principalBalance,omitempty"`
+	EscrowBalance          Amount  `json:"escrowBalance,omitempty"`
+	InterestRate           float64 `json:"interestRate,omitempty"`
+	InterestPeriod         string  `json:"interestPeriod,omitempty"`
+	InitialAmount          Amount  `json:"initialAmount,omitempty"`
+	InitialDate            Date    `json:"initialDate"`
+	NextPayment            Amount  `json:"nextPayment,omitempty"`
+	NextPaymentDate        Date    `json:"nextPaymentDate"`
+	LastPaymentAmount      Amount  `json:"lastPaymentAmount,omitempty"`
+	LastPaymentReceiveDate Date    `json:"lastPaymentReceiveDate"`
+	PrincipalPaidYTD       Amount  `json:"principalPaidYTD,omitempty"`
+	InterestPaidYTD        Amount  `json:"interestPaidYTD,omitempty"`
+	PaymentMinAmount       Amount  `json:"paymentMinAmount,omitempty"`
+	AutoPayEnrolled        bool    `json:"autopayEnrolled,omitempty"`
+	Lender                 string  `json:"lender,omitempty"`
+	PaymentsRemaining      int     `json:"paymentsRemaining,omitempty"`
+}
+
+type InvestmentAccount struct {
+	CustomerAccount
+	InterestMarginBalance Amount  `json:"interestMarginBalance,omitempty"`
+	ShortBalance          Amount  `json:"shortBalance,omitempty"`
+	AvailableCashBalance  Amount  `json:"availableCashBalance,omitempty"`
+	CurrentBalance        Amount  `json:"currentBalance"`
+	MaturityValueAmount   Amount  `json:"maturityValueAmount,omitempty"`
+	UnvestedBalance       Amount  `json:"unvestedBalance,omitempty"`
+	VestedBalance         Amount  `json:"vestedBalance,omitempty"`
+	EmpMatchAmount        Amount  `json:"empMatchAmount,omitempty"`
+	EmpMatchAmountYtd     Amount  `json:"empMatchAmountYtd,omitempty"`
+	CashBalanceAmount     Amount  `json:"cashBalanceAmount,omitempty"`
+	CurrentLoanBalance    Amount  `json:"currentLoanBalance,omitempty"`
+	LoanRate              float64 `json:"loanRate,omitempty"`
+}
+
+type RewardsAccount struct {
+	CustomerAccount
+	ProgramType            string `json:"programType"`
+	OriginalBalance        Amount `json:"originalBalance,omitempty"`
+	CurrentBalance         Amount `json:"currentBalance"`
+	RewardQualifyAmountYtd Amount `json:"rewardQualifyAmountYtd,omitempty"`
+	RewardLifetimeEarned   Amount `json:"rewardLifetimeEarned,omitempty"`
+	SegmentYtd             Amount `json:"segmentYtd,omitempty"`
+}
+
+/*
+Rewards accounts carry no subtype field; they are told apart by their program type.
+*/
+func (a *RewardsAccount) Type() AccountType {
+	return RewardsType
+}
+
+type OtherAccount struct {
+	CustomerAccount
+}
+
+/*
+The accounts of a response, kept undecoded until the type of each is known.
+*/
+type rawAccounts struct {
+	Accounts  []json.RawMessage `json:"accounts"`
+	IntuitTid string            `json:"-"`
+}
+
+func (r *rawAccounts) setIntuitTid(tid string) {
+	r.IntuitTid = tid
+}
+
+/*
+Decode each account of a response into the struct for its type.
+*/
+func (r *rawAccounts) decode(configuration *Configuration, endpoint string) ([]TypedAccount, error) {
+	accounts := make([]TypedAccount, 0, len(r.Accounts))
+	for _, b := range r.Accounts {
+		a, err := decodeAccount(configuration, endpoint, b)
+		if err != nil {
+			return nil, &DecodeError{Method: GET, Endpoint: endpoint, Body: b, Err: err, IntuitTid: r.IntuitTid}
+		}
+		a.Common().IntuitTid = r.IntuitTid
+		accounts = append(accounts, a)
+	}
+
+	return accounts, nil
+}
+
+func decodeAccount(configuration *Configuration, endpoint string, b []byte) (TypedAccount, error) {
+	var probe struct {
+		CustomerAccount
+		ProgramType string `json:"programType"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return nil, err
+	}
+
+	var a TypedAccount
+	switch probe.Type() {
+	case BankingType:
+		a = &BankingAccount{}
+	case CreditType:
+		a = &CreditAccount{}
+	case LoanType:
+		a = &LoanAccount{}
+	case InvestmentType:
+		a = &InvestmentAccount{}
+	default:
+		if probe.ProgramType != "" {
+			a = &RewardsAccount{}
+		} else {
+			a = &OtherAccount{}
+		}
+	}
+
+	return a, decodeTyped(configuration, endpoint, b, a)
+}
+
+/*
+Decode the accounts of an already decoded response, such as the data returned by ChallengeSession.Respond or Do, into the struct for each one's type.
+*/
+func NewTypedAccounts(data interface{}) ([]TypedAccount, error) {
+	return defaultClient().NewTypedAccounts(data)
+}
+
+/*
+Decode the accounts of an already decoded response, taking dates without a zone to be in the client's Location. See NewTypedAccounts.
+*/
+func (c *Client) NewTypedAccounts(data interface{}) ([]TypedAccount, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &rawAccounts{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+
+	return r.decode(c.Configuration(), "")
+}
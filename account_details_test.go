@@ -0,0 +1,63 @@
+package intuit
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewTypedAccounts(t *testing.T) {
+	var data map[string]interface{}
+	d := json.NewDecoder(strings.NewReader(`{"accounts": [
+		{"accountId": 1, "bankingAccountType": "CHECKING", "availableBalanceAmount": 120.5},
+		{"accountId": 2, "creditAccountType": "CREDITCARD", "creditMaxAmount": 5000, "paymentDueDate": "2014-07-01"},
+		{"accountId": 3, "loanType": "MORTGAGE", "payoffAmount": 150000},
+		{"accountId": 4, "investmentAccountType": "IRA", "vestedBalance": 900},
+		{"accountId": 5, "programType": "AIRLINE", "currentBalance": 25000},
+		{"accountId": 6}
+	]}`))
+	d.UseNumber()
+	assert.NoError(t, d.Decode(&data))
+
+	accounts, err := NewTypedAccounts(data)
+	assert.NoError(t, err)
+	if !assert.Len(t, accounts, 6) {
+		return
+	}
+
+	types := make([]AccountType, len(accounts))
+	for i, a := range accounts {
+		types[i] = a.Type()
+	}
+	assert.Equal(t, []AccountType{BankingType, CreditType, LoanType, InvestmentType, RewardsType, OtherType}, types)
+
+	assert.Equal(t, Amount(120.5), accounts[0].(*BankingAccount).AvailableBalanceAmount)
+	credit := accounts[1].(*CreditAccount)
+	assert.Equal(t, Amount(5000), credit.CreditMaxAmount)
+	assert.Equal(t, 2014, credit.PaymentDueDate.Year())
+	assert.Equal(t, Amount(150000), accounts[2].(*LoanAccount).PayoffAmount)
+	assert.Equal(t, Amount(900), accounts[3].(*InvestmentAccount).VestedBalance)
+	assert.Equal(t, "AIRLINE", accounts[4].(*RewardsAccount).ProgramType)
+	assert.Equal(t, "6", accounts[5].Common().AccountId.String())
+}
+
+func TestAccount(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts/2" {
+			w.Write([]byte(`{"accounts": []}`))
+			return
+		}
+		w.Write([]byte(`{"accounts": [{"accountId": 1, "loanType": "AUTO", "nextPayment": 310.25}]}`))
+	})
+	defer done()
+
+	account, err := Account("1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Amount(310.25), account.(*LoanAccount).NextPayment)
+	}
+
+	_, err = Account("2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
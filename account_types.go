@@ -0,0 +1,194 @@
+package intuit
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+AccountType is the category of a CAD account.
+*/
+type AccountType string
+
+const (
+	BankingType    AccountType = "BANKING"
+	CreditType     AccountType = "CREDIT"
+	LoanType       AccountType = "LOAN"
+	InvestmentType AccountType = "INVESTMENT"
+	RewardsType    AccountType = "REWARDS"
+	OtherType      AccountType = "OTHER"
+)
+
+/*
+AccountSubtype is the banking, credit, loan or investment type reported on a CAD account.
+*/
+type AccountSubtype string
+
+// Banking account types
+const (
+	Checking         AccountSubtype = "CHECKING"
+	Savings          AccountSubtype = "SAVINGS"
+	MoneyMarket      AccountSubtype = "MONEYMRKT"
+	RecurringDeposit AccountSubtype = "RECURRINGDEPOSIT"
+	CD               AccountSubtype = "CD"
+	CashManagement   AccountSubtype = "CASHMANAGEMENT"
+	Overdraft        AccountSubtype = "OVERDRAFT"
+)
+
+// Credit account types
+const (
+	CreditCard   AccountSubtype = "CREDITCARD"
+	LineOfCredit AccountSubtype = "LINEOFCREDIT"
+)
+
+// Loan types
+const (
+	Loan              AccountSubtype = "LOAN"
+	AutoLoan          AccountSubtype = "AUTO"
+	CommercialLoan    AccountSubtype = "COMMERCIAL"
+	ConstructionLoan  AccountSubtype = "CONSTR"
+	ConsumerLoan      AccountSubtype = "CONSUMER"
+	HomeEquity        AccountSubtype = "HOMEEQUITY"
+	MilitaryLoan      AccountSubtype = "MILITARY"
+	Mortgage          AccountSubtype = "MORTGAGE"
+	SmallBusinessLoan AccountSubtype = "SMB"
+	StudentLoan       AccountSubtype = "STUDENT"
+)
+
+// Investment account types
+const (
+	Taxable          AccountSubtype = "TAXABLE"
+	Retirement401K   AccountSubtype = "401K"
+	Brokerage        AccountSubtype = "BROKERAGE"
+	IRA              AccountSubtype = "IRA"
+	Retirement403B   AccountSubtype = "403B"
+	Keogh            AccountSubtype = "KEOGH"
+	Trust            AccountSubtype = "TRUST"
+	TDA              AccountSubtype = "TDA"
+	SimpleIRA        AccountSubtype = "SIMPLE"
+	NormalInvestment AccountSubtype = "NORMAL"
+	SARSEP           AccountSubtype = "SARSEP"
+	UGMA             AccountSubtype = "UGMA"
+)
+
+// Reported for credit and investment accounts which fit no other type.
+const OtherSubtype AccountSubtype = "OTHER"
+
+var accountSubtypes = map[AccountSubtype]AccountType{
+	Checking:          BankingType,
+	Savings:           BankingType,
+	MoneyMarket:       BankingType,
+	RecurringDeposit:  BankingType,
+	CD:                BankingType,
+	CashManagement:    BankingType,
+	Overdraft:         BankingType,
+	CreditCard:        CreditType,
+	LineOfCredit:      CreditType,
+	Loan:              LoanType,
+	AutoLoan:          LoanType,
+	CommercialLoan:    LoanType,
+	ConstructionLoan:  LoanType,
+	ConsumerLoan:      LoanType,
+	HomeEquity:        LoanType,
+	MilitaryLoan:      LoanType,
+	Mortgage:          LoanType,
+	SmallBusinessLoan: LoanType,
+	StudentLoan:       LoanType,
+	Taxable:           InvestmentType,
+	Retirement401K:    InvestmentType,
+	Brokerage:         InvestmentType,
+	IRA:               InvestmentType,
+	Retirement403B:    InvestmentType,
+	Keogh:             InvestmentType,
+	Trust:             InvestmentType,
+	TDA:               InvestmentType,
+	SimpleIRA:         InvestmentType,
+	NormalInvestment:  InvestmentType,
+	SARSEP:            InvestmentType,
+	UGMA:              InvestmentType,
+}
+
+/*
+Parse an account type, ignoring case.
+*/
+func ParseAccountType(s string) (AccountType, error) {
+	t := AccountType(strings.ToUpper(strings.TrimSpace(s)))
+
+	switch t {
+	case BankingType, CreditType, LoanType, InvestmentType, RewardsType, OtherType:
+		return t, nil
+	}
+
+	return "", fmt.Errorf("intuit: unknown account type %q", s)
+}
+
+/*
+Parse an account subtype, ignoring case.
+*/
+func ParseAccountSubtype(s string) (AccountSubtype, error) {
+	t := AccountSubtype(strings.ToUpper(strings.TrimSpace(s)))
+
+	if _, ok := accountSubtypes[t]; ok || t == OtherSubtype {
+		return t, nil
+	}
+
+	return "", fmt.Errorf("intuit: unknown account subtype %q", s)
+}
+
+/*
+Return the account type a subtype belongs to. OtherSubtype is shared by credit and investment accounts, and so maps to OtherType.
+*/
+func (t AccountSubtype) Type() AccountType {
+	if a, ok := accountSubtypes[t]; ok {
+		return a
+	}
+
+	return OtherType
+}
+
+/*
+Return the account's type, derived from whichever of its subtype fields is set.
+*/
+func (a CustomerAccount) Type() AccountType {
+	switch {
+	case a.BankingAccountType != "":
+		return BankingType
+	case a.CreditAccountType != "":
+		return CreditType
+	case a.LoanType != "":
+		return LoanType
+	case a.InvestmentAccountType != "":
+		return InvestmentType
+	}
+
+	return OtherType
+}
+
+/*
+Return the account's banking, credit, loan or investment subtype, normalized to upper case.
+*/
+func (a CustomerAccount) Subtype() AccountSubtype {
+	for _, s := range []string{a.BankingAccountType, a.CreditAccountType, a.LoanType, a.InvestmentAccountType} {
+		if s != "" {
+			return AccountSubtype(strings.ToUpper(s))
+		}
+	}
+
+	return ""
+}
+
+func (a CustomerAccount) IsDepository() bool {
+	return a.Type() == BankingType
+}
+
+func (a CustomerAccount) IsCredit() bool {
+	return a.Type() == CreditType
+}
+
+func (a CustomerAccount) IsLoan() bool {
+	return a.Type() == LoanType
+}
+
+func (a CustomerAccount) IsInvestment() bool {
+	return a.Type() == InvestmentType
+}
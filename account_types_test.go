@@ -0,0 +1,46 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseAccountSubtype(t *testing.T) {
+	s, err := ParseAccountSubtype(" moneymrkt")
+	assert.NoError(t, err)
+	assert.Equal(t, MoneyMarket, s)
+	assert.Equal(t, BankingType, s.Type())
+
+	s, err = ParseAccountSubtype("401k")
+	assert.NoError(t, err)
+	assert.Equal(t, InvestmentType, s.Type())
+
+	s, err = ParseAccountSubtype("other")
+	assert.NoError(t, err)
+	assert.Equal(t, OtherType, s.Type())
+
+	_, err = ParseAccountSubtype("BITCOIN")
+	assert.Error(t, err)
+
+	a, err := ParseAccountType("Credit")
+	assert.NoError(t, err)
+	assert.Equal(t, CreditType, a)
+}
+
+func TestAccountPredicates(t *testing.T) {
+	checking := CustomerAccount{BankingAccountType: "checking"}
+	assert.True(t, checking.IsDepository())
+	assert.False(t, checking.IsCredit())
+	assert.Equal(t, Checking, checking.Subtype())
+
+	card := CustomerAccount{CreditAccountType: "CREDITCARD"}
+	assert.True(t, card.IsCredit())
+	assert.Equal(t, CreditCard, card.Subtype())
+
+	ira := CustomerAccount{InvestmentAccountType: "IRA"}
+	assert.True(t, ira.IsInvestment())
+	assert.False(t, ira.IsDepository())
+
+	assert.Equal(t, OtherType, CustomerAccount{}.Type())
+	assert.Equal(t, AccountSubtype(""), CustomerAccount{}.Subtype())
+}
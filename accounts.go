@@ -0,0 +1,227 @@
+package intuit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+/*
+Maximum number of institution lookups performed concurrently when enriching accounts.
+*/
+var InstitutionLookupConcurrency = 4
+
+type CustomerAccount struct {
+	AccountId             json.Number    `json:"accountId"`
+	Status                AccountStatus  `json:"status"`
+	AccountNumber         string         `json:"accountNumber"`
+	AccountNickname       string         `json:"accountNickname"`
+	DisplayPosition       int            `json:"displayPosition"`
+	InstitutionId         json.Number    `json:"institutionId"`
+	InstitutionLoginId    json.Number    `json:"institutionLoginId"`
+	Description           string         `json:"description"`
+	BalanceAmount         Amount         `json:"balanceAmount"`
+	BalanceDate           Date           `json:"balanceDate"`
+	CurrencyCode          string         `json:"currencyCode"`
+	AggrStatusCode        string         `json:"aggrStatusCode"`
+	AggrSuccessDate       Date           `json:"aggrSuccessDate"`
+	AggrAttemptDate       Date           `json:"aggrAttemptDate"`
+	BankingAccountType    string         `json:"bankingAccountType,omitempty"`
+	CreditAccountType     string         `json:"creditAccountType,omitempty"`
+	LoanType              string         `json:"loanType,omitempty"`
+	InvestmentAccountType string         `json:"investmentAccountType,omitempty"`
+	Holders               AccountHolders `json:"holders,omitempty"`
+	IntuitTid             string         `json:"-"`
+
+	detailLoaded bool
+}
+
+type AccountWithInstitution struct {
+	CustomerAccount
+	Institution *InstitutionDetails
+}
+
+type accountList struct {
+	Accounts []CustomerAccount `json:"accounts"`
+}
+
+func (l *accountList) setIntuitTid(tid string) {
+	for i := range l.Accounts {
+		l.Accounts[i].IntuitTid = tid
+	}
+}
+
+/*
+Return all accounts for the scoped customer, each joined with its institution's name, home page and logo.
+
+Institutions are looked up once each, from the institution cache where possible and otherwise in parallel, bounded by InstitutionLookupConcurrency. If any lookup fails, the accounts are still returned, those at the failed institutions with a nil Institution, alongside a BatchError listing the failed institutions.
+*/
+func AccountsWithInstitutions() ([]AccountWithInstitution, error) {
+	return defaultClient().AccountsWithInstitutions()
+}
+
+/*
+Return all accounts for the scoped customer, each joined with its institution. See AccountsWithInstitutions.
+*/
+func (c *Client) AccountsWithInstitutions() ([]AccountWithInstitution, error) {
+	accounts, err := customerAccounts(c.background())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	for _, a := range accounts {
+		ids = append(ids, a.InstitutionId.String())
+	}
+
+	institutions, err := lookupInstitutions(c.background(), ids, InstitutionLookupConcurrency)
+
+	results := make([]AccountWithInstitution, len(accounts))
+	for i, a := range accounts {
+		results[i] = AccountWithInstitution{CustomerAccount: a, Institution: institutions[a.InstitutionId.String()]}
+	}
+
+	return results, err
+}
+
+/*
+Return all accounts for the scoped customer, grouped by the Id of the login they belong to.
+*/
+func AccountsByLogin() (map[string][]CustomerAccount, error) {
+	return defaultClient().AccountsByLogin()
+}
+
+/*
+Return all accounts for the scoped customer, grouped by the Id of the login they belong to.
+*/
+func (c *Client) AccountsByLogin() (map[string][]CustomerAccount, error) {
+	accounts, err := customerAccounts(c.background())
+	if err != nil {
+		return nil, err
+	}
+
+	logins := make(map[string][]CustomerAccount)
+	for _, a := range accounts {
+		loginId := a.InstitutionLoginId.String()
+		logins[loginId] = append(logins[loginId], a)
+	}
+
+	return logins, nil
+}
+
+/*
+Return the Id of the login an account belongs to.
+*/
+func LoginForAccount(accountId string) (string, error) {
+	return defaultClient().LoginForAccount(accountId)
+}
+
+/*
+Return the Id of the login an account belongs to.
+*/
+func (c *Client) LoginForAccount(accountId string) (string, error) {
+	var list accountList
+	if err := fetch(c.background(), GET, fmt.Sprintf("accounts/%s", accountId), nil, nil, nil, &list); err != nil {
+		return "", notFound("account", accountId, err)
+	}
+
+	if len(list.Accounts) == 0 {
+		return "", &NotFoundError{Resource: "account", Id: accountId}
+	}
+
+	return list.Accounts[0].InstitutionLoginId.String(), nil
+}
+
+var accountCache = newPersistentCache("accounts", func(b []byte) (interface{}, error) {
+	var accounts []CustomerAccount
+	return accounts, json.Unmarshal(b, &accounts)
+})
+
+/*
+Clear the cached accounts returned by CachedAccounts.
+*/
+func ClearAccountCache() {
+	defaultClient().ClearAccountCache()
+}
+
+/*
+Clear the cached accounts returned by CachedAccounts, including those persisted to the client's KV.
+*/
+func (c *Client) ClearAccountCache() {
+	accountCache.clear(c.Configuration())
+}
+
+/*
+Return all accounts for the scoped customer from the account cache, fetching them when missing or stale, along with how fresh they are. See CacheTTL and StaleWhileRevalidate.
+
+The returned slice is the caller's own copy.
+*/
+func CachedAccounts() ([]CustomerAccount, Freshness, error) {
+	return defaultClient().CachedAccounts()
+}
+
+/*
+Return all accounts for the scoped customer from the account cache. See CachedAccounts.
+*/
+func (c *Client) CachedAccounts() ([]CustomerAccount, Freshness, error) {
+	ctx := c.background()
+	v, freshness, err := accountCache.get(c.Configuration(), customerFor(ctx), func() (interface{}, error) {
+		return customerAccounts(ctx)
+	})
+	if err != nil {
+		return nil, freshness, err
+	}
+
+	cached := v.([]CustomerAccount)
+	accounts := make([]CustomerAccount, len(cached))
+	copy(accounts, cached)
+	return accounts, freshness, nil
+}
+
+func customerAccounts(ctx context.Context) ([]CustomerAccount, error) {
+	list, err := Get[accountList](ctx, "accounts", nil)
+	return list.Accounts, err
+}
+
+func lookupInstitutions(ctx context.Context, ids []string, concurrency int) (map[string]*InstitutionDetails, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mutex sync.Mutex
+		wg    sync.WaitGroup
+	)
+	results := make(map[string]*InstitutionDetails)
+	seen := make(map[string]bool)
+	sem := make(chan struct{}, concurrency)
+	batch := NewBatchError("look up institutions", len(ids))
+
+	for i, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			institution, err := cachedInstitution(ctx, id)
+			if err != nil {
+				batch.Add(i, id, err)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			results[id] = institution
+		}(i, id)
+	}
+
+	wg.Wait()
+	batch.Total = len(seen)
+	return results, batch.Err()
+}
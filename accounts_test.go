@@ -0,0 +1,88 @@
+package intuit
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAccountsWithInstitutions(t *testing.T) {
+	var (
+		mutex            sync.Mutex
+		inFlight, most   int
+		institutionCalls = make(map[string]int)
+	)
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts" {
+			w.Write([]byte(`{"accounts": [
+				{"accountId": 1, "institutionId": 1}, {"accountId": 2, "institutionId": 2}, {"accountId": 3, "institutionId": 1},
+				{"accountId": 4, "institutionId": 3}, {"accountId": 5, "institutionId": 4}, {"accountId": 6, "institutionId": 5}
+			]}`))
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/institutions/")
+		mutex.Lock()
+		institutionCalls[id]++
+		inFlight++
+		if inFlight > most {
+			most = inFlight
+		}
+		mutex.Unlock()
+
+		// Hold each lookup long enough for the others to pile up against the bound.
+		time.Sleep(20 * time.Millisecond)
+
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+
+		if id == "3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errorInfo": [{"errorCode": "500", "errorMessage": "unavailable"}]}`))
+			return
+		}
+		w.Write([]byte(`{"institutionId": ` + id + `, "institutionName": "Institution ` + id + `"}`))
+	})
+	defer done()
+	ClearInstitutionCache()
+	defer ClearInstitutionCache()
+
+	previous := InstitutionLookupConcurrency
+	InstitutionLookupConcurrency = 2
+	defer func() { InstitutionLookupConcurrency = previous }()
+
+	accounts, err := AccountsWithInstitutions()
+
+	// Accounts at the failed institution are still returned, without their institution.
+	var batch *BatchError
+	if assert.True(t, errors.As(err, &batch)) {
+		assert.Equal(t, []string{"3"}, batch.Ids())
+		assert.Equal(t, 5, batch.Total)
+	}
+	if assert.Len(t, accounts, 6) {
+		assert.Equal(t, "Institution 1", accounts[0].Institution.InstitutionName)
+		assert.Equal(t, "Institution 2", accounts[1].Institution.InstitutionName)
+		assert.Equal(t, "Institution 1", accounts[2].Institution.InstitutionName)
+		assert.Nil(t, accounts[3].Institution)
+		assert.Equal(t, "Institution 5", accounts[5].Institution.InstitutionName)
+	}
+
+	// Each institution is looked up once, and never more than the bound at a time.
+	mutex.Lock()
+	assert.Equal(t, map[string]int{"1": 1, "2": 1, "3": 1, "4": 1, "5": 1}, institutionCalls)
+	assert.Equal(t, 2, most)
+	mutex.Unlock()
+
+	// Looked up institutions come from the cache; the failed one is tried again.
+	accounts, err = AccountsWithInstitutions()
+	assert.Error(t, err)
+	assert.Len(t, accounts, 6)
+	mutex.Lock()
+	assert.Equal(t, map[string]int{"1": 1, "2": 1, "3": 2, "4": 1, "5": 1}, institutionCalls)
+	mutex.Unlock()
+}
@@ -0,0 +1,225 @@
+/*
+Anonymize accounts and transactions for analytics export, so aggregation output can be shared without raw financial PII.
+
+	accounts, err := anonymize.Accounts(accounts, anonymize.Options{Key: key})
+	transactions, err := anonymize.Transactions(transactions, anonymize.Options{Key: key})
+
+Identifiers are replaced with a keyed HMAC, so the same account, login or payee maps to the same token across exports made with the same key, and joins between accounts and transactions still work, but tokens cannot be reversed or recomputed without the key. Amounts are reduced to a direction and a bucket. Account numbers, nicknames, descriptions, holders and memos are dropped, and payee names are scrubbed of digit runs and email addresses, or dropped for person-to-person payments, where the payee is usually a person's name.
+*/
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"math"
+	"regexp"
+	"strings"
+)
+
+/*
+Default upper bounds of the amount buckets, in the account's currency.
+*/
+var DefaultBuckets = []float64{10, 50, 100, 500, 1000, 5000, 10000}
+
+/*
+Payees matching any of these are person-to-person payments, whose payee is dropped.
+*/
+var PersonalPayees = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(zelle|venmo|paypal|cash ?app|square cash|popmoney)\b`),
+	regexp.MustCompile(`(?i)\b(transfer|xfer|payment|check|cheque|deposit)\s+(to|from)\b`),
+	regexp.MustCompile(`(?i)^\s*(check|cheque)\b`),
+}
+
+var (
+	emails = regexp.MustCompile(`\S+@\S+`)
+	digits = regexp.MustCompile(`[0-9][0-9\-.*#/]*`)
+	spaces = regexp.MustCompile(`\s+`)
+)
+
+type Options struct {
+	// Secret key for the HMAC of identifiers. Required; keep it out of the export so tokens cannot be recomputed.
+	Key []byte
+
+	// Ascending upper bounds of the amount buckets. Defaults to DefaultBuckets.
+	Buckets []float64
+}
+
+func (o Options) buckets() []float64 {
+	if len(o.Buckets) == 0 {
+		return DefaultBuckets
+	}
+
+	return o.Buckets
+}
+
+func (o Options) check() error {
+	if len(o.Key) == 0 {
+		return errors.New("anonymize: a key is required")
+	}
+
+	return nil
+}
+
+/*
+Return the token for an identifier in a namespace such as "account", the base64 of the first 12 bytes of its HMAC-SHA256. Empty identifiers stay empty.
+*/
+func (o Options) token(namespace string, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, o.Key)
+	mac.Write([]byte(namespace + "\x00" + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:12])
+}
+
+/*
+Return the label of the bucket holding the magnitude of an amount, such as "50-100" or "10000+".
+*/
+func (o Options) bucket(amount intuit.Decimal) string {
+	a := math.Abs(float64(amount.Amount()))
+	lower := 0.0
+	for _, upper := range o.buckets() {
+		if a < upper {
+			return fmt.Sprintf("%s-%s", formatBound(lower), formatBound(upper))
+		}
+		lower = upper
+	}
+
+	return formatBound(lower) + "+"
+}
+
+func formatBound(f float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.2f", f), ".00")
+}
+
+func direction(amount intuit.Decimal) string {
+	switch amount.Sign() {
+	case -1:
+		return "debit"
+	case 1:
+		return "credit"
+	}
+
+	return ""
+}
+
+/*
+Account is an anonymized account.
+*/
+type Account struct {
+	Id            string               `json:"id"`
+	InstitutionId string               `json:"institutionId"`
+	LoginId       string               `json:"loginId"`
+	Type          intuit.AccountType   `json:"type"`
+	Status        intuit.AccountStatus `json:"status"`
+	Currency      string               `json:"currency"`
+
+	// "credit" for a positive balance, "debit" for a negative one, empty for zero.
+	BalanceDirection string      `json:"balanceDirection"`
+	BalanceBucket    string      `json:"balanceBucket"`
+	BalanceDate      intuit.Date `json:"balanceDate"`
+
+	AggrStatusCode string `json:"aggrStatusCode"`
+}
+
+/*
+Transaction is an anonymized transaction.
+*/
+type Transaction struct {
+	Id        string                 `json:"id"`
+	AccountId string                 `json:"accountId"`
+	Type      intuit.TransactionType `json:"type"`
+	Currency  string                 `json:"currency"`
+
+	// The scrubbed payee name, empty for person-to-person payments.
+	Payee string `json:"payee,omitempty"`
+
+	// Token of the normalized payee name, set even when Payee is dropped, so spending can still be grouped by payee.
+	PayeeId string `json:"payeeId,omitempty"`
+
+	PostedDate intuit.Date `json:"postedDate"`
+
+	// "debit" for money out, "credit" for money in.
+	Direction    string `json:"direction"`
+	AmountBucket string `json:"amountBucket"`
+	Pending      bool   `json:"pending"`
+}
+
+/*
+Anonymize accounts. The institution Id is kept, since institutions are public.
+*/
+func Accounts(accounts []intuit.CustomerAccount, o Options) ([]Account, error) {
+	if err := o.check(); err != nil {
+		return nil, err
+	}
+
+	anonymized := make([]Account, 0, len(accounts))
+	for _, a := range accounts {
+		anonymized = append(anonymized, Account{
+			Id:               o.token("account", a.AccountId.String()),
+			InstitutionId:    a.InstitutionId.String(),
+			LoginId:          o.token("login", a.InstitutionLoginId.String()),
+			Type:             a.Type(),
+			Status:           a.Status,
+			Currency:         a.CurrencyCode,
+			BalanceDirection: direction(a.BalanceAmount.Decimal()),
+			BalanceBucket:    o.bucket(a.BalanceAmount.Decimal()),
+			BalanceDate:      a.BalanceDate,
+			AggrStatusCode:   a.AggrStatusCode,
+		})
+	}
+
+	return anonymized, nil
+}
+
+/*
+Anonymize transactions. Account Ids are tokenized as Accounts does, so the two can be joined.
+*/
+func Transactions(transactions []intuit.Transaction, o Options) ([]Transaction, error) {
+	if err := o.check(); err != nil {
+		return nil, err
+	}
+
+	anonymized := make([]Transaction, 0, len(transactions))
+	for _, t := range transactions {
+		normalized := normalizePayee(t.PayeeName)
+		anonymized = append(anonymized, Transaction{
+			Id:           o.token("transaction", t.Id.String()),
+			AccountId:    o.token("account", t.AccountId),
+			Type:         t.Type,
+			Currency:     t.CurrencyType,
+			Payee:        ScrubPayee(t.PayeeName),
+			PayeeId:      o.token("payee", strings.ToLower(normalized)),
+			PostedDate:   t.PostedDate,
+			Direction:    direction(t.Amount),
+			AmountBucket: o.bucket(t.Amount),
+			Pending:      t.Pending,
+		})
+	}
+
+	return anonymized, nil
+}
+
+/*
+Return a payee name with email addresses and digit runs, such as card, phone and reference numbers, removed, or empty for a person-to-person payment.
+*/
+func ScrubPayee(payee string) string {
+	for _, p := range PersonalPayees {
+		if p.MatchString(payee) {
+			return ""
+		}
+	}
+
+	return normalizePayee(payee)
+}
+
+func normalizePayee(payee string) string {
+	payee = emails.ReplaceAllString(payee, " ")
+	payee = digits.ReplaceAllString(payee, " ")
+	return strings.TrimSpace(spaces.ReplaceAllString(payee, " "))
+}
@@ -0,0 +1,63 @@
+package anonymize
+
+import (
+	"encoding/json"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTransactions(t *testing.T) {
+	o := Options{Key: []byte("secret")}
+	transactions := []intuit.Transaction{
+		{Id: "1", AccountId: "10", PayeeName: "AMAZON MKTPLACE 1234-5678 jane@example.com", Memo: "gift for Jane", Amount: intuit.MustParseDecimal("-42.5")},
+		{Id: "2", AccountId: "10", PayeeName: "ZELLE TO JANE DOE", Amount: intuit.MustParseDecimal("12000")},
+		{Id: "3", AccountId: "10", PayeeName: "Amazon Mktplace 9999", Amount: intuit.MustParseDecimal("-7")},
+	}
+
+	anonymized, err := Transactions(transactions, o)
+	assert.NoError(t, err)
+	assert.Equal(t, "AMAZON MKTPLACE", anonymized[0].Payee)
+	assert.Equal(t, "debit", anonymized[0].Direction)
+	assert.Equal(t, "10-50", anonymized[0].AmountBucket)
+
+	assert.Equal(t, "", anonymized[1].Payee)
+	assert.NotEmpty(t, anonymized[1].PayeeId)
+	assert.Equal(t, "credit", anonymized[1].Direction)
+	assert.Equal(t, "10000+", anonymized[1].AmountBucket)
+
+	// The same payee and account map to the same tokens.
+	assert.Equal(t, anonymized[0].PayeeId, anonymized[2].PayeeId)
+	assert.Equal(t, anonymized[0].AccountId, anonymized[1].AccountId)
+	assert.NotEqual(t, "10", anonymized[0].AccountId)
+
+	b, _ := json.Marshal(anonymized)
+	assert.NotContains(t, string(b), "Jane")
+	assert.NotContains(t, string(b), "jane")
+	assert.NotContains(t, string(b), "1234")
+
+	// Tokens depend on the key.
+	other, _ := Transactions(transactions, Options{Key: []byte("other")})
+	assert.NotEqual(t, anonymized[0].AccountId, other[0].AccountId)
+
+	_, err = Transactions(transactions, Options{})
+	assert.EqualError(t, err, "anonymize: a key is required")
+}
+
+func TestAccounts(t *testing.T) {
+	o := Options{Key: []byte("secret"), Buckets: []float64{100, 1000}}
+	accounts, err := Accounts([]intuit.CustomerAccount{
+		{AccountId: "10", AccountNumber: "000123456", AccountNickname: "Jane's checking", InstitutionId: "100000", BankingAccountType: "CHECKING", BalanceAmount: 250.75},
+	}, o)
+	assert.NoError(t, err)
+
+	transactions, _ := Transactions([]intuit.Transaction{{Id: "1", AccountId: "10"}}, o)
+	assert.Equal(t, transactions[0].AccountId, accounts[0].Id)
+	assert.Equal(t, "100000", accounts[0].InstitutionId)
+	assert.Equal(t, "100-1000", accounts[0].BalanceBucket)
+	assert.Equal(t, "credit", accounts[0].BalanceDirection)
+
+	b, _ := json.Marshal(accounts)
+	assert.NotContains(t, string(b), "123456")
+	assert.NotContains(t, string(b), "Jane")
+}
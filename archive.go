@@ -0,0 +1,78 @@
+package intuit
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+Archiver keeps a local copy of data before it is deleted from Intuit, which cannot be undone.
+
+When Configuration.Archiver is set, DeleteAccount and DeleteCustomer first snapshot the affected accounts and their transactions and pass the snapshot to Archive. If Archive returns an error, nothing is deleted.
+*/
+type Archiver interface {
+	Archive(snapshot *Snapshot) error
+}
+
+/*
+ArchiverFunc adapts a function to the Archiver interface.
+*/
+type ArchiverFunc func(snapshot *Snapshot) error
+
+func (f ArchiverFunc) Archive(snapshot *Snapshot) error {
+	return f(snapshot)
+}
+
+func (c *Client) archiveCustomer() error {
+	archiver := c.Configuration().Archiver
+	if archiver == nil {
+		return nil
+	}
+
+	q := c.Configuration().ArchiveQuery
+	snapshot, err := c.CustomerSnapshot(&q)
+	if err != nil {
+		return fmt.Errorf("intuit: archiving customer before deletion: %w", err)
+	}
+
+	return archive(archiver, snapshot)
+}
+
+func (c *Client) archiveAccount(accountId string) error {
+	archiver := c.Configuration().Archiver
+	if archiver == nil {
+		return nil
+	}
+
+	ctx := c.background()
+	accounts, err := customerAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("intuit: archiving account %s before deletion: %w", accountId, err)
+	}
+
+	for _, a := range accounts {
+		if a.AccountId.String() != accountId {
+			continue
+		}
+
+		account := AccountSnapshot{CustomerAccount: a}
+		account.Transactions, err = collectTransactions(ctx, accountId, c.Configuration().ArchiveQuery)
+		if err != nil {
+			return fmt.Errorf("intuit: archiving account %s before deletion: %w", accountId, err)
+		}
+
+		login := LoginSnapshot{LoginId: a.InstitutionLoginId.String(), InstitutionId: a.InstitutionId.String(), Accounts: []AccountSnapshot{account}}
+		return archive(archiver, &Snapshot{CustomerId: customerFor(ctx), CreatedAt: time.Now().UTC(), Logins: []LoginSnapshot{login}})
+	}
+
+	// Nothing to archive; the delete will report the missing account.
+	return nil
+}
+
+func archive(archiver Archiver, snapshot *Snapshot) error {
+	if err := archiver.Archive(snapshot); err != nil {
+		return fmt.Errorf("intuit: archiving before deletion: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package intuit
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func archiveStub(t *testing.T, deleted *[]string) func() {
+	return configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == DELETE:
+			*deleted = append(*deleted, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/accounts":
+			w.Write([]byte(`{"accounts": [
+				{"accountId": 1, "institutionId": 100000, "institutionLoginId": 7},
+				{"accountId": 2, "institutionId": 100000, "institutionLoginId": 7}
+			]}`))
+		case r.URL.Path == "/accounts/2/transactions":
+			w.Write([]byte(`{"bankingTransactions": [{"id": 9, "amount": -5}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestDeleteAccountArchives(t *testing.T) {
+	var deleted []string
+	defer archiveStub(t, &deleted)()
+
+	var archived *Snapshot
+	SessionConfiguration.Archiver = ArchiverFunc(func(s *Snapshot) error {
+		assert.Empty(t, deleted)
+		archived = s
+		return nil
+	})
+
+	assert.NoError(t, DeleteAccount("2"))
+	assert.Equal(t, []string{"/accounts/2"}, deleted)
+	assert.Equal(t, "7", archived.Logins[0].LoginId)
+	assert.Equal(t, 1, len(archived.Logins[0].Accounts))
+	assert.Equal(t, "2", archived.Logins[0].Accounts[0].AccountId.String())
+	assert.Equal(t, MustParseDecimal("-5"), archived.Logins[0].Accounts[0].Transactions[0].Amount)
+}
+
+func TestArchiveFailurePreventsDelete(t *testing.T) {
+	var deleted []string
+	defer archiveStub(t, &deleted)()
+
+	SessionConfiguration.Archiver = ArchiverFunc(func(s *Snapshot) error {
+		return errors.New("disk full")
+	})
+
+	err := DeleteAccount("2")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+	assert.Empty(t, deleted)
+}
@@ -0,0 +1,213 @@
+package backfill
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"github.com/MattNewberry/intuit"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Name of the gzipped JSON lines file in a run's archive, holding one ArchivedChunk per line.
+	ChunksFile = "chunks.jsonl.gz"
+
+	// Name of the JSON manifest in a run's archive.
+	ManifestFile = "manifest.json"
+)
+
+/*
+ArchivedChunk is the transactions of one account and month, as handled during an archived run.
+*/
+type ArchivedChunk struct {
+	AccountId    string               `json:"accountId"`
+	Start        time.Time            `json:"start"`
+	End          time.Time            `json:"end"`
+	Transactions []intuit.Transaction `json:"transactions"`
+}
+
+/*
+Manifest summarizes an archived run: what it fetched, what failed and how long it took.
+*/
+type Manifest struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Duration   string    `json:"duration"`
+
+	// Accounts backfilled, and chunks scheduled by the run, excluding those already checkpointed.
+	Accounts int `json:"accounts"`
+	Chunks   int `json:"chunks"`
+
+	Completed    int `json:"completed"`
+	Failed       int `json:"failed"`
+	Transactions int `json:"transactions"`
+
+	// The error the run returned, if any.
+	Error string `json:"error,omitempty"`
+
+	// Every chunk attempted, ordered by account and start.
+	Entries []ManifestEntry `json:"entries"`
+}
+
+type ManifestEntry struct {
+	AccountId    string    `json:"accountId"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Transactions int       `json:"transactions"`
+
+	// Time taken to fetch the chunk.
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+/*
+An archive being written for a run.
+*/
+type archive struct {
+	dir      string
+	file     *os.File
+	buffer   *bufio.Writer
+	gz       *gzip.Writer
+	encoder  *json.Encoder
+	manifest Manifest
+	mutex    sync.Mutex
+}
+
+/*
+Create the archive of a run started at started, in a directory of its own under root named after the start time.
+*/
+func newArchive(root string, started time.Time, accounts int, chunks int) (*archive, error) {
+	dir := filepath.Join(root, started.UTC().Format("20060102T150405.000000000Z"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(filepath.Join(dir, ChunksFile))
+	if err != nil {
+		return nil, err
+	}
+
+	a := &archive{dir: dir, file: f, buffer: bufio.NewWriter(f)}
+	a.gz = gzip.NewWriter(a.buffer)
+	a.encoder = json.NewEncoder(a.gz)
+	a.manifest = Manifest{StartedAt: started, Accounts: accounts, Chunks: chunks, Entries: make([]ManifestEntry, 0)}
+
+	return a, nil
+}
+
+/*
+Write a handled chunk and record it in the manifest.
+*/
+func (a *archive) write(accountId string, chunk intuit.Chunk, transactions []intuit.Transaction, d time.Duration) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if err := a.encoder.Encode(ArchivedChunk{AccountId: accountId, Start: chunk.Start, End: chunk.End, Transactions: transactions}); err != nil {
+		return err
+	}
+
+	a.manifest.Completed++
+	a.manifest.Transactions += len(transactions)
+	a.manifest.Entries = append(a.manifest.Entries, ManifestEntry{AccountId: accountId, Start: chunk.Start, End: chunk.End, Transactions: len(transactions), Duration: d.String()})
+	return nil
+}
+
+/*
+Record a failed chunk in the manifest.
+*/
+func (a *archive) fail(accountId string, chunk intuit.Chunk, d time.Duration, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.manifest.Failed++
+	a.manifest.Entries = append(a.manifest.Entries, ManifestEntry{AccountId: accountId, Start: chunk.Start, End: chunk.End, Duration: d.String(), Error: err.Error()})
+}
+
+/*
+Finish the chunks file and write the manifest, recording the run's outcome.
+*/
+func (a *archive) close(finished time.Time, runErr error) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	err := a.gz.Close()
+	if flushErr := a.buffer.Flush(); err == nil {
+		err = flushErr
+	}
+	if closeErr := a.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	m := a.manifest
+	m.FinishedAt = finished
+	m.Duration = finished.Sub(m.StartedAt).String()
+	if runErr != nil {
+		m.Error = runErr.Error()
+	}
+	sort.SliceStable(m.Entries, func(i, j int) bool {
+		if m.Entries[i].AccountId != m.Entries[j].AccountId {
+			return m.Entries[i].AccountId < m.Entries[j].AccountId
+		}
+		return m.Entries[i].Start.Before(m.Entries[j].Start)
+	})
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(a.dir, ManifestFile), b, 0644)
+}
+
+/*
+Read the manifest of an archived run from its directory.
+*/
+func ReadManifest(dir string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	return m, json.Unmarshal(b, m)
+}
+
+/*
+Pass each chunk of an archived run to handle, in the order they were handled, stopping at the first error. Use it to load a run's results again, such as into a new store.
+*/
+func Replay(dir string, handle func(accountId string, chunk intuit.Chunk, transactions []intuit.Transaction) error) error {
+	f, err := os.Open(filepath.Join(dir, ChunksFile))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	d := json.NewDecoder(gz)
+	for {
+		var c ArchivedChunk
+		if err := d.Decode(&c); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := handle(c.AccountId, intuit.Chunk{Start: c.Start, End: c.End}, c.Transactions); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Fetch years of transaction history for every account of a customer, in monthly chunks across a pool of workers, checkpointing progress so an interrupted backfill resumes where it left off.
+
+	checkpoint, err := backfill.NewFileCheckpoint("backfill.json")
+	if err != nil {
+		return err
+	}
+
+	b := &backfill.Backfill{
+		Years:      5,
+		Workers:    4,
+		Checkpoint: checkpoint,
+		Handle: func(accountId string, chunk intuit.Chunk, transactions []intuit.Transaction) error {
+			return store(accountId, transactions)
+		},
+	}
+	err = b.Run(context.Background())
+
+Set ArchiveDir to keep a compressed record of each run, with a manifest of what was fetched, what failed and how long it took, for auditing; Replay feeds an archived run to a handler again.
+*/
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"sync"
+	"time"
+)
+
+const DefaultWorkers = 4
+
+/*
+Backfill describes a history backfill for the scoped customer.
+*/
+type Backfill struct {
+	// Years of history to fetch, counted back from the start of the current month.
+	Years int
+
+	// Number of chunks fetched concurrently. Defaults to DefaultWorkers.
+	Workers int
+
+	// Progress store. Defaults to a MemoryCheckpoint.
+	Checkpoint Checkpoint
+
+	// Receive the transactions of a single account and month. Calls are serialized; the chunk is only checkpointed once Handle succeeds.
+	Handle func(accountId string, chunk intuit.Chunk, transactions []intuit.Transaction) error
+
+	// Return the accounts to backfill. Defaults to every account of the scoped customer.
+	Accounts func() ([]intuit.CustomerAccount, error)
+
+	// Fetch the transactions of an account within a chunk. Defaults to streaming them with intuit.TransactionsChan.
+	Fetch func(ctx context.Context, accountId string, chunk intuit.Chunk) ([]intuit.Transaction, error)
+
+	// Current time. Defaults to time.Now.
+	Now func() time.Time
+
+	// Directory under which each run writes an archive of its own: the handled chunks as gzipped JSON lines, and a Manifest of counts, errors and durations. Nothing is archived when empty. See Replay.
+	ArchiveDir string
+}
+
+type job struct {
+	index     int
+	accountId string
+	chunk     intuit.Chunk
+}
+
+/*
+Fetch every chunk not yet recorded in the checkpoint, stopping at the first error. Failed chunks are reported in an *intuit.BatchError identifying each by account Id; chunks already in flight when the run stops may add to it.
+
+Chunks are calendar months. The current month ends today, so it is fetched again on every run until it has passed.
+
+Requests run at intuit.PriorityBackground unless ctx sets another priority, so a PriorityMiddleware lets interactive calls ahead of the backfill.
+*/
+func (b *Backfill) Run(ctx context.Context) error {
+	accounts, err := b.accounts()
+	if err != nil {
+		return err
+	}
+
+	checkpoint := b.Checkpoint
+	if checkpoint == nil {
+		checkpoint = NewMemoryCheckpoint()
+	}
+
+	jobs := make([]job, 0)
+	for _, a := range accounts {
+		for _, chunk := range b.chunks() {
+			if !checkpoint.Completed(a.AccountId.String(), chunk) {
+				jobs = append(jobs, job{index: len(jobs), accountId: a.AccountId.String(), chunk: chunk})
+			}
+		}
+	}
+
+	var archive *archive
+	if b.ArchiveDir != "" {
+		if archive, err = newArchive(b.ArchiveDir, time.Now(), len(accounts), len(jobs)); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := intuit.PriorityFromContext(ctx); !ok {
+		ctx = intuit.WithPriority(ctx, intuit.PriorityBackground)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		failed bool
+	)
+	batch := intuit.NewBatchError("backfill", len(jobs))
+
+	fail := func(j job, d time.Duration, err error) {
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			return
+		}
+
+		if archive != nil {
+			archive.fail(j.accountId, j.chunk, d, err)
+		}
+
+		mutex.Lock()
+		failed = true
+		mutex.Unlock()
+		batch.Add(j.index, j.accountId, fmt.Errorf("%s to %s: %w", j.chunk.Start.Format("2006-01-02"), j.chunk.End.Format("2006-01-02"), err))
+		cancel()
+	}
+
+	queue := make(chan job)
+	workers := b.Workers
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range queue {
+				started := time.Now()
+				transactions, err := b.fetch(ctx, j.accountId, j.chunk)
+				d := time.Since(started)
+				if err != nil {
+					fail(j, d, err)
+					continue
+				}
+
+				mutex.Lock()
+				if failed {
+					// Leave the chunk unchecked, so a resumed run fetches and handles it.
+					mutex.Unlock()
+					continue
+				}
+				if b.Handle != nil {
+					err = b.Handle(j.accountId, j.chunk, transactions)
+				}
+				if err == nil && archive != nil {
+					err = archive.write(j.accountId, j.chunk, transactions, d)
+				}
+				mutex.Unlock()
+
+				if err == nil {
+					err = checkpoint.Complete(j.accountId, j.chunk)
+				}
+				if err != nil {
+					fail(j, d, err)
+				}
+			}
+		}()
+	}
+
+	for _, j := range jobs {
+		select {
+		case queue <- j:
+			continue
+		case <-ctx.Done():
+		}
+		break
+	}
+	close(queue)
+	wg.Wait()
+
+	err = batch.Err()
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	if archive != nil {
+		if archiveErr := archive.close(time.Now(), err); err == nil {
+			err = archiveErr
+		}
+	}
+
+	return err
+}
+
+func (b *Backfill) chunks() []intuit.Chunk {
+	now := time.Now
+	if b.Now != nil {
+		now = b.Now
+	}
+
+	end := now()
+	today := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	start := time.Date(end.Year()-b.Years, end.Month(), 1, 0, 0, 0, 0, end.Location())
+
+	return intuit.MonthChunker()(start, today)
+}
+
+func (b *Backfill) accounts() ([]intuit.CustomerAccount, error) {
+	if b.Accounts != nil {
+		return b.Accounts()
+	}
+
+	logins, err := intuit.AccountsByLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]intuit.CustomerAccount, 0)
+	for _, login := range logins {
+		accounts = append(accounts, login...)
+	}
+
+	return accounts, nil
+}
+
+func (b *Backfill) fetch(ctx context.Context, accountId string, chunk intuit.Chunk) ([]intuit.Transaction, error) {
+	if b.Fetch != nil {
+		return b.Fetch(ctx, accountId, chunk)
+	}
+
+	transactions, errs := intuit.TransactionsChan(ctx, accountId, intuit.TransactionQuery{Start: chunk.Start, End: chunk.End})
+
+	all := make([]intuit.Transaction, 0)
+	for t := range transactions {
+		all = append(all, t)
+	}
+
+	return all, <-errs
+}
@@ -0,0 +1,196 @@
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testBackfill(fetch func(accountId string, chunk intuit.Chunk) error) (*Backfill, map[string]int) {
+	handled := make(map[string]int)
+
+	return &Backfill{
+		Years:   1,
+		Workers: 3,
+		Now: func() time.Time {
+			return time.Date(2014, 5, 15, 12, 0, 0, 0, time.UTC)
+		},
+		Accounts: func() ([]intuit.CustomerAccount, error) {
+			return []intuit.CustomerAccount{{AccountId: json.Number("1")}, {AccountId: json.Number("2")}}, nil
+		},
+		Fetch: func(ctx context.Context, accountId string, chunk intuit.Chunk) ([]intuit.Transaction, error) {
+			if err := fetch(accountId, chunk); err != nil {
+				return nil, err
+			}
+			return []intuit.Transaction{{Amount: intuit.MustParseDecimal("1")}}, nil
+		},
+		Handle: func(accountId string, chunk intuit.Chunk, transactions []intuit.Transaction) error {
+			handled[accountId] += len(transactions)
+			return nil
+		},
+	}, handled
+}
+
+func TestBackfill(t *testing.T) {
+	var mutex sync.Mutex
+	chunks := make([]intuit.Chunk, 0)
+
+	b, handled := testBackfill(func(accountId string, chunk intuit.Chunk) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if accountId == "1" {
+			chunks = append(chunks, chunk)
+		}
+		return nil
+	})
+
+	assert.NoError(t, b.Run(context.Background()))
+	assert.Equal(t, 13, handled["1"])
+	assert.Equal(t, 13, handled["2"])
+	assert.Equal(t, 13, len(chunks))
+
+	for _, c := range chunks {
+		assert.Equal(t, 1, c.Start.Day())
+		assert.False(t, c.Start.Before(time.Date(2013, 5, 1, 0, 0, 0, 0, time.UTC)))
+		assert.False(t, c.End.After(time.Date(2014, 5, 15, 0, 0, 0, 0, time.UTC)))
+	}
+}
+
+func TestBackfillResumes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backfill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.json")
+
+	checkpoint, err := NewFileCheckpoint(path)
+	assert.NoError(t, err)
+
+	failing := intuit.Chunk{Start: time.Date(2013, 9, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2013, 9, 30, 0, 0, 0, 0, time.UTC)}
+	b, _ := testBackfill(func(accountId string, chunk intuit.Chunk) error {
+		if accountId == "2" && chunk == failing {
+			return errors.New("service unavailable")
+		}
+		return nil
+	})
+	b.Checkpoint = checkpoint
+	err = b.Run(context.Background())
+	batch, ok := err.(*intuit.BatchError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"2"}, batch.Ids())
+	assert.Contains(t, err.Error(), "2: 2013-09-01 to 2013-09-30: service unavailable")
+	assert.False(t, checkpoint.Completed("2", failing))
+
+	checkpoint, err = NewFileCheckpoint(path)
+	assert.NoError(t, err)
+
+	var fetched int32
+	b, handled := testBackfill(func(accountId string, chunk intuit.Chunk) error {
+		atomic.AddInt32(&fetched, 1)
+		return nil
+	})
+	b.Checkpoint = checkpoint
+	assert.NoError(t, b.Run(context.Background()))
+
+	assert.True(t, fetched < 26)
+	assert.Equal(t, int(fetched), handled["1"]+handled["2"])
+	assert.True(t, checkpoint.Completed("2", failing))
+}
+
+func TestBackfillResumesChunksInFlight(t *testing.T) {
+	checkpoint := NewMemoryCheckpoint()
+
+	var mutex sync.Mutex
+	handled := make(map[string]int)
+	b, _ := testBackfill(nil)
+	b.Checkpoint = checkpoint
+	b.Handle = func(accountId string, chunk intuit.Chunk, transactions []intuit.Transaction) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		handled[accountId+" "+chunk.Start.Format("2006-01")]++
+		return nil
+	}
+
+	chunks := b.chunks()
+	slow, failing := chunks[0], chunks[1]
+	started := make(chan struct{})
+	b.Fetch = func(ctx context.Context, accountId string, chunk intuit.Chunk) ([]intuit.Transaction, error) {
+		switch {
+		case accountId == "1" && chunk == slow:
+			// Succeed only once the other chunk has failed and the run is stopping.
+			close(started)
+			<-ctx.Done()
+		case accountId == "1" && chunk == failing:
+			<-started
+			return nil, errors.New("service unavailable")
+		}
+		return []intuit.Transaction{{Amount: intuit.MustParseDecimal("1")}}, nil
+	}
+
+	assert.Error(t, b.Run(context.Background()))
+	assert.False(t, checkpoint.Completed("1", failing))
+	assert.False(t, checkpoint.Completed("1", slow))
+	assert.Zero(t, handled["1 "+slow.Start.Format("2006-01")])
+
+	b.Fetch = func(ctx context.Context, accountId string, chunk intuit.Chunk) ([]intuit.Transaction, error) {
+		return []intuit.Transaction{{Amount: intuit.MustParseDecimal("1")}}, nil
+	}
+	assert.NoError(t, b.Run(context.Background()))
+
+	for _, accountId := range []string{"1", "2"} {
+		for _, chunk := range chunks {
+			assert.Equal(t, 1, handled[accountId+" "+chunk.Start.Format("2006-01")], "%s %s", accountId, chunk.Start.Format("2006-01"))
+			assert.True(t, checkpoint.Completed(accountId, chunk), "%s %s", accountId, chunk.Start.Format("2006-01"))
+		}
+	}
+}
+
+func TestBackfillArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backfill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	failing := intuit.Chunk{Start: time.Date(2013, 9, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2013, 9, 30, 0, 0, 0, 0, time.UTC)}
+	b, _ := testBackfill(func(accountId string, chunk intuit.Chunk) error {
+		if accountId == "2" && chunk == failing {
+			return errors.New("service unavailable")
+		}
+		return nil
+	})
+	b.Workers = 1
+	b.ArchiveDir = dir
+	assert.Error(t, b.Run(context.Background()))
+
+	runs, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	if !assert.Len(t, runs, 1) {
+		return
+	}
+	run := filepath.Join(dir, runs[0].Name())
+
+	m, err := ReadManifest(run)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, m.Accounts)
+	assert.Equal(t, 26, m.Chunks)
+	assert.Equal(t, 1, m.Failed)
+	assert.Equal(t, m.Completed, m.Transactions)
+	assert.Equal(t, m.Completed+m.Failed, len(m.Entries))
+	assert.Contains(t, m.Error, "service unavailable")
+	assert.False(t, m.FinishedAt.Before(m.StartedAt))
+
+	replayed := 0
+	assert.NoError(t, Replay(run, func(accountId string, chunk intuit.Chunk, transactions []intuit.Transaction) error {
+		assert.False(t, accountId == "2" && chunk.Start.Equal(failing.Start))
+		replayed += len(transactions)
+		return nil
+	}))
+	assert.Equal(t, m.Transactions, replayed)
+}
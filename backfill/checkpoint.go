@@ -0,0 +1,115 @@
+package backfill
+
+import (
+	"encoding/json"
+	"github.com/MattNewberry/intuit"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+/*
+Checkpoint records which chunks of which accounts have been backfilled. Implementations must be safe for concurrent use.
+*/
+type Checkpoint interface {
+	Completed(accountId string, chunk intuit.Chunk) bool
+	Complete(accountId string, chunk intuit.Chunk) error
+}
+
+/*
+MemoryCheckpoint keeps progress in memory, for backfills which need not survive the process.
+*/
+type MemoryCheckpoint struct {
+	mutex sync.Mutex
+	done  map[string]bool
+}
+
+func NewMemoryCheckpoint() *MemoryCheckpoint {
+	return &MemoryCheckpoint{done: make(map[string]bool)}
+}
+
+func (c *MemoryCheckpoint) Completed(accountId string, chunk intuit.Chunk) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.done[key(accountId, chunk)]
+}
+
+func (c *MemoryCheckpoint) Complete(accountId string, chunk intuit.Chunk) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.done[key(accountId, chunk)] = true
+	return nil
+}
+
+/*
+FileCheckpoint persists progress to a JSON file, rewritten after every completed chunk, so an interrupted backfill resumes where it left off.
+*/
+type FileCheckpoint struct {
+	MemoryCheckpoint
+	path string
+}
+
+/*
+Open the checkpoint file at path, starting afresh if it does not exist.
+*/
+func NewFileCheckpoint(path string) (*FileCheckpoint, error) {
+	c := &FileCheckpoint{MemoryCheckpoint: *NewMemoryCheckpoint(), path: path}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		c.done[k] = true
+	}
+
+	return c, nil
+}
+
+func (c *FileCheckpoint) Complete(accountId string, chunk intuit.Chunk) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.done[key(accountId, chunk)] = true
+
+	keys := make([]string, 0, len(c.done))
+	for k := range c.done {
+		keys = append(keys, k)
+	}
+
+	b, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename, so an interruption never leaves a truncated checkpoint.
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
+
+func key(accountId string, chunk intuit.Chunk) string {
+	return accountId + "/" + chunk.Start.Format("2006-01-02") + "/" + chunk.End.Format("2006-01-02")
+}
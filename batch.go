@@ -0,0 +1,109 @@
+package intuit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+ItemError is the failure of a single item of a batch operation.
+*/
+type ItemError struct {
+	// Position of the item in the batch, and its Id, such as an account or institution Id.
+	Index int
+	Id    string
+
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Id, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+/*
+BatchError reports every item which failed in a batch operation, such as LoadDetails or DeleteAccounts, ordered by index. Items not listed succeeded, unless the operation stopped early.
+
+It unwraps to the ItemError of each failure, so errors.Is and errors.As match against any of them.
+*/
+type BatchError struct {
+	// Operation which failed, such as "load account details".
+	Op string
+
+	// Number of items in the batch.
+	Total int
+
+	Errors []*ItemError
+
+	mutex sync.Mutex
+}
+
+/*
+Return an empty BatchError for an operation over total items. Add records failures; Err returns the error to report.
+*/
+func NewBatchError(op string, total int) *BatchError {
+	return &BatchError{Op: op, Total: total}
+}
+
+/*
+Record the failure of an item. It is safe for concurrent use.
+*/
+func (e *BatchError) Add(index int, id string, err error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.Errors = append(e.Errors, &ItemError{Index: index, Id: id, Err: err})
+}
+
+/*
+Return the BatchError if any item failed, otherwise nil.
+*/
+func (e *BatchError) Err() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(e.Errors, func(i, j int) bool {
+		return e.Errors[i].Index < e.Errors[j].Index
+	})
+
+	return e
+}
+
+func (e *BatchError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, item := range e.Errors {
+		messages[i] = item.Error()
+	}
+
+	return fmt.Sprintf("intuit: %s: %d of %d failed: %s", e.Op, len(e.Errors), e.Total, strings.Join(messages, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, item := range e.Errors {
+		errs[i] = item
+	}
+
+	return errs
+}
+
+/*
+Return the Ids of the failed items.
+*/
+func (e *BatchError) Ids() []string {
+	ids := make([]string, len(e.Errors))
+	for i, item := range e.Errors {
+		ids[i] = item.Id
+	}
+
+	return ids
+}
@@ -0,0 +1,43 @@
+package intuit
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestBatchError(t *testing.T) {
+	batch := NewBatchError("delete accounts", 3)
+	assert.Nil(t, batch.Err())
+
+	missing := errors.New("missing")
+	batch.Add(2, "c", missing)
+	batch.Add(0, "a", errors.New("busy"))
+
+	err := batch.Err()
+	assert.Equal(t, "intuit: delete accounts: 2 of 3 failed: a: busy; c: missing", err.Error())
+	assert.Equal(t, []string{"a", "c"}, batch.Ids())
+	assert.True(t, errors.Is(err, missing))
+
+	var item *ItemError
+	assert.True(t, errors.As(err, &item))
+	assert.Equal(t, 0, item.Index)
+}
+
+func TestDeleteAccounts(t *testing.T) {
+	deleted := make([]string, 0)
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		deleted = append(deleted, r.URL.Path)
+	})
+	defer done()
+
+	err := DeleteAccounts([]string{"1", "2", "3"})
+	assert.Equal(t, []string{"/accounts/1", "/accounts/3"}, deleted)
+	assert.Equal(t, []string{"2"}, err.(*BatchError).Ids())
+	assert.Equal(t, http.StatusNotFound, StatusCode(err))
+}
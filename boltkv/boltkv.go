@@ -0,0 +1,112 @@
+//go:build bolt
+
+/*
+Persist the package's state in a single bbolt database file, for single instances which want durable tokens, caches and snapshots without running a server.
+
+	kv, err := boltkv.Open("intuit.db")
+	intuit.Configure(&intuit.Configuration{KV: kv, ...})
+
+Build with the bolt tag to include this package, so applications not using it do not pull in bbolt.
+*/
+package boltkv
+
+import (
+	"encoding/binary"
+	"github.com/MattNewberry/intuit"
+	"go.etcd.io/bbolt"
+	"strings"
+	"time"
+)
+
+var bucket = []byte("intuit")
+
+/*
+Store is an intuit.KV backed by a bbolt database. Each value is stored after its expiry in Unix nanoseconds, zero for none.
+*/
+type Store struct {
+	db *bbolt.DB
+}
+
+/*
+Open or create the database at path.
+*/
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func expired(b []byte, now time.Time) bool {
+	if len(b) < 8 {
+		return true
+	}
+
+	expires := int64(binary.BigEndian.Uint64(b))
+	return expires != 0 && now.UnixNano() > expires
+}
+
+func (s *Store) Get(key string) ([]byte, error) {
+	var value []byte
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(bucket).Get([]byte(key))
+		if b == nil || expired(b, time.Now()) {
+			return intuit.ErrKeyNotFound
+		}
+
+		// Values are only valid for the life of the transaction.
+		value = append([]byte(nil), b[8:]...)
+		return nil
+	})
+
+	return value, err
+}
+
+func (s *Store) Set(key string, value []byte, ttl time.Duration) error {
+	b := make([]byte, 8, 8+len(value))
+	if ttl > 0 {
+		binary.BigEndian.PutUint64(b, uint64(time.Now().Add(ttl).UnixNano()))
+	}
+	b = append(b, value...)
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), b)
+	})
+}
+
+func (s *Store) Delete(key string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).Delete([]byte(key))
+	})
+}
+
+func (s *Store) Keys(prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		now := time.Now()
+		c := tx.Bucket(bucket).Cursor()
+		for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+			if !expired(v, now) {
+				keys = append(keys, string(k))
+			}
+		}
+		return nil
+	})
+
+	return keys, err
+}
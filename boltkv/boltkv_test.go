@@ -0,0 +1,46 @@
+//go:build bolt
+
+package boltkv
+
+import (
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltkv")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := Open(filepath.Join(dir, "intuit.db"))
+	assert.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.Get("missing")
+	assert.Equal(t, intuit.ErrKeyNotFound, err)
+
+	assert.NoError(t, s.Set("token/a", []byte("one"), 0))
+	assert.NoError(t, s.Set("token/b", []byte("two"), time.Nanosecond))
+	assert.NoError(t, s.Set("tokens", []byte("three"), 0))
+	time.Sleep(time.Millisecond)
+
+	b, err := s.Get("token/a")
+	assert.NoError(t, err)
+	assert.Equal(t, "one", string(b))
+
+	_, err = s.Get("token/b")
+	assert.Equal(t, intuit.ErrKeyNotFound, err)
+
+	keys, err := s.Keys("token/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"token/a"}, keys)
+
+	assert.NoError(t, s.Delete("token/a"))
+	_, err = s.Get("token/a")
+	assert.Equal(t, intuit.ErrKeyNotFound, err)
+}
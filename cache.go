@@ -0,0 +1,238 @@
+package intuit
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Freshness describes how current a cached result is.
+*/
+type Freshness struct {
+	// When the result was fetched from Intuit.
+	FetchedAt time.Time
+
+	// The result is older than CacheTTL. With StaleWhileRevalidate, a refresh is under way in the background.
+	Stale bool
+}
+
+/*
+Return the age of the result.
+*/
+func (f Freshness) Age() time.Duration {
+	return time.Since(f.FetchedAt)
+}
+
+type cacheEntry struct {
+	value      interface{}
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+/*
+A cache of fetched results by key, honoring the configured CacheTTL and StaleWhileRevalidate.
+*/
+type cache struct {
+	mutex   sync.Mutex
+	entries map[string]*cacheEntry
+
+	// Entries are persisted to the configured KV under namespace, and decoded from it with decode.
+	namespace string
+	decode    func([]byte) (interface{}, error)
+}
+
+func newCache() *cache {
+	return &cache{entries: make(map[string]*cacheEntry)}
+}
+
+/*
+Return a cache whose entries are also kept in the configured KV, if any, under the given namespace.
+*/
+func newPersistentCache(namespace string, decode func([]byte) (interface{}, error)) *cache {
+	return &cache{entries: make(map[string]*cacheEntry), namespace: namespace, decode: decode}
+}
+
+/*
+Return key scoped to the configuration's OAuth consumer, so that applications sharing the process or a KV do not see each other's entries.
+*/
+func (c *cache) scope(configuration *Configuration, key string) string {
+	return configuration.consumerKey() + "/" + key
+}
+
+/*
+Remove the configuration's entries, in memory and in the configured KV.
+*/
+func (c *cache) clear(configuration *Configuration) {
+	prefix := c.scope(configuration, "")
+
+	c.mutex.Lock()
+	for k := range c.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.entries, k)
+		}
+	}
+	c.mutex.Unlock()
+
+	kv := configuration.kv()
+	if c.namespace == "" || kv == nil {
+		return
+	}
+
+	keys, err := kv.Keys(c.namespace + "/" + prefix)
+	if err != nil {
+		configuration.logf("intuit: warning: clearing %s cache: %v", c.namespace, err)
+	}
+	for _, k := range keys {
+		kv.Delete(k)
+	}
+}
+
+type persistedEntry struct {
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Value     json.RawMessage `json:"value"`
+}
+
+/*
+Load an entry from the configured KV, or return nil.
+*/
+func (c *cache) load(configuration *Configuration, scoped string) *cacheEntry {
+	kv := configuration.kv()
+	if c.namespace == "" || kv == nil {
+		return nil
+	}
+
+	b, err := kv.Get(c.namespace + "/" + scoped)
+	if err != nil {
+		return nil
+	}
+
+	var p persistedEntry
+	if json.Unmarshal(b, &p) != nil {
+		return nil
+	}
+	v, err := c.decode(p.Value)
+	if err != nil {
+		return nil
+	}
+
+	return &cacheEntry{value: v, fetchedAt: p.FetchedAt}
+}
+
+/*
+Save an entry to the configured KV, if any.
+*/
+func (c *cache) store(configuration *Configuration, scoped string, e *cacheEntry) {
+	kv := configuration.kv()
+	if c.namespace == "" || kv == nil {
+		return
+	}
+
+	value, err := json.Marshal(e.value)
+	if err == nil {
+		var b []byte
+		b, err = json.Marshal(persistedEntry{FetchedAt: e.fetchedAt, Value: value})
+		if err == nil {
+			err = kv.Set(c.namespace+"/"+scoped, b, 0)
+		}
+	}
+	if err != nil {
+		configuration.logf("intuit: warning: saving %s %s: %v", c.namespace, scoped, err)
+	}
+}
+
+/*
+Return the cached value for key, fetching it when missing or stale. With StaleWhileRevalidate, a stale value is returned immediately while a single background refresh replaces it; refresh failures are logged and the stale value kept.
+*/
+func (c *cache) get(configuration *Configuration, key string, fetch func() (interface{}, error)) (interface{}, Freshness, error) {
+	ttl := configuration.CacheTTL
+	swr := configuration.StaleWhileRevalidate
+	key = c.scope(configuration, key)
+
+	c.mutex.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		if e = c.load(configuration, key); e != nil {
+			c.entries[key] = e
+			ok = true
+		}
+	}
+	if ok {
+		stale := ttl > 0 && time.Since(e.fetchedAt) >= ttl
+		if !stale || swr {
+			if stale && !e.refreshing {
+				e.refreshing = true
+				go c.refresh(configuration, key, e, fetch)
+			}
+			c.mutex.Unlock()
+			return e.value, Freshness{FetchedAt: e.fetchedAt, Stale: stale}, nil
+		}
+	}
+	c.mutex.Unlock()
+
+	v, err := fetch()
+	if err != nil {
+		return nil, Freshness{}, err
+	}
+
+	e = &cacheEntry{value: v, fetchedAt: time.Now()}
+	c.mutex.Lock()
+	c.entries[key] = e
+	c.mutex.Unlock()
+	c.store(configuration, key, e)
+
+	return v, Freshness{FetchedAt: e.fetchedAt}, nil
+}
+
+func (c *cache) refresh(configuration *Configuration, key string, stale *cacheEntry, fetch func() (interface{}, error)) {
+	v, err := fetch()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil {
+		configuration.logf("intuit: warning: refreshing %s in the background failed: %v", key, err)
+		stale.refreshing = false
+		return
+	}
+
+	if c.entries[key] == stale {
+		e := &cacheEntry{value: v, fetchedAt: time.Now()}
+		c.entries[key] = e
+		c.store(configuration, key, e)
+	}
+}
+
+/*
+Store a freshly fetched value, replacing any entry for key.
+*/
+func (c *cache) put(configuration *Configuration, key string, v interface{}) {
+	key = c.scope(configuration, key)
+	e := &cacheEntry{value: v, fetchedAt: time.Now()}
+
+	c.mutex.Lock()
+	c.entries[key] = e
+	c.mutex.Unlock()
+
+	c.store(configuration, key, e)
+}
+
+/*
+Return the keys of the configuration's entries in memory.
+*/
+func (c *cache) keys(configuration *Configuration) []string {
+	prefix := c.scope(configuration, "")
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, strings.TrimPrefix(k, prefix))
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,71 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	var requests int32
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Write([]byte(`{"institutionId": 1, "institutionName": "First"}`))
+		} else {
+			w.Write([]byte(`{"institutionId": 1, "institutionName": "Second"}`))
+		}
+	})
+	defer done()
+	ClearInstitutionCache()
+	defer ClearInstitutionCache()
+
+	SessionConfiguration.CacheTTL = 10 * time.Millisecond
+	SessionConfiguration.StaleWhileRevalidate = true
+
+	institution, freshness, err := CachedInstitution("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "First", institution.InstitutionName)
+	assert.False(t, freshness.Stale)
+
+	time.Sleep(20 * time.Millisecond)
+
+	// The stale entry is served while it is refreshed in the background.
+	institution, freshness, err = CachedInstitution("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "First", institution.InstitutionName)
+	assert.True(t, freshness.Stale)
+
+	for i := 0; i < 100 && institution.InstitutionName == "First"; i++ {
+		time.Sleep(5 * time.Millisecond)
+		institution, _, _ = CachedInstitution("1")
+	}
+	assert.Equal(t, "Second", institution.InstitutionName)
+}
+
+func TestCacheTTL(t *testing.T) {
+	var requests int32
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"accounts": [{"accountId": 1}]}`))
+	})
+	defer done()
+	ClearAccountCache()
+	defer ClearAccountCache()
+
+	accounts, _, err := CachedAccounts()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(accounts))
+
+	// Without a TTL entries never expire.
+	CachedAccounts()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// Expired entries are fetched again before returning without StaleWhileRevalidate.
+	SessionConfiguration.CacheTTL = time.Nanosecond
+	_, freshness, err := CachedAccounts()
+	assert.NoError(t, err)
+	assert.False(t, freshness.Stale)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
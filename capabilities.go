@@ -0,0 +1,104 @@
+package intuit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+/*
+Capability is a feature an institution supports, as listed in its details: either a kind of data or one of the AccountType values.
+*/
+type Capability string
+
+const (
+	CapabilityTransactions Capability = "TRANSACTIONS"
+	CapabilityBalance      Capability = "BALANCE"
+)
+
+/*
+Capabilities are the features listed in an institution's details. Most institutions list none, in which case every feature is assumed to be supported.
+*/
+type Capabilities []Capability
+
+/*
+Accept capabilities either as a bare array or wrapped in a "capability" element, as converted from Intuit's XML schema. Names are normalized to upper case.
+*/
+func (c *Capabilities) UnmarshalJSON(b []byte) error {
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		var wrapped struct {
+			Capability []string `json:"capability"`
+		}
+		if err := json.Unmarshal(b, &wrapped); err != nil {
+			return err
+		}
+		names = wrapped.Capability
+	}
+
+	capabilities := make(Capabilities, len(names))
+	for i, n := range names {
+		capabilities[i] = Capability(strings.ToUpper(strings.TrimSpace(n)))
+	}
+	*c = capabilities
+	return nil
+}
+
+/*
+Report whether the capability is listed.
+*/
+func (c Capabilities) Has(capability Capability) bool {
+	for _, listed := range c {
+		if listed == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Return the account types listed, or nil if none are.
+*/
+func (c Capabilities) AccountTypes() []AccountType {
+	var types []AccountType
+	for _, listed := range c {
+		switch t := AccountType(listed); t {
+		case BankingType, CreditType, LoanType, InvestmentType, RewardsType, OtherType:
+			types = append(types, t)
+		}
+	}
+
+	return types
+}
+
+/*
+Report whether transactions can be fetched for the institution's accounts. Institutions which list capabilities without CapabilityTransactions only report balances.
+*/
+func (i *InstitutionDetails) SupportsTransactions() bool {
+	return len(i.Capabilities) == 0 || i.Capabilities.Has(CapabilityTransactions)
+}
+
+/*
+Report whether the institution only reports balances, without transactions.
+*/
+func (i *InstitutionDetails) IsBalanceOnly() bool {
+	return !i.SupportsTransactions()
+}
+
+/*
+Report whether accounts of the given type can be added at the institution. Institutions which list no account types are assumed to support all of them.
+*/
+func (i *InstitutionDetails) SupportsAccountType(t AccountType) bool {
+	types := i.Capabilities.AccountTypes()
+	if len(types) == 0 {
+		return true
+	}
+
+	for _, supported := range types {
+		if supported == t {
+			return true
+		}
+	}
+
+	return false
+}
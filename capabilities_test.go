@@ -0,0 +1,28 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	details, err := NewInstitutionDetails(map[string]interface{}{"institutionId": 1})
+	assert.NoError(t, err)
+	assert.True(t, details.SupportsTransactions())
+	assert.True(t, details.SupportsAccountType(LoanType))
+
+	details, err = NewInstitutionDetails(map[string]interface{}{
+		"institutionId": 2,
+		"capabilities":  map[string]interface{}{"capability": []interface{}{"balance", "BANKING", "CREDIT"}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, details.IsBalanceOnly())
+	assert.Equal(t, []AccountType{BankingType, CreditType}, details.Capabilities.AccountTypes())
+	assert.True(t, details.SupportsAccountType(CreditType))
+	assert.False(t, details.SupportsAccountType(InvestmentType))
+
+	details, err = NewInstitutionDetails(map[string]interface{}{"institutionId": 3, "capabilities": []interface{}{"TRANSACTIONS"}})
+	assert.NoError(t, err)
+	assert.True(t, details.SupportsTransactions())
+	assert.True(t, details.SupportsAccountType(InvestmentType))
+}
@@ -0,0 +1,289 @@
+package intuit
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type ChallengeKind int
+
+const (
+	TextChallenge ChallengeKind = iota
+	ChoiceChallenge
+	ImageChallenge
+)
+
+func (k ChallengeKind) String() string {
+	switch k {
+	case ChoiceChallenge:
+		return "choice"
+	case ImageChallenge:
+		return "image"
+	}
+
+	return "text"
+}
+
+/*
+Answer is the response to a single challenge. For choice challenges, Value is the selected choice's value.
+
+Some challenges, such as multi-part image selections, are answered with several response elements. Their further values are held in More, and all values are sent in order, grouped by challenge.
+*/
+type Answer struct {
+	Value string
+
+	// Values sent as further response elements after Value.
+	More []string `json:",omitempty"`
+
+	// Insert the values into the request as raw XML rather than escaping them.
+	Raw bool
+}
+
+/*
+Answer a text or image challenge with free text.
+*/
+func TextAnswer(text string) Answer {
+	return Answer{Value: text}
+}
+
+/*
+Answer a challenge with XML inserted into the response as is. The caller is responsible for its well-formedness.
+*/
+func RawAnswer(raw string) Answer {
+	return Answer{Value: raw, Raw: true}
+}
+
+/*
+Answer a challenge with several text values, each sent as its own response element in order.
+*/
+func MultiAnswer(values ...string) Answer {
+	if len(values) == 0 {
+		return Answer{}
+	}
+
+	return Answer{Value: values[0], More: values[1:]}
+}
+
+/*
+Return every value of the answer in the order they are sent.
+*/
+func (a Answer) Values() []string {
+	return append([]string{a.Value}, a.More...)
+}
+
+/*
+Return the answer as it is inserted into the challenge response, escaped unless raw.
+*/
+func (a Answer) innerXML() string {
+	return a.escape(a.Value)
+}
+
+func (a Answer) escape(value string) string {
+	if a.Raw {
+		return value
+	}
+
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+/*
+Return a response element for each value of the answer.
+*/
+func (a Answer) responses() []ChallengeResponse {
+	values := a.Values()
+	responses := make([]ChallengeResponse, len(values))
+	for i, v := range values {
+		responses[i] = ChallengeResponse{Answer: a.escape(v), XMLNS: ChallengeXMLNS}
+	}
+
+	return responses
+}
+
+/*
+Answer a choice challenge with one of its choices.
+*/
+func ChoiceAnswer(choice Choice) Answer {
+	return Answer{Value: fmt.Sprint(choice.Value)}
+}
+
+/*
+Answer a choice challenge which asks for several selections, in order.
+*/
+func ChoiceAnswers(choices ...Choice) Answer {
+	values := make([]string, len(choices))
+	for i, c := range choices {
+		values[i] = fmt.Sprint(c.Value)
+	}
+
+	return MultiAnswer(values...)
+}
+
+/*
+Return the kind of answer the challenge expects. Challenges offering choices must be answered with one of them, regardless of whether the prompt is text or an image.
+*/
+func (c Challenge) Kind() ChallengeKind {
+	if len(c.Choices) > 0 {
+		return ChoiceChallenge
+	} else if len(c.Image) > 0 {
+		return ImageChallenge
+	}
+
+	return TextChallenge
+}
+
+/*
+Check that an answer is acceptable for the challenge, including each of its further values.
+*/
+func (c Challenge) Validate(a Answer) error {
+	for _, v := range a.Values() {
+		if err := c.validate(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c Challenge) validate(value string) error {
+	if c.Kind() != ChoiceChallenge {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("intuit: %s challenge %q requires a non-empty answer", c.Kind(), c.Question)
+		}
+		return nil
+	}
+
+	for _, choice := range c.Choices {
+		if fmt.Sprint(choice.Value) == value {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("intuit: %q is not one of the choices for challenge %q", value, c.Question)
+}
+
+/*
+Check that every challenge in the session has an acceptable answer, so invalid answers are caught before being sent to Intuit.
+*/
+func (s *ChallengeSession) Validate() error {
+	if len(s.Answers) != len(s.Challenges) {
+		return fmt.Errorf("intuit: %d answers given for %d challenges", len(s.Answers), len(s.Challenges))
+	}
+
+	for i, c := range s.Challenges {
+		if err := c.Validate(s.Answers[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+Return the challenge image as a data: URL, such as "data:image/png;base64,...", for embedding in a web page. It is empty when the challenge has no image.
+*/
+func (c Challenge) ImageDataURL() string {
+	if len(c.Image) == 0 {
+		return ""
+	}
+
+	return "data:" + imageContentType(c.Image) + ";base64," + base64.StdEncoding.EncodeToString(c.Image)
+}
+
+/*
+Return a URL for the challenge image. Images larger than MaxImageDataURLSize are stored with the configured ImageStore and its URL returned; others, or all images when no store is configured, are returned as data URLs. It is empty when the challenge has no image.
+*/
+func (c Challenge) ImageURL() (string, error) {
+	return defaultClient().ImageURL(c)
+}
+
+/*
+Return a URL for a challenge image, stored with the client's ImageStore. See Challenge.ImageURL.
+*/
+func (c *Client) ImageURL(challenge Challenge) (string, error) {
+	configuration := c.Configuration()
+	if len(challenge.Image) == 0 || configuration == nil || configuration.ImageStore == nil || len(challenge.Image) <= configuration.maxImageDataURLSize() {
+		return challenge.ImageDataURL(), nil
+	}
+
+	return configuration.ImageStore.StoreImage(challenge.Image, imageContentType(challenge.Image))
+}
+
+/*
+ImageStore saves challenge images too large to inline as data URLs, such as to a blob store serving them over HTTPS, returning the URL they can be fetched from.
+*/
+type ImageStore interface {
+	StoreImage(image []byte, contentType string) (url string, err error)
+}
+
+/*
+ImageStoreFunc adapts a function to an ImageStore.
+*/
+type ImageStoreFunc func(image []byte, contentType string) (string, error)
+
+func (f ImageStoreFunc) StoreImage(image []byte, contentType string) (string, error) {
+	return f(image, contentType)
+}
+
+/*
+Default size in bytes above which challenge images are stored with the ImageStore rather than inlined.
+*/
+const DefaultMaxImageDataURLSize = 32 * 1024
+
+func (c *Configuration) maxImageDataURLSize() int {
+	if c.MaxImageDataURLSize > 0 {
+		return c.MaxImageDataURLSize
+	}
+
+	return DefaultMaxImageDataURLSize
+}
+
+func imageContentType(b []byte) string {
+	t := http.DetectContentType(b)
+	if !strings.HasPrefix(t, "image/") {
+		return "application/octet-stream"
+	}
+
+	return t
+}
+
+/*
+Split a challenge prompt into its question text or image. Image prompts are delivered as base64 encoded image data, either bare or as an "image" field.
+*/
+func parseChallengePrompt(val interface{}) (question string, image []byte) {
+	switch v := val.(type) {
+	case string:
+		if b := decodeChallengeImage(v); b != nil {
+			return "", b
+		}
+		return v, nil
+	case map[string]interface{}:
+		if s, ok := v["image"].(string); ok {
+			b, _ := base64.StdEncoding.DecodeString(s)
+			return "", b
+		}
+		if s, ok := v["text"].(string); ok {
+			return s, nil
+		}
+	}
+
+	return fmt.Sprint(val), nil
+}
+
+func decodeChallengeImage(s string) []byte {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil
+	}
+
+	if !strings.HasPrefix(http.DetectContentType(b), "image/") {
+		return nil
+	}
+
+	return b
+}
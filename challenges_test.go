@@ -0,0 +1,128 @@
+package intuit
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var gifPixel = []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")
+
+func TestChallengeKind(t *testing.T) {
+	assert.Equal(t, TextChallenge, Challenge{Question: "Mother's maiden name?"}.Kind())
+	assert.Equal(t, ChoiceChallenge, Challenge{Question: "City?", Choices: []Choice{{Value: "1", Text: "Paris"}}}.Kind())
+	assert.Equal(t, ImageChallenge, Challenge{Image: gifPixel}.Kind())
+}
+
+func TestParseChallengePromptImage(t *testing.T) {
+	question, image := parseChallengePrompt(base64.StdEncoding.EncodeToString(gifPixel))
+	assert.Equal(t, "", question)
+	assert.Equal(t, gifPixel, image)
+
+	question, image = parseChallengePrompt("What is your favorite color?")
+	assert.Equal(t, "What is your favorite color?", question)
+	assert.Nil(t, image)
+}
+
+func TestChallengeSessionValidate(t *testing.T) {
+	choice := Challenge{Question: "City?", Choices: []Choice{{Value: "1", Text: "Paris"}, {Value: "2", Text: "Rome"}}}
+	text := Challenge{Question: "Color?"}
+	session := &ChallengeSession{Challenges: []Challenge{choice, text}}
+
+	session.Answers = []Answer{ChoiceAnswer(choice.Choices[1]), TextAnswer("blue")}
+	assert.NoError(t, session.Validate())
+
+	session.Answers = []Answer{TextAnswer("Rome"), TextAnswer("blue")}
+	assert.Error(t, session.Validate())
+
+	session.Answers = []Answer{ChoiceAnswer(choice.Choices[0]), TextAnswer(" ")}
+	assert.Error(t, session.Validate())
+
+	session.Answers = []Answer{ChoiceAnswer(choice.Choices[0])}
+	assert.Error(t, session.Validate())
+}
+
+func TestAnswerEscaping(t *testing.T) {
+	b, err := xml.Marshal(ChallengeResponse{Answer: TextAnswer("AT&T <Wireless>").innerXML(), XMLNS: ChallengeXMLNS})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), ">AT&amp;T &lt;Wireless&gt;</v11:response>")
+
+	b, err = xml.Marshal(ChallengeResponse{Answer: RawAnswer("<b>1</b>").innerXML(), XMLNS: ChallengeXMLNS})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "><b>1</b></v11:response>")
+}
+
+func TestChallengeImageURL(t *testing.T) {
+	c := Challenge{Image: gifPixel}
+	assert.Equal(t, "data:image/gif;base64,"+base64.StdEncoding.EncodeToString(gifPixel), c.ImageDataURL())
+	assert.Equal(t, "", Challenge{Question: "City?"}.ImageDataURL())
+
+	previous := SessionConfiguration
+	defer func() { SessionConfiguration = previous }()
+
+	var stored string
+	SessionConfiguration = &Configuration{
+		MaxImageDataURLSize: 8,
+		ImageStore: ImageStoreFunc(func(image []byte, contentType string) (string, error) {
+			stored = contentType
+			return "https://blobs.example.com/1", nil
+		}),
+	}
+
+	u, err := c.ImageURL()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://blobs.example.com/1", u)
+	assert.Equal(t, "image/gif", stored)
+
+	SessionConfiguration.MaxImageDataURLSize = 0
+	u, err = c.ImageURL()
+	assert.NoError(t, err)
+	assert.Equal(t, c.ImageDataURL(), u)
+
+	client := NewClient(Configuration{
+		MaxImageDataURLSize: 8,
+		ImageStore: ImageStoreFunc(func(image []byte, contentType string) (string, error) {
+			return "https://blobs.example.com/2", nil
+		}),
+	})
+	SessionConfiguration = nil
+
+	u, err = c.ImageURL()
+	assert.NoError(t, err)
+	assert.Equal(t, c.ImageDataURL(), u)
+
+	u, err = client.ImageURL(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://blobs.example.com/2", u)
+}
+
+func TestMultiPartAnswers(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		responses := strings.Split(string(body), "<v11:response")
+		assert.Equal(t, 5, len(responses))
+		assert.Contains(t, responses[1], ">1</v11:response>")
+		assert.Contains(t, responses[2], ">3</v11:response>")
+		assert.Contains(t, responses[3], ">2</v11:response>")
+		assert.Contains(t, responses[4], ">blue</v11:response>")
+		w.Write([]byte(`{"accounts": []}`))
+	})
+	defer done()
+
+	grid := Challenge{Question: "Select your images in order", Choices: []Choice{{Value: "1"}, {Value: "2"}, {Value: "3"}}}
+	session := &ChallengeSession{InstitutionId: "100000", contextType: discoverAndAddType}
+	session.Challenges = []Challenge{grid, {Question: "Favorite color?"}}
+
+	session.Answers = []Answer{ChoiceAnswers(grid.Choices[0], grid.Choices[2], Choice{Value: "4"}), TextAnswer("blue")}
+	assert.Error(t, session.Validate())
+
+	session.Answers = []Answer{ChoiceAnswers(grid.Choices[0], grid.Choices[2], grid.Choices[1]), TextAnswer("blue")}
+	assert.Equal(t, []string{"1", "3", "2"}, session.Answers[0].Values())
+	_, next, err := session.Respond()
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+}
@@ -0,0 +1,240 @@
+package intuit
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+/*
+Lifetime of stored challenge sessions. Intuit expires a challenge session a few minutes after issuing it, after which answering fails and the login must be retried.
+*/
+const DefaultChallengeTTL = 5 * time.Minute
+
+/*
+Returned when loading a challenge session which was never stored, has been answered or has expired.
+*/
+var ErrChallengeExpired = errors.New("intuit: challenge session expired")
+
+/*
+ChallengeStore persists MFA challenge sessions between asking a user the questions and receiving their answers, which in a web backend are separate requests and may be handled by different instances. Sessions should be discarded once ttl has passed.
+
+Sessions encode to JSON with MarshalJSON, so a store only needs to keep bytes. MemoryChallengeStore serves a single instance; the redisstore package shares sessions between instances.
+*/
+type ChallengeStore interface {
+	Save(key string, session *ChallengeSession, ttl time.Duration) error
+
+	// Return ErrChallengeExpired if there is no live session for key.
+	Load(key string) (*ChallengeSession, error)
+
+	Delete(key string) error
+}
+
+type challengeSessionJSON struct {
+	InstitutionId string      `json:"institutionId"`
+	LoginId       string      `json:"loginId,omitempty"`
+	SessionId     string      `json:"sessionId"`
+	NodeId        string      `json:"nodeId"`
+	Challenges    []Challenge `json:"challenges"`
+	Answers       []Answer    `json:"answers,omitempty"`
+	Update        bool        `json:"update,omitempty"`
+}
+
+/*
+Encode the session, including whether it continues a discover or an update, so it can be answered after being restored.
+*/
+func (s *ChallengeSession) MarshalJSON() ([]byte, error) {
+	return json.Marshal(challengeSessionJSON{
+		InstitutionId: s.InstitutionId,
+		LoginId:       s.LoginId,
+		SessionId:     s.SessionId,
+		NodeId:        s.NodeId,
+		Challenges:    s.Challenges,
+		Answers:       s.Answers,
+		Update:        s.contextType == updateLoginType,
+	})
+}
+
+func (s *ChallengeSession) UnmarshalJSON(b []byte) error {
+	var v challengeSessionJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = ChallengeSession{
+		InstitutionId: v.InstitutionId,
+		LoginId:       v.LoginId,
+		SessionId:     v.SessionId,
+		NodeId:        v.NodeId,
+		Challenges:    v.Challenges,
+		Answers:       v.Answers,
+		contextType:   discoverAndAddType,
+	}
+	if v.Update {
+		s.contextType = updateLoginType
+	}
+
+	return nil
+}
+
+/*
+MemoryChallengeStore keeps challenge sessions in memory, for a single instance.
+*/
+type MemoryChallengeStore struct {
+	mutex    sync.Mutex
+	sessions map[string]memoryChallenge
+}
+
+type memoryChallenge struct {
+	data    []byte
+	expires time.Time
+}
+
+func NewMemoryChallengeStore() *MemoryChallengeStore {
+	return &MemoryChallengeStore{sessions: make(map[string]memoryChallenge)}
+}
+
+func (m *MemoryChallengeStore) Save(key string, session *ChallengeSession, ttl time.Duration) error {
+	b, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	for k, s := range m.sessions {
+		if now.After(s.expires) {
+			delete(m.sessions, k)
+		}
+	}
+	m.sessions[key] = memoryChallenge{data: b, expires: now.Add(ttl)}
+	return nil
+}
+
+func (m *MemoryChallengeStore) Load(key string) (*ChallengeSession, error) {
+	m.mutex.Lock()
+	s, ok := m.sessions[key]
+	m.mutex.Unlock()
+
+	if !ok || time.Now().After(s.expires) {
+		return nil, ErrChallengeExpired
+	}
+
+	session := &ChallengeSession{}
+	return session, json.Unmarshal(s.data, session)
+}
+
+func (m *MemoryChallengeStore) Delete(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.sessions, key)
+	return nil
+}
+
+/*
+ChallengeManager hands out keys for MFA challenge sessions and answers them later by key, keeping the sessions in a ChallengeStore.
+
+	manager := &intuit.ChallengeManager{Store: redisstore.New("localhost:6379")}
+
+	_, session, err := intuit.DiscoverAndAddAccounts(...)
+	key, err := manager.Put(session)
+	// Send session.Challenges and key to the user; later, possibly on another instance:
+	data, next, err := manager.Respond(key, answers)
+*/
+type ChallengeManager struct {
+	Store ChallengeStore
+
+	// Lifetime of stored sessions. Defaults to DefaultChallengeTTL.
+	TTL time.Duration
+}
+
+func (m *ChallengeManager) ttl() time.Duration {
+	if m.TTL > 0 {
+		return m.TTL
+	}
+
+	return DefaultChallengeTTL
+}
+
+/*
+Store a session under a new random key.
+*/
+func (m *ChallengeManager) Put(session *ChallengeSession) (string, error) {
+	key, err := RandomNonce()
+	if err != nil {
+		return "", err
+	}
+
+	return key, m.Store.Save(key, session, m.ttl())
+}
+
+/*
+Return the session stored under key, or ErrChallengeExpired.
+*/
+func (m *ChallengeManager) Get(key string) (*ChallengeSession, error) {
+	return m.Store.Load(key)
+}
+
+/*
+Answer the session stored under key. When the institution asks further questions, the next session replaces it under the same key, with a fresh TTL; otherwise it is deleted.
+*/
+func (m *ChallengeManager) Respond(key string, answers []Answer) (data interface{}, next *ChallengeSession, err error) {
+	session, err := m.Store.Load(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session.Answers = answers
+	data, next, err = session.Respond()
+	if err != nil {
+		return
+	}
+
+	if next != nil {
+		err = m.Store.Save(key, next, m.ttl())
+	} else {
+		err = m.Store.Delete(key)
+	}
+
+	return
+}
+
+/*
+Return a ChallengeStore keeping sessions in kv under "challenge/", so they share the persistence configured for the rest of the package.
+*/
+func NewKVChallengeStore(kv KV) ChallengeStore {
+	return kvChallengeStore{kv}
+}
+
+type kvChallengeStore struct {
+	kv KV
+}
+
+func (s kvChallengeStore) Save(key string, session *ChallengeSession, ttl time.Duration) error {
+	b, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return s.kv.Set("challenge/"+key, b, ttl)
+}
+
+func (s kvChallengeStore) Load(key string) (*ChallengeSession, error) {
+	b, err := s.kv.Get("challenge/" + key)
+	if err == ErrKeyNotFound {
+		return nil, ErrChallengeExpired
+	} else if err != nil {
+		return nil, err
+	}
+
+	session := &ChallengeSession{}
+	return session, json.Unmarshal(b, session)
+}
+
+func (s kvChallengeStore) Delete(key string) error {
+	return s.kv.Delete("challenge/" + key)
+}
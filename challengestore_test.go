@@ -0,0 +1,82 @@
+package intuit
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestChallengeSessionJSON(t *testing.T) {
+	session := &ChallengeSession{InstitutionId: "100000", LoginId: "9", SessionId: "session", NodeId: "node", contextType: updateLoginType}
+	session.Challenges = []Challenge{{Question: "Pick a city", Choices: []Choice{{Value: "1", Text: "Paris"}}}}
+
+	b, err := json.Marshal(session)
+	assert.NoError(t, err)
+
+	restored := &ChallengeSession{}
+	assert.NoError(t, json.Unmarshal(b, restored))
+	assert.Equal(t, session, restored)
+}
+
+func TestMemoryChallengeStore(t *testing.T) {
+	store := NewMemoryChallengeStore()
+	assert.NoError(t, store.Save("a", &ChallengeSession{SessionId: "a"}, time.Minute))
+	assert.NoError(t, store.Save("b", &ChallengeSession{SessionId: "b"}, time.Nanosecond))
+	time.Sleep(time.Millisecond)
+
+	session, err := store.Load("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", session.SessionId)
+
+	_, err = store.Load("b")
+	assert.Equal(t, ErrChallengeExpired, err)
+
+	assert.NoError(t, store.Delete("a"))
+	_, err = store.Load("a")
+	assert.Equal(t, ErrChallengeExpired, err)
+}
+
+func TestChallengeManager(t *testing.T) {
+	rounds := 0
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		rounds++
+		assert.Equal(t, "/institutions/100000/logins", r.URL.Path)
+
+		if rounds == 1 {
+			assert.Equal(t, "node-1", r.Header.Get("challengeNodeId"))
+			w.Header().Set("challengeSessionId", "session")
+			w.Header().Set("challengeNodeId", "node-2")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"challenge": [{"textOrImageAndChoice": ["Mother's maiden name?"]}]}`))
+			return
+		}
+
+		assert.Equal(t, "node-2", r.Header.Get("challengeNodeId"))
+		w.Write([]byte(`{"accounts": []}`))
+	})
+	defer done()
+
+	manager := &ChallengeManager{Store: NewMemoryChallengeStore()}
+	session := &ChallengeSession{InstitutionId: "100000", SessionId: "session", NodeId: "node-1", contextType: discoverAndAddType}
+	session.Challenges = []Challenge{{Question: "Favorite color?"}}
+
+	key, err := manager.Put(session)
+	assert.NoError(t, err)
+
+	_, next, err := manager.Respond(key, []Answer{TextAnswer("blue")})
+	assert.NoError(t, err)
+	assert.Equal(t, "Mother's maiden name?", next.Challenges[0].Question)
+
+	stored, err := manager.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "node-2", stored.NodeId)
+
+	_, next, err = manager.Respond(key, []Answer{TextAnswer("Smith")})
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+
+	_, err = manager.Get(key)
+	assert.Equal(t, ErrChallengeExpired, err)
+}
@@ -1,70 +1,388 @@
 package intuit
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
-	"fmt"
-	"github.com/MattNewberry/oauth"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 )
 
-func post(endpoint string, body interface{}, params map[string]string, headers map[string][]string) (interface{}, error) {
-	return request(POST, endpoint, body, params, headers)
+/*
+Client makes API calls with its own configuration, so that one process can serve several applications, or several customers with different settings, concurrently.
+
+	client := intuit.NewClient(intuit.Configuration{OAuthConsumerKey: "...", ...})
+	accounts, err := client.Accounts()
+
+The package-level functions are wrappers calling the same methods on a client using SessionConfiguration, as set with Configure. Functions taking a context, such as Get and TransactionsChan, use the client whose Context it was derived from.
+
+Clients share the in-memory institution and account caches, with entries kept apart by OAuthConsumerKey as well as institution and customer Id.
+*/
+type Client struct {
+	configuration *Configuration
+}
+
+/*
+Return a client for a configuration. The configuration is copied, so later changes to it do not affect the client; the client obtains its own OAuth tokens.
+*/
+func NewClient(configuration Configuration) *Client {
+	configuration.tokens = nil
+	configuration.reportedDeprecations = nil
+	configuration.reads = nil
+	configuration.pinned = nil
+	configuration.deprecations()
+	return &Client{configuration: &configuration}
+}
+
+/*
+Return the client used by the package-level functions, which follows SessionConfiguration.
+*/
+func defaultClient() *Client {
+	return &Client{}
 }
 
-func get(endpoint string, params map[string]string) (interface{}, error) {
-	return request(GET, endpoint, "", params, nil)
+/*
+Return the client's configuration.
+*/
+func (c *Client) Configuration() *Configuration {
+	if c.configuration == nil {
+		return SessionConfiguration
+	}
+
+	return c.configuration
 }
 
-func put(endpoint string, body interface{}, params map[string]string, headers map[string][]string) (interface{}, error) {
-	return request(PUT, endpoint, body, params, headers)
+type clientKey struct{}
+
+/*
+Return a context whose calls use the client's configuration, for functions which take a context rather than being methods, such as Get.
+*/
+func (c *Client) Context(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.configuration == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, clientKey{}, c.configuration)
 }
 
-func request(method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (data interface{}, err error) {
-	if SessionConfiguration.oAuthToken == nil {
-		SessionConfiguration.oAuthToken, err = MakeSamlAssertion()
+func (c *Client) background() context.Context {
+	return c.Context(context.Background())
+}
 
-		if err != nil {
-			return
+/*
+Return the configuration calls made with ctx use: that of the client it was derived from, otherwise SessionConfiguration.
+*/
+func configurationFor(ctx context.Context) *Configuration {
+	if ctx != nil {
+		if c, ok := ctx.Value(clientKey{}).(*Configuration); ok {
+			return c
 		}
 	}
 
-	c := oauth.NewConsumer(
-		SessionConfiguration.OAuthConsumerKey,
-		SessionConfiguration.OAuthConsumerSecret,
-		oauth.ServiceProvider{})
-	c.AdditionalHeaders = map[string][]string{
-		"Accept":       []string{"application/json"},
-		"Content-Type": []string{"application/xml"},
+	return SessionConfiguration
+}
+
+func post(ctx context.Context, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (interface{}, error) {
+	return request(ctx, POST, endpoint, body, params, headers)
+}
+
+func get(ctx context.Context, endpoint string, params map[string]string) (interface{}, error) {
+	return request(ctx, GET, endpoint, nil, params, nil)
+}
+
+/*
+Perform a signed request against an endpoint relative to BaseURL, returning the decoded JSON response.
+
+This is a low-level escape hatch for endpoints which are not otherwise modeled by this package. Any method may carry params, headers and a body; a non-nil body is encoded as XML.
+*/
+func Do(method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (interface{}, error) {
+	return defaultClient().Do(method, endpoint, body, params, headers)
+}
+
+/*
+Perform a signed request against an endpoint relative to BaseURL. See Do.
+*/
+func (c *Client) Do(method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (interface{}, error) {
+	return request(c.background(), method, endpoint, body, params, headers)
+}
+
+func request(ctx context.Context, method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (data interface{}, err error) {
+	data, _, err = exchange(ctx, method, endpoint, body, params, headers)
+	return
+}
+
+/*
+Perform a request, returning the decoded JSON response along with the response headers.
+*/
+func exchange(ctx context.Context, method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (data interface{}, header http.Header, err error) {
+	_, data, header, err = exchangeBody(ctx, method, endpoint, body, params, headers)
+	return
+}
+
+/*
+Perform a request like exchange, also returning the response body for decoding into typed results.
+*/
+func exchangeBody(ctx context.Context, method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (b []byte, data interface{}, header http.Header, err error) {
+	defer recoverInternal(method, endpoint, "", &err)
+
+	res, err := send(ctx, method, endpoint, body, params, headers)
+	if err != nil {
+		if apiError, ok := err.(*APIError); ok {
+			data = apiError.Data
+			header = apiError.Header
+		}
+		return nil, data, header, err
+	}
+
+	defer res.Body.Close()
+	b, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, res.Header, &TransportError{Method: method, Endpoint: endpoint, Err: err, RequestId: requestId(res)}
+	}
+
+	if err = decodeBody(b, &data); err != nil {
+		return nil, nil, res.Header, &DecodeError{Method: method, Endpoint: endpoint, StatusCode: res.StatusCode, Body: b, Err: err, IntuitTid: intuitTid(res.Header), RequestId: requestId(res)}
 	}
 
-	for k, v := range headers {
-		c.AdditionalHeaders[k] = v
+	return b, data, res.Header, nil
+}
+
+/*
+Perform a request and decode the JSON response into v. An empty response, as sent with 204 No Content, leaves v unchanged.
+*/
+func fetch(ctx context.Context, method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string, v interface{}) (err error) {
+	defer recoverInternal(method, endpoint, "", &err)
+
+	res, err := send(ctx, method, endpoint, body, params, headers)
+	if err != nil {
+		return err
 	}
 
-	url := fmt.Sprintf("%s%s", BaseURL, endpoint)
-	var res *http.Response
+	defer res.Body.Close()
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return &TransportError{Method: method, Endpoint: endpoint, Err: err, RequestId: requestId(res)}
+	}
 
-	if method == GET {
-		res, err = c.Get(url, params, SessionConfiguration.oAuthToken)
-	} else if method == POST {
-		payload, _ := xml.MarshalIndent(body, "  ", "    ")
-		res, err = c.Post(url, string(payload), params, SessionConfiguration.oAuthToken)
-	} else if method == PUT {
-		payload, _ := xml.MarshalIndent(body, "  ", "    ")
-		res, err = c.Put(url, string(payload), params, SessionConfiguration.oAuthToken)
-	} else if method == DELETE {
-		res, err = c.Delete(url, params, SessionConfiguration.oAuthToken)
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+
+	if err = decodeTyped(configurationFor(ctx), endpoint, b, v); err != nil {
+		decodeError := &DecodeError{Method: method, Endpoint: endpoint, StatusCode: res.StatusCode, Body: b, Err: err, IntuitTid: intuitTid(res.Header), RequestId: requestId(res), Partial: v}
+		var raw interface{}
+		if decodeBody(b, &raw) == nil {
+			decodeError.Raw = raw
+		}
+		return decodeError
 	}
 
-	if err == nil {
-		d := json.NewDecoder(res.Body)
-		d.UseNumber()
-		err = d.Decode(&data)
-	} else {
-		httpError := err.(oauth.HTTPExecuteError)
-		json.Unmarshal(httpError.ResponseBodyBytes, &data)
+	if c, ok := v.(correlated); ok {
+		c.setIntuitTid(intuitTid(res.Header))
+	}
+
+	return nil
+}
+
+/*
+Run a request through the middleware pipeline, returning the undecoded response on success. Panics anywhere in the pipeline are returned as an InternalError.
+*/
+func send(ctx context.Context, method string, endpoint string, body interface{}, params map[string]string, headers map[string][]string) (res *http.Response, err error) {
+	configuration := configurationFor(ctx)
+	id, err := configuration.newRequestId()
+	if err != nil {
+		return nil, err
+	}
+	defer recoverInternal(method, endpoint, id, &err)
+
+	req := &Request{
+		Context:   ctx,
+		Method:    method,
+		Endpoint:  endpoint,
+		Body:      body,
+		Params:    params,
+		Header:    headers,
+		RequestId: id,
+	}
+
+	res, err = pipeline(configuration.Middleware)(req)
+	if method != GET {
+		// Whether or not it succeeded, a change may have reached Intuit.
+		configuration.invalidateAccounts(customerFor(ctx))
+	}
+
+	return res, err
+}
+
+/*
+Sign and execute a request. This is the innermost handler of the pipeline, encoding the body and translating failures into TransportError and APIError values.
+
+Every method is handled the same way: params are sent in the query string, headers are added to the request and a non-nil body is encoded as XML.
+*/
+func transport(req *Request) (*http.Response, error) {
+	configuration := configurationFor(req.Context)
+
+	u, err := url.Parse(configuration.baseURL() + req.Endpoint)
+	if err != nil {
+		return nil, &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: err, RequestId: req.RequestId}
+	}
+
+	query := u.Query()
+	for k, v := range req.Params {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	var payload []byte
+	if hasBody(req.Body) {
+		if payload, err = xml.MarshalIndent(req.Body, "  ", "    "); err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	httpReq, err := http.NewRequest(req.Method, u.String(), body)
+	if err != nil {
+		return nil, &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: err, RequestId: req.RequestId}
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Content-Type", "application/xml")
+	if req.RequestId != "" {
+		httpReq.Header.Set(RequestIdHeader, req.RequestId)
+	}
+	for k, vs := range req.Header {
+		httpReq.Header.Del(k)
+		for _, v := range vs {
+			httpReq.Header.Add(k, v)
+		}
+	}
+
+	s, err := newSigner(configuration, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.sign(httpReq); err != nil {
+		return nil, err
+	}
+
+	wire := configuration.WireLog
+	if wire != nil {
+		logWireRequest(wire, req, httpReq, payload)
+	}
+
+	res, err := configuration.httpClient().Do(httpReq.WithContext(req.Context))
+	if err != nil {
+		if req.Context.Err() != nil {
+			err = req.Context.Err()
+		}
+		return nil, &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: err, RequestId: req.RequestId}
+	}
+
+	tid := intuitTid(res.Header)
+	logIntuitTid(req, tid)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		defer res.Body.Close()
+		b, _ := ioutil.ReadAll(res.Body)
+		if wire != nil {
+			logWireResponse(wire, req, res, b)
+		}
+
+		apiError := &APIError{
+			Method:     req.Method,
+			Endpoint:   req.Endpoint,
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+			Body:       b,
+			Header:     res.Header,
+			IntuitTid:  tid,
+			RequestId:  req.RequestId,
+
+			configuration: configuration,
+		}
+		var data interface{}
+		if decodeBody(b, &data) == nil {
+			apiError.Data = data
+			apiError.Code = errorCode(data)
+			apiError.Hint = ErrorHints[apiError.Code]
+		}
+		return nil, apiError
+	}
+
+	if wire != nil {
+		logWireResponse(wire, req, res, readWireBody(res))
+	}
+
+	return res, nil
+}
+
+func hasBody(body interface{}) bool {
+	if body == nil {
+		return false
+	}
+
+	s, ok := body.(string)
+	return !ok || s != ""
+}
+
+/*
+Return the intuit_tid header identifying a request to Intuit support.
+*/
+func intuitTid(header http.Header) string {
+	return headerValue(header, "intuit_tid")
+}
+
+func logIntuitTid(req *Request, tid string) {
+	if c := configurationFor(req.Context); c.LogIntuitTid && tid != "" {
+		c.logf("intuit: %s %s intuit_tid=%s request_id=%s", req.Method, req.Endpoint, tid, req.RequestId)
+	}
+}
+
+/*
+Header carrying the client-generated Id of each call.
+*/
+const RequestIdHeader = "X-Request-Id"
+
+/*
+Return the client-generated Id sent with the request a response answers.
+*/
+func requestId(res *http.Response) string {
+	if res == nil || res.Request == nil {
+		return ""
+	}
+
+	return res.Request.Header.Get(RequestIdHeader)
+}
+
+func (c *Configuration) newRequestId() (string, error) {
+	if c.RequestIdGenerator != nil {
+		return c.RequestIdGenerator()
+	}
+
+	return RandomNonce()
+}
+
+/*
+Implemented by typed results which record the intuit_tid of the response they were decoded from.
+*/
+type correlated interface {
+	setIntuitTid(tid string)
+}
+
+func decodeBody(b []byte, data *interface{}) error {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
 	}
 
-	return data, err
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(data)
 }
@@ -0,0 +1,229 @@
+package intuit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/*
+Point the session at a stub API server, with a token already in place so no SAML exchange is attempted.
+*/
+func configureStubAPI(t *testing.T, handler http.HandlerFunc) func() {
+	server := httptest.NewServer(handler)
+	previous := SessionConfiguration
+	Configure(&Configuration{
+		OAuthConsumerKey:    "consumer",
+		OAuthConsumerSecret: "secret",
+		BaseURL:             server.URL + "/",
+		tokens:              map[string]*AccessToken{"": {Token: "token", Secret: "secret"}},
+	})
+
+	return func() {
+		server.Close()
+		SessionConfiguration = previous
+	}
+}
+
+func TestDeleteWithBodyAndHeaders(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		assert.Equal(t, DELETE, r.Method)
+		assert.Equal(t, "/logins/1", r.URL.Path)
+		assert.Equal(t, "yes", r.URL.Query().Get("force"))
+		assert.Equal(t, "value", r.Header.Get("X-Custom"))
+		assert.Contains(t, r.Header.Get("Authorization"), `oauth_token="token"`)
+		assert.Contains(t, string(body), "<InstitutionLogin")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer done()
+
+	payload := &InstitutionLogin{XMLNS: InstitutionXMLNS}
+	data, err := Do(DELETE, "logins/1", payload, map[string]string{"force": "yes"}, map[string][]string{"X-Custom": {"value"}})
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestAPIError(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("intuit_tid", "abc-123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errorInfo": [{"errorType": "APP_ERROR", "errorCode": "104"}]}`))
+	})
+	defer done()
+
+	_, err := Do(GET, "accounts/1", nil, nil, nil)
+	apiError, ok := err.(*APIError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, apiError.StatusCode)
+	assert.Equal(t, "abc-123", apiError.IntuitTid)
+	assert.NotNil(t, apiError.Data)
+	assert.Equal(t, http.StatusNotFound, StatusCode(fmt.Errorf("wrapped: %w", err)))
+}
+
+func TestStatusCode(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{not json`))
+	})
+	defer done()
+
+	_, err := Do(GET, "accounts", nil, nil, nil)
+	assert.IsType(t, &DecodeError{}, err)
+	assert.Equal(t, http.StatusOK, StatusCode(err))
+
+	assert.Equal(t, 0, StatusCode(&TransportError{Err: errors.New("connection refused")}))
+	assert.Equal(t, 0, StatusCode(ErrNotAuthenticated))
+}
+
+func TestAPIErrorHint(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errorInfo": {"errorType": "AGG_ERROR", "errorCode": 103}}`))
+	})
+	defer done()
+
+	_, err := Do(GET, "logins/1/accounts", nil, nil, nil)
+	apiError := err.(*APIError)
+	assert.Equal(t, "103", apiError.Code)
+	assert.Equal(t, ErrorHints["103"], apiError.Hint)
+	assert.Equal(t, "intuit: GET logins/1/accounts: 401 Unauthorized (code 103: the login credentials were rejected; the user must re-enter credentials)", err.Error())
+}
+
+func TestClient(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accounts": [{"accountId": 0}]}`))
+	})
+	defer done()
+
+	clients := make([]*Client, 2)
+	for i := range clients {
+		id := i + 1
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.Header.Get("Authorization"), fmt.Sprintf(`oauth_token="token-%d"`, id))
+			fmt.Fprintf(w, `{"accounts": [{"accountId": %d}]}`, id)
+		}))
+		defer server.Close()
+
+		clients[i] = NewClient(Configuration{
+			OAuthConsumerKey:    "consumer",
+			OAuthConsumerSecret: "secret",
+			BaseURL:             server.URL + "/",
+		})
+		clients[i].configuration.tokens = map[string]*AccessToken{"": {Token: fmt.Sprintf("token-%d", id), Secret: "secret"}}
+	}
+
+	results := make(chan error, 20)
+	for n := 0; n < 10; n++ {
+		for i, c := range clients {
+			go func(id int, c *Client) {
+				accounts, err := c.OpenAccounts()
+				if err == nil && accounts[0].AccountId.String() != fmt.Sprint(id) {
+					err = fmt.Errorf("client %d got account %s", id, accounts[0].AccountId)
+				}
+				results <- err
+			}(i+1, c)
+		}
+	}
+	for n := 0; n < 20; n++ {
+		assert.NoError(t, <-results)
+	}
+
+	// The package-level functions still use SessionConfiguration.
+	accounts, err := OpenAccounts()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0", accounts[0].AccountId.String())
+	}
+}
+
+func TestClientsKeepApart(t *testing.T) {
+	var fetches int32
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Write([]byte(`{"accounts": [{"accountId": 1}]}`))
+	})
+	defer done()
+	defer ClearAccountCache()
+
+	// A client does not inherit the tokens of the configuration it was copied from.
+	client := NewClient(*SessionConfiguration)
+	assert.Nil(t, client.configuration.tokens)
+	client.configuration.setToken("", &AccessToken{Token: "token", Secret: "secret"})
+
+	other := *SessionConfiguration
+	other.OAuthConsumerKey = "other"
+	otherClient := NewClient(other)
+	otherClient.configuration.setToken("", &AccessToken{Token: "token", Secret: "secret"})
+	defer otherClient.ClearAccountCache()
+
+	// Clients for the same application share cached accounts; another application's are its own.
+	_, _, err := CachedAccounts()
+	assert.NoError(t, err)
+	_, _, err = client.CachedAccounts()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	_, _, err = otherClient.CachedAccounts()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	// Clearing one application's cache leaves the other's.
+	otherClient.ClearAccountCache()
+	CachedAccounts()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func TestClientLocation(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {})
+	defer done()
+
+	pacific := time.FixedZone("PDT", -7*60*60)
+	evening := time.Date(2014, 5, 1, 20, 0, 0, 0, pacific).UTC()
+
+	queries := make(chan url.Values, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/accounts") {
+			w.Write([]byte(`{"accounts": [{"accountId": 1, "balanceDate": "2014-05-01"}]}`))
+			return
+		}
+		queries <- r.URL.Query()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Configuration{OAuthConsumerKey: "consumer", OAuthConsumerSecret: "secret", BaseURL: server.URL + "/", Location: pacific})
+	client.configuration.tokens = map[string]*AccessToken{"": {Token: "token", Secret: "secret"}}
+
+	// The session is in UTC, where the evening has already become the next day.
+	assert.Equal(t, "2014-05-02", FormatQueryDate(evening))
+	assert.Equal(t, "2014-05-01", client.FormatQueryDate(evening))
+
+	_, err := client.Transactions("1", evening, evening)
+	assert.NoError(t, err)
+	q := <-queries
+	assert.Equal(t, "2014-05-01", q.Get("txnStartDate"))
+	assert.Equal(t, "2014-05-01", q.Get("txnEndDate"))
+
+	d, err := client.ParseDate("2014-05-01")
+	assert.NoError(t, err)
+	assert.True(t, d.Equal(time.Date(2014, 5, 1, 0, 0, 0, 0, pacific)))
+
+	// Dates without a zone in responses are in the client's Location, not the session's.
+	accounts, err := client.Accounts()
+	if assert.NoError(t, err) && assert.Len(t, accounts, 1) {
+		assert.True(t, accounts[0].Common().BalanceDate.Equal(time.Date(2014, 5, 1, 0, 0, 0, 0, pacific)))
+	}
+
+	typed, err := client.NewTypedAccounts(map[string]interface{}{"accounts": []interface{}{map[string]interface{}{"accountId": 1, "balanceDate": "2014-05-01"}}})
+	if assert.NoError(t, err) && assert.Len(t, typed, 1) {
+		assert.True(t, typed[0].Common().BalanceDate.Equal(time.Date(2014, 5, 1, 0, 0, 0, 0, pacific)))
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"github.com/MattNewberry/intuit/prompt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+/*
+The number of search results offered to choose from.
+*/
+const searchResults = 10
+
+type institution struct {
+	InstitutionId   json.Number `json:"institutionId"`
+	InstitutionName string      `json:"institutionName"`
+	HomeURL         string      `json:"homeUrl"`
+}
+
+/*
+Walk through linking a login: find the institution, collect its credentials, answer any MFA challenges and print the accounts added.
+*/
+func connect(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("connect", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print the accounts as JSON instead of a table")
+	institutionId := flags.String("institution", "", "Id of the institution, skipping the search")
+	flags.Parse(args)
+
+	p := prompt.New()
+	p.Out = out
+
+	id := *institutionId
+	if id == "" {
+		chosen, err := chooseInstitution(p, strings.Join(flags.Args(), " "))
+		if err != nil {
+			return err
+		}
+		id = chosen.InstitutionId.String()
+	}
+
+	details, err := intuit.Institution(id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Signing in to %s\n", details.InstitutionName)
+	credentials, err := p.Credentials(details)
+	if err != nil {
+		return err
+	}
+
+	accounts, session, err := intuit.DiscoverAndAddAccountsWithCredentials(id, credentials)
+	result := map[string]interface{}{"accounts": accounts}
+
+	if session != nil {
+		var response interface{}
+		response, err = intuit.RenderChallenges(context.Background(), session, p)
+		if m, ok := response.(map[string]interface{}); ok && err == nil {
+			result = m
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	discovered, err := intuit.NewDiscoverResult(result)
+	if err != nil {
+		return err
+	}
+
+	return printAccounts(out, discovered, *asJSON)
+}
+
+/*
+Search the institutions by name, asking for a query until it matches, and let the user pick one of the best matches.
+*/
+func chooseInstitution(p *prompt.Prompter, query string) (*institution, error) {
+	all, err := institutions()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if query == "" {
+			if query, err = p.Field(intuit.InstitutionKey{Description: "Search institutions"}); err != nil {
+				return nil, err
+			}
+		}
+
+		matches := search(all, query, searchResults)
+		if len(matches) == 0 {
+			fmt.Fprintf(p.Out, "No institutions match %q.\n", query)
+			query = ""
+			continue
+		}
+
+		for i, m := range matches {
+			fmt.Fprintf(p.Out, "  %2d) %s  %s\n", i+1, m.InstitutionName, m.HomeURL)
+		}
+
+		choice, err := p.Field(intuit.InstitutionKey{Description: fmt.Sprintf("Institution [1-%d, or a new search]", len(matches))})
+		if err != nil {
+			return nil, err
+		}
+
+		if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(matches) {
+			return &matches[n-1], nil
+		}
+		query = choice
+	}
+}
+
+func institutions() ([]institution, error) {
+	data, err := intuit.Do(intuit.GET, "institutions", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Institution []institution `json:"institution"`
+	}
+	if err = json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+	if len(list.Institution) == 0 {
+		return nil, errors.New("no institutions are available")
+	}
+
+	return list.Institution, nil
+}
+
+func printAccounts(out io.Writer, result *intuit.DiscoverResult, asJSON bool) error {
+	if asJSON {
+		e := json.NewEncoder(out)
+		e.SetIndent("", "  ")
+		return e.Encode(result.Accounts)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tNUMBER\tTYPE\tBALANCE\tSTATUS")
+	for _, a := range result.Accounts {
+		name := a.AccountNickname
+		if name == "" {
+			name = a.Description
+		}
+
+		currency := a.CurrencyCode
+		if currency == "" {
+			currency = "USD"
+		}
+
+		status := "added"
+		if !a.Added() {
+			status = "failed"
+			if code := a.StatusCode(); code != "" {
+				status += " (" + code + ")"
+			}
+			if a.ErrorInfo != nil && a.ErrorInfo.ErrorMessage != "" {
+				status += ": " + a.ErrorInfo.ErrorMessage
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", a.AccountId, name, a.AccountNumber, a.Subtype(), a.BalanceAmount.Format(currency, intuit.DefaultLocale), status)
+	}
+
+	return w.Flush()
+}
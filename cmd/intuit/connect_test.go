@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"github.com/MattNewberry/intuit"
+	"github.com/MattNewberry/intuit/intuittest"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestConnect(t *testing.T) {
+	b := intuittest.NewBundle()
+	b.Institutions["100000"] = map[string]interface{}{
+		"institutionId":   "100000",
+		"institutionName": "CCBank-Beavercreek",
+		"homeUrl":         "http://www.example.com",
+		"keys": []interface{}{
+			map[string]interface{}{"name": "Banking Userid", "description": "User ID", "displayFlag": true, "displayOrder": 1},
+			map[string]interface{}{"name": "Banking Password", "description": "Password", "displayFlag": true, "displayOrder": 2, "mask": true},
+		},
+	}
+	b.Institutions["100001"] = map[string]interface{}{"institutionId": "100001", "institutionName": "Chase"}
+	b.Accounts = append(b.Accounts, map[string]interface{}{
+		"accountId":       "75000033008",
+		"institutionId":   "100000",
+		"accountNickname": "My Checking",
+		"accountNumber":   "0000000001",
+		"balanceAmount":   1234.5,
+		"currencyCode":    "USD",
+	})
+
+	server := intuittest.NewServer(b)
+	defer server.Close()
+
+	configuration, err := server.Configuration()
+	assert.NoError(t, err)
+	previous := intuit.SessionConfiguration
+	intuit.Configure(configuration)
+	defer func() { intuit.SessionConfiguration = previous }()
+
+	r, w, _ := os.Pipe()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	w.WriteString("1\ndirect\ngo\n")
+	w.Close()
+
+	var out bytes.Buffer
+	assert.NoError(t, connect([]string{"beaver"}, &out))
+	assert.Contains(t, out.String(), " 1) CCBank-Beavercreek")
+	assert.Contains(t, out.String(), "Signing in to CCBank-Beavercreek")
+	assert.Contains(t, out.String(), "75000033008  My Checking  0000000001")
+	assert.Contains(t, out.String(), "$1,234.50")
+}
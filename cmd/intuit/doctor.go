@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"io"
+	"time"
+)
+
+/*
+Check the configuration step by step, printing a pass or fail line for each. Unlike other commands, doctor runs with an invalid configuration, to report what is wrong with it.
+*/
+func doctor(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	timeout := flags.Duration("timeout", 30*time.Second, "time allowed for all checks")
+	ntp := flags.String("ntp", intuit.NTPServer, "NTP server to measure clock skew against, as host:port")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	intuit.NTPServer = *ntp
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	d := intuit.Diagnose(ctx)
+	if err := d.Write(out); err != nil {
+		return err
+	}
+
+	if failed := len(d.Failed()); failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(d.Steps))
+	}
+
+	return nil
+}
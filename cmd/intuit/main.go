@@ -0,0 +1,128 @@
+/*
+Command intuit is a terminal client for Intuit's Customer Account Data API.
+
+Usage:
+
+	intuit [flags] <command> [arguments]
+
+The commands are:
+
+	connect         link an institution login interactively and list its accounts
+	transactions    watch an account and print new transactions as they post
+	doctor          check the key, clock and credentials and report what is wrong
+
+Credentials are read from flags, falling back to the INTUIT_CERTIFICATE, INTUIT_PUBLIC_CERTIFICATE, INTUIT_CONSUMER_KEY, INTUIT_CONSUMER_SECRET, INTUIT_SAML_PROVIDER_ID and INTUIT_CUSTOMER_ID environment variables.
+With -demo, every command runs against a built-in fake server holding demo accounts and transactions, so the flows can be shown without any Intuit credentials. Sign in to the DAG Site with the username direct, or tfa_text or tfa_choice to be asked an MFA question.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"github.com/MattNewberry/intuit/intuittest"
+	"io"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, out io.Writer) error
+
+	// Run without validating the configuration first.
+	unvalidated bool
+}
+
+var commands = []command{
+	{"connect", "link an institution login interactively and list its accounts", connect, false},
+	{"transactions", "watch an account and print new transactions as they post", transactions, false},
+	{"doctor", "check the key, clock and credentials and report what is wrong", doctor, true},
+}
+
+func main() {
+	flags := flag.NewFlagSet("intuit", flag.ExitOnError)
+	configuration := configurationFlags(flags)
+	demo := flags.Bool("demo", false, "run against a built-in fake server with demo data instead of Intuit; no credentials are needed")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: intuit [flags] <command> [arguments]\n\nCommands:")
+		for _, c := range commands {
+			fmt.Fprintf(os.Stderr, "  %-14s %s\n", c.name, c.usage)
+		}
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	if *demo {
+		var err error
+		if configuration, err = startDemo(); err != nil {
+			fatal(err)
+		}
+		defer stopDemo()
+	}
+
+	for _, c := range commands {
+		if c.name != flags.Arg(0) {
+			continue
+		}
+
+		if !c.unvalidated {
+			if err := configuration.Validate(); err != nil {
+				fatal(err)
+			}
+		}
+		intuit.Configure(configuration)
+
+		if err := c.run(flags.Args()[1:], os.Stdout); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "intuit: unknown command %q\n", flags.Arg(0))
+	flags.Usage()
+	os.Exit(2)
+}
+
+/*
+Register the configuration flags, defaulting each to its environment variable.
+*/
+func configurationFlags(flags *flag.FlagSet) *intuit.Configuration {
+	c := &intuit.Configuration{}
+	flags.StringVar(&c.CertificatePath, "certificate", os.Getenv("INTUIT_CERTIFICATE"), "path of the application's private key")
+	flags.StringVar(&c.PublicCertificatePath, "public-certificate", os.Getenv("INTUIT_PUBLIC_CERTIFICATE"), "path of the application's certificate, to check assertions against before sending")
+	flags.StringVar(&c.OAuthConsumerKey, "consumer-key", os.Getenv("INTUIT_CONSUMER_KEY"), "OAuth consumer key")
+	flags.StringVar(&c.OAuthConsumerSecret, "consumer-secret", os.Getenv("INTUIT_CONSUMER_SECRET"), "OAuth consumer secret")
+	flags.StringVar(&c.SamlProviderId, "saml-provider-id", os.Getenv("INTUIT_SAML_PROVIDER_ID"), "SAML identity provider Id")
+	flags.StringVar(&c.CustomerId, "customer", os.Getenv("INTUIT_CUSTOMER_ID"), "Id of the customer to act as")
+
+	return c
+}
+
+/*
+Start the demo server and return a configuration pointing at it.
+*/
+func startDemo() (*intuit.Configuration, error) {
+	server := intuittest.NewServer(intuittest.DemoBundle())
+	stopDemo = server.Close
+
+	fmt.Fprintln(os.Stderr, "intuit: demo mode, using built-in demo data")
+	return server.Configuration()
+}
+
+/*
+Stop the demo server, if running, removing its key file.
+*/
+var stopDemo = func() {}
+
+func fatal(err error) {
+	stopDemo()
+	fmt.Fprintln(os.Stderr, "intuit:", err)
+	os.Exit(1)
+}
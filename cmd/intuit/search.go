@@ -0,0 +1,77 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+/*
+Return the institutions whose names match the query, best first. A name matches when the query's letters appear in it in order; contiguous matches, matches at the start of words and names starting with the query rank higher.
+*/
+func search(institutions []institution, query string, limit int) []institution {
+	type match struct {
+		institution
+		score int
+	}
+
+	matches := make([]match, 0)
+	for _, i := range institutions {
+		if score, ok := fuzzyScore(i.InstitutionName, query); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score > matches[b].score
+		}
+		return len(matches[a].InstitutionName) < len(matches[b].InstitutionName)
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	results := make([]institution, len(matches))
+	for i, m := range matches {
+		results[i] = m.institution
+	}
+
+	return results
+}
+
+func fuzzyScore(name string, query string) (int, bool) {
+	n := []rune(strings.ToLower(name))
+	q := []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	score, j, previous := 0, 0, -2
+	for i := 0; i < len(n) && j < len(q); i++ {
+		if n[i] != q[j] {
+			continue
+		}
+
+		score++
+		if i == previous+1 {
+			score += 3
+		}
+		if i == 0 || !unicode.IsLetter(n[i-1]) && !unicode.IsDigit(n[i-1]) {
+			score += 2
+		}
+		previous = i
+		j++
+	}
+
+	if j < len(q) {
+		return 0, false
+	}
+
+	if strings.HasPrefix(strings.ToLower(name), strings.ToLower(strings.TrimSpace(query))) {
+		score += 10
+	}
+
+	return score, true
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	all := []institution{
+		{InstitutionId: "1", InstitutionName: "Bank of America"},
+		{InstitutionId: "2", InstitutionName: "American Express"},
+		{InstitutionId: "3", InstitutionName: "Chase"},
+		{InstitutionId: "4", InstitutionName: "Bank of the West"},
+	}
+
+	names := func(matches []institution) []string {
+		result := make([]string, len(matches))
+		for i, m := range matches {
+			result[i] = m.InstitutionName
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Chase"}, names(search(all, "chase", 10)))
+	assert.Equal(t, []string{"American Express", "Bank of America"}, names(search(all, "amer", 10)))
+	assert.Equal(t, []string{"Bank of America", "Bank of the West"}, names(search(all, "b of", 10)))
+	assert.Equal(t, []string{"Bank of America"}, names(search(all, "bank", 1)))
+	assert.Equal(t, 0, len(search(all, "wells", 10)))
+}
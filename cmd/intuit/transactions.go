@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"github.com/MattNewberry/intuit/render"
+	"io"
+	"time"
+)
+
+/*
+Run a transactions subcommand. The only one is watch.
+*/
+func transactions(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "watch" {
+		return errors.New("usage: intuit transactions watch -account <id> [-interval 1h] [-days 30] [-json]")
+	}
+
+	return watchTransactions(args[1:], out)
+}
+
+/*
+Poll an account and print each transaction the first time it is seen. The first poll only sets the baseline, so transactions already posted are not printed.
+*/
+func watchTransactions(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("transactions watch", flag.ExitOnError)
+	accountId := flags.String("account", "", "Id of the account to watch")
+	interval := flags.Duration("interval", intuit.DefaultWatchInterval, "time between polls")
+	days := flags.Int("days", 30, "number of days of transactions fetched on each poll")
+	polls := flags.Int("polls", 0, "stop after this many polls, or never when 0")
+	asJSON := flags.Bool("json", false, "print each transaction as a line of JSON instead of a table row")
+	flags.Parse(args)
+
+	if *accountId == "" {
+		return errors.New("transactions watch: -account is required")
+	}
+
+	w := &intuit.Watcher{
+		Interval: *interval,
+		Snapshot: func(*intuit.TransactionQuery) (*intuit.Snapshot, error) {
+			return accountSnapshot(*accountId, *days)
+		},
+	}
+
+	return runWatch(w, *polls, out, *asJSON)
+}
+
+/*
+Take a snapshot of a single account with its transactions from the last days, so each poll costs two requests however many accounts the customer has.
+*/
+func accountSnapshot(accountId string, days int) (*intuit.Snapshot, error) {
+	logins, err := intuit.AccountsByLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	for loginId, accounts := range logins {
+		for _, a := range accounts {
+			if a.AccountId.String() != accountId {
+				continue
+			}
+
+			account := intuit.AccountSnapshot{CustomerAccount: a}
+			q := intuit.TransactionQuery{Start: time.Now().AddDate(0, 0, -days)}
+			transactions, errs := intuit.TransactionsChan(context.Background(), accountId, q)
+			for t := range transactions {
+				account.Transactions = append(account.Transactions, t)
+			}
+			if err := <-errs; err != nil {
+				return nil, err
+			}
+
+			login := intuit.LoginSnapshot{LoginId: loginId, InstitutionId: a.InstitutionId.String(), Accounts: []intuit.AccountSnapshot{account}}
+			return &intuit.Snapshot{CustomerId: intuit.SessionConfiguration.CustomerId, CreatedAt: time.Now().UTC(), Logins: []intuit.LoginSnapshot{login}}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account %s not found", accountId)
+}
+
+/*
+Run the watcher, printing the transactions of each EventTransactionsAdded event as they arrive.
+*/
+func runWatch(w *intuit.Watcher, polls int, out io.Writer, asJSON bool) error {
+	e := json.NewEncoder(out)
+	if !asJSON {
+		render.Transactions(out, nil, render.Options{})
+	}
+
+	w.Handle = func(event intuit.Event) {
+		if event.Type != intuit.EventTransactionsAdded {
+			return
+		}
+
+		if !asJSON {
+			// Amounts are in the account's currency unless a transaction says otherwise.
+			transactions := make([]intuit.Transaction, len(event.Transactions))
+			for i, transaction := range event.Transactions {
+				if transaction.CurrencyType == "" {
+					transaction.CurrencyType = event.Account.CurrencyCode
+				}
+				transactions[i] = transaction
+			}
+			render.Transactions(out, transactions, render.Options{NoHeader: true})
+			return
+		}
+
+		for _, transaction := range event.Transactions {
+			e.Encode(transaction)
+		}
+	}
+
+	if polls == 0 {
+		return w.Run(context.Background())
+	}
+
+	for i := 0; i < polls; i++ {
+		if i > 0 {
+			time.Sleep(w.Interval)
+		}
+		if err := w.Poll(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/MattNewberry/intuit"
+	"github.com/MattNewberry/intuit/intuittest"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWatchTransactions(t *testing.T) {
+	b := intuittest.NewBundle()
+	b.Accounts = append(b.Accounts, map[string]interface{}{"accountId": "1", "institutionId": "100000", "institutionLoginId": "9"})
+	b.Transactions["1"] = map[string]interface{}{"bankingTransactions": []interface{}{
+		map[string]interface{}{"id": "10", "payeeName": "Coffee", "amount": -3.5, "postedDate": "2014-06-01"},
+	}}
+
+	server := intuittest.NewServer(b)
+	defer server.Close()
+
+	configuration, err := server.Configuration()
+	assert.NoError(t, err)
+	previous := intuit.SessionConfiguration
+	intuit.Configure(configuration)
+	defer func() { intuit.SessionConfiguration = previous }()
+
+	snapshot, err := accountSnapshot("1", 30)
+	assert.NoError(t, err)
+	assert.Equal(t, "9", snapshot.Logins[0].LoginId)
+	assert.Equal(t, 1, len(snapshot.Logins[0].Accounts[0].Transactions))
+
+	_, err = accountSnapshot("2", 30)
+	assert.EqualError(t, err, "account 2 not found")
+
+	// Only transactions which appear after the first poll are printed.
+	polls := []*intuit.Snapshot{snapshot, snapshot}
+	added := *snapshot
+	account := added.Logins[0].Accounts[0]
+	account.Transactions = append(account.Transactions, intuit.Transaction{Id: "11", PayeeName: "Rent", Pending: true})
+	added.Logins = []intuit.LoginSnapshot{{LoginId: "9", Accounts: []intuit.AccountSnapshot{account}}}
+	polls = append(polls, &added)
+
+	w := &intuit.Watcher{Snapshot: func(*intuit.TransactionQuery) (*intuit.Snapshot, error) {
+		s := polls[0]
+		polls = polls[1:]
+		return s, nil
+	}}
+
+	var out bytes.Buffer
+	assert.NoError(t, runWatch(w, 3, &out, true))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, 1, len(lines))
+	var transaction map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &transaction))
+	assert.Equal(t, "Rent", transaction["payeeName"])
+}
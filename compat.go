@@ -0,0 +1,32 @@
+package intuit
+
+import "errors"
+
+/*
+Compat restores behavior which has since been corrected, so existing integrations can upgrade and adopt each fix on their own schedule. The zero value selects current behavior.
+
+Each flag is deprecated and reported through OnDeprecation when the configuration is passed to Configure.
+*/
+type Compat struct {
+	// Send the end date of transaction queries as "tnxEndDate". Intuit ignores the misspelled parameter, so transactions up to today are returned regardless of the end date.
+	LegacyEndDateParam bool
+
+	// Return the 401 *APIError which carried MFA challenges alongside the challenge session. Challenges are otherwise not errors: the session is returned with a nil error.
+	LegacyMFAErrors bool
+}
+
+/*
+Returned by RespondToChallenge when the institution answers with further challenges.
+*/
+var ErrChallengeRequired = errors.New("intuit: the institution asked further challenges; use ChallengeSession.Respond to answer them")
+
+/*
+Return the error to report alongside a challenge session.
+*/
+func challengeError(configuration *Configuration, err error) error {
+	if configuration.Compat.LegacyMFAErrors {
+		return err
+	}
+
+	return nil
+}
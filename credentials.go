@@ -0,0 +1,87 @@
+package intuit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+/*
+CredentialKeys describes the credential field names an institution expects when logging in. Extra lists any additional fields beyond the username and password, such as a PIN, which must be supplied by the caller; build the full set with Credentials and log in with DiscoverAndAddAccountsWithCredentials.
+*/
+type CredentialKeys struct {
+	Username string
+	Password string
+	Extra    []string
+}
+
+var (
+	credentialKeysMutex sync.RWMutex
+	credentialKeys      = map[string]CredentialKeys{
+		// Intuit's test institution (DAG Site), whose login form Intuit documents for development.
+		TestInstitutionId: {Username: "Banking Userid", Password: "Banking Password"},
+	}
+)
+
+/*
+Register the credential keys for an institution, replacing any existing entry. Only the test institution ships registered, as the fields of other institutions' login forms differ by environment and change over time; register keys from InstitutionDetails.CredentialKeys, or from a verified source, at startup.
+
+Registered keys are used by DiscoverAndAddAccounts when it is called without explicit username and password keys.
+*/
+func RegisterCredentialKeys(institutionId string, keys CredentialKeys) {
+	credentialKeysMutex.Lock()
+	defer credentialKeysMutex.Unlock()
+
+	credentialKeys[institutionId] = keys
+}
+
+/*
+Return the credentials for a login with the keys, taking the value of each Extra field from extra. An error is returned when any Extra field has no value.
+*/
+func (k CredentialKeys) Credentials(username string, password string, extra map[string]string) ([]Credential, error) {
+	credentials := []Credential{{Name: k.Username, Value: username}, {Name: k.Password, Value: password}}
+	for _, name := range k.Extra {
+		value, ok := extra[name]
+		if !ok {
+			return nil, fmt.Errorf("intuit: no value for credential %q", name)
+		}
+		credentials = append(credentials, Credential{Name: name, Value: value})
+	}
+
+	return credentials, nil
+}
+
+/*
+Return the credential keys registered for an institution.
+*/
+func CredentialKeysFor(institutionId string) (keys CredentialKeys, ok bool) {
+	credentialKeysMutex.RLock()
+	defer credentialKeysMutex.RUnlock()
+
+	keys, ok = credentialKeys[institutionId]
+	return
+}
+
+func resolveCredentialKeys(institutionId string, usernameKey string, passwordKey string) (string, string, error) {
+	if usernameKey != "" && passwordKey != "" {
+		return usernameKey, passwordKey, nil
+	}
+
+	keys, ok := CredentialKeysFor(institutionId)
+	if !ok {
+		return "", "", fmt.Errorf("intuit: no credential keys registered for institution %s", institutionId)
+	}
+	if len(keys.Extra) > 0 {
+		// A login with only the username and password would be refused.
+		return "", "", fmt.Errorf("intuit: institution %s also requires %s; use DiscoverAndAddAccountsWithCredentials", institutionId, strings.Join(keys.Extra, ", "))
+	}
+
+	if usernameKey == "" {
+		usernameKey = keys.Username
+	}
+	if passwordKey == "" {
+		passwordKey = keys.Password
+	}
+
+	return usernameKey, passwordKey, nil
+}
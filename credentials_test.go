@@ -0,0 +1,45 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResolveCredentialKeys(t *testing.T) {
+	usernameKey, passwordKey, err := resolveCredentialKeys(TestInstitutionId, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Banking Userid", usernameKey)
+	assert.Equal(t, "Banking Password", passwordKey)
+
+	// Explicit keys take precedence.
+	usernameKey, _, err = resolveCredentialKeys(TestInstitutionId, "Login", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Login", usernameKey)
+
+	_, _, err = resolveCredentialKeys("1", "", "")
+	assert.Error(t, err)
+
+	// Registered keys override the shipped entry.
+	shipped, _ := CredentialKeysFor(TestInstitutionId)
+	t.Cleanup(func() { RegisterCredentialKeys(TestInstitutionId, shipped) })
+	RegisterCredentialKeys(TestInstitutionId, CredentialKeys{Username: "Login", Password: "Secret"})
+	usernameKey, passwordKey, _ = resolveCredentialKeys(TestInstitutionId, "", "")
+	assert.Equal(t, "Login", usernameKey)
+	assert.Equal(t, "Secret", passwordKey)
+
+	// Institutions needing more than a username and password are not logged in to without it.
+	RegisterCredentialKeys(TestInstitutionId, CredentialKeys{Username: "Login", Password: "Secret", Extra: []string{"PIN"}})
+	_, _, err = resolveCredentialKeys(TestInstitutionId, "", "")
+	assert.EqualError(t, err, "intuit: institution 100000 also requires PIN; use DiscoverAndAddAccountsWithCredentials")
+}
+
+func TestCredentials(t *testing.T) {
+	keys := CredentialKeys{Username: "Login", Password: "Secret", Extra: []string{"PIN"}}
+
+	credentials, err := keys.Credentials("user", "pass", map[string]string{"PIN": "1234"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Credential{{Name: "Login", Value: "user"}, {Name: "Secret", Value: "pass"}, {Name: "PIN", Value: "1234"}}, credentials)
+
+	_, err = keys.Credentials("user", "pass", nil)
+	assert.EqualError(t, err, `intuit: no value for credential "PIN"`)
+}
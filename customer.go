@@ -0,0 +1,110 @@
+package intuit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+type customerKey struct{}
+
+/*
+Return a context which scopes requests made with it to the given customer, overriding the configured CustomerId. OAuth tokens are obtained and cached per customer, so requests for different customers may be interleaved freely.
+*/
+func WithCustomer(ctx context.Context, customerId string) context.Context {
+	return context.WithValue(ctx, customerKey{}, customerId)
+}
+
+/*
+Return the customer selected with WithCustomer, if any.
+*/
+func CustomerFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(customerKey{}).(string)
+	return id, ok
+}
+
+/*
+Return the customer requests made with ctx are scoped to.
+*/
+func customerFor(ctx context.Context) string {
+	if ctx != nil {
+		if id, ok := CustomerFromContext(ctx); ok {
+			return id
+		}
+	}
+
+	return configurationFor(ctx).CustomerId
+}
+
+// Guards the token caches of all configurations.
+var tokenMutex sync.Mutex
+
+/*
+Lifetime of persisted OAuth tokens. Tokens obtained through the SAML exchange are valid for an hour.
+*/
+const tokenTTL = time.Hour
+
+/*
+Return the cached token for a customer, falling back to the configured KV so tokens survive restarts and are shared between instances. Tokens are kept in the KV under the OAuth consumer key as well as the customer Id, so applications sharing a store do not use each other's tokens.
+*/
+func (c *Configuration) token(customerId string) *AccessToken {
+	tokenMutex.Lock()
+	token := c.tokens[customerId]
+	tokenMutex.Unlock()
+
+	if token != nil || c.kv() == nil {
+		return token
+	}
+
+	b, err := c.kv().Get(c.tokenKey(customerId))
+	if err != nil {
+		if err != ErrKeyNotFound {
+			c.logf("intuit: warning: loading token for %s: %v", customerId, err)
+		}
+		return nil
+	}
+
+	token = &AccessToken{}
+	if err = json.Unmarshal(b, token); err != nil {
+		return nil
+	}
+	c.cacheToken(customerId, token)
+	return token
+}
+
+func (c *Configuration) setToken(customerId string, token *AccessToken) {
+	c.cacheToken(customerId, token)
+
+	if c.kv() != nil {
+		b, _ := json.Marshal(token)
+		if err := c.kv().Set(c.tokenKey(customerId), b, tokenTTL); err != nil {
+			c.logf("intuit: warning: saving token for %s: %v", customerId, err)
+		}
+	}
+}
+
+func (c *Configuration) cacheToken(customerId string, token *AccessToken) {
+	tokenMutex.Lock()
+	defer tokenMutex.Unlock()
+
+	if c.tokens == nil {
+		c.tokens = make(map[string]*AccessToken)
+	}
+	c.tokens[customerId] = token
+}
+
+func (c *Configuration) tokenKey(customerId string) string {
+	return "token/" + c.consumerKey() + "/" + customerId
+}
+
+/*
+Return the OAuth consumer key, which scopes the tokens and cached results kept for customers to the application they belong to.
+*/
+func (c *Configuration) consumerKey() string {
+	if c == nil {
+		return ""
+	}
+
+	return c.OAuthConsumerKey
+}
@@ -0,0 +1,53 @@
+package intuit
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithCustomer(t *testing.T) {
+	var mutex sync.Mutex
+	exchanges := make(map[string]int)
+
+	server, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/") {
+			w.Write([]byte(`{"customer": "` + parseOAuthHeader(r.Header.Get("Authorization"))["oauth_token"] + `"}`))
+			return
+		}
+
+		assertion, _ := base64.URLEncoding.DecodeString(r.FormValue("saml_assertion"))
+		for _, customer := range []string{"alice", "bob", "customer"} {
+			if strings.Contains(string(assertion), ">"+customer+"<") {
+				mutex.Lock()
+				exchanges[customer]++
+				mutex.Unlock()
+				w.Write([]byte("oauth_token=" + customer + "&oauth_token_secret=secret"))
+			}
+		}
+	})
+	defer done()
+	SessionConfiguration.BaseURL = server.URL + "/v1/"
+
+	customer := func(ctx context.Context) string {
+		var v struct{ Customer string }
+		assert.NoError(t, fetch(ctx, GET, "accounts", nil, nil, nil, &v))
+		return v.Customer
+	}
+
+	alice := WithCustomer(context.Background(), "alice")
+	id, ok := CustomerFromContext(alice)
+	assert.True(t, ok)
+	assert.Equal(t, "alice", id)
+
+	assert.Equal(t, "alice", customer(alice))
+	assert.Equal(t, "bob", customer(WithCustomer(context.Background(), "bob")))
+	assert.Equal(t, "customer", customer(context.Background()))
+	assert.Equal(t, "alice", customer(alice))
+
+	assert.Equal(t, map[string]int{"alice": 1, "bob": 1, "customer": 1}, exchanges)
+}
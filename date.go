@@ -0,0 +1,185 @@
+package intuit
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Layouts of the dates and datetimes CAD emits, tried in order.
+*/
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02Z07:00",
+	"2006-01-02",
+}
+
+/*
+Date is a point in time decoded from any of the date-only or datetime formats used in CAD payloads. Dates without a zone are taken to be in the Location of the configuration decoding the response, UTC by default, and empty or null values decode to the zero Date. Dates decoded directly with encoding/json are taken to be in UTC.
+*/
+type Date struct {
+	time.Time
+
+	// Set when the date was decoded without a zone and has yet to be placed in a configuration's Location.
+	floating bool
+}
+
+/*
+Parse a CAD date or datetime. Dates without a zone are taken to be in the configured Location.
+*/
+func ParseDate(s string) (Date, error) {
+	return defaultClient().ParseDate(s)
+}
+
+/*
+Parse a CAD date or datetime, taking dates without a zone to be in the client's Location. See ParseDate.
+*/
+func (c *Client) ParseDate(s string) (Date, error) {
+	return c.Configuration().parseDate(s)
+}
+
+func (c *Configuration) parseDate(s string) (Date, error) {
+	d, err := parseDate(s)
+	d.anchor(c.location())
+	return d, err
+}
+
+/*
+Parse a CAD date or datetime, leaving dates without a zone floating in UTC.
+*/
+func parseDate(s string) (Date, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Date{Time: t, floating: !strings.Contains(layout, "Z")}, nil
+		}
+	}
+
+	return Date{}, fmt.Errorf("intuit: unrecognized date %q", s)
+}
+
+/*
+Place a floating date's wall clock in loc.
+*/
+func (d *Date) anchor(loc *time.Location) {
+	if d.floating {
+		t := d.Time
+		d.Time = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+		d.floating = false
+	}
+}
+
+/*
+Anchor every floating Date reachable from v, a pointer to a decoded response, in the configuration's Location.
+*/
+func (c *Configuration) anchorDates(v interface{}) {
+	anchorDates(reflect.ValueOf(v), c.location())
+}
+
+func anchorDates(v reflect.Value, loc *time.Location) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			anchorDates(v.Elem(), loc)
+		}
+	case reflect.Struct:
+		if v.Type() == dateType {
+			if v.CanAddr() {
+				v.Addr().Interface().(*Date).anchor(loc)
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				anchorDates(v.Field(i), loc)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			anchorDates(v.Index(i), loc)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			// Map values are not addressable, so anchor a copy and store it back.
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			anchorDates(e, loc)
+			v.SetMapIndex(k, e)
+		}
+	}
+}
+
+var dateType = reflect.TypeOf(Date{})
+
+/*
+Format the date a query time falls on, as sent in CAD query parameters such as txnStartDate. The day is taken in the configured Location when set, so a time late in the evening west of UTC is not sent as the following day; otherwise in the time's own zone.
+*/
+func FormatQueryDate(t time.Time) string {
+	return defaultClient().FormatQueryDate(t)
+}
+
+/*
+Format the date a query time falls on, taking the day in the client's Location. See FormatQueryDate.
+*/
+func (c *Client) FormatQueryDate(t time.Time) string {
+	return c.Configuration().formatQueryDate(t)
+}
+
+func (c *Configuration) formatQueryDate(t time.Time) string {
+	if c != nil && c.Location != nil {
+		t = t.In(c.Location)
+	}
+
+	return t.Format(transactionDateFormat)
+}
+
+/*
+Return the zone dates without one are interpreted in.
+*/
+func (c *Configuration) location() *time.Location {
+	if c == nil || c.Location == nil {
+		return time.UTC
+	}
+
+	return c.Location
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) || bytes.Equal(b, []byte(`""`)) {
+		*d = Date{}
+		return nil
+	}
+
+	// Some payloads carry dates as milliseconds since the epoch.
+	if ms, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+		*d = Date{Time: time.Unix(0, ms*int64(time.Millisecond)).UTC()}
+		return nil
+	}
+
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return fmt.Errorf("intuit: unrecognized date %s", b)
+	}
+
+	// The decoding configuration anchors floating dates in its Location; see Configuration.anchorDates.
+	parsed, err := parseDate(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return d.Time.MarshalJSON()
+}
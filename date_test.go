@@ -0,0 +1,79 @@
+package intuit
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDateFormats(t *testing.T) {
+	pdt := time.FixedZone("", -7*60*60)
+
+	cases := map[string]time.Time{
+		`"2014-05-01T10:30:00-07:00"`:     time.Date(2014, 5, 1, 10, 30, 0, 0, pdt),
+		`"2014-05-01T10:30:00.123-07:00"`: time.Date(2014, 5, 1, 10, 30, 0, 123000000, pdt),
+		`"2014-05-01T10:30:00Z"`:          time.Date(2014, 5, 1, 10, 30, 0, 0, time.UTC),
+		`"2014-05-01T10:30:00-0700"`:      time.Date(2014, 5, 1, 10, 30, 0, 0, pdt),
+		`"2014-05-01T10:30:00.5-0700"`:    time.Date(2014, 5, 1, 10, 30, 0, 500000000, pdt),
+		`"2014-05-01T10:30:00"`:           time.Date(2014, 5, 1, 10, 30, 0, 0, time.UTC),
+		`"2014-05-01T10:30:00.250"`:       time.Date(2014, 5, 1, 10, 30, 0, 250000000, time.UTC),
+		`"2014-05-01-07:00"`:              time.Date(2014, 5, 1, 0, 0, 0, 0, pdt),
+		`"2014-05-01Z"`:                   time.Date(2014, 5, 1, 0, 0, 0, 0, time.UTC),
+		`"2014-05-01"`:                    time.Date(2014, 5, 1, 0, 0, 0, 0, time.UTC),
+		`1398965400000`:                   time.Date(2014, 5, 1, 17, 30, 0, 0, time.UTC),
+		`null`:                            {},
+		`""`:                              {},
+	}
+
+	for in, expected := range cases {
+		var d Date
+		assert.NoError(t, json.Unmarshal([]byte(in), &d), in)
+		assert.True(t, expected.Equal(d.Time), "%s decoded as %v", in, d.Time)
+	}
+}
+
+func TestDateInvalid(t *testing.T) {
+	for _, in := range []string{`"05/01/2014"`, `"2014-13-01"`, `"yesterday"`, `true`, `{}`} {
+		var d Date
+		assert.Error(t, json.Unmarshal([]byte(in), &d), in)
+	}
+}
+
+func TestDateMarshal(t *testing.T) {
+	b, err := json.Marshal(struct{ A, B Date }{A: Date{Time: time.Date(2014, 5, 1, 0, 0, 0, 0, time.UTC)}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"A":"2014-05-01T00:00:00Z","B":null}`, string(b))
+}
+
+func TestTypedModelDates(t *testing.T) {
+	var a CustomerAccount
+	assert.NoError(t, json.Unmarshal([]byte(`{"balanceDate": "2014-05-01", "aggrSuccessDate": "2014-05-01T10:30:00.000-07:00"}`), &a))
+	assert.Equal(t, 2014, a.BalanceDate.Year())
+	assert.Equal(t, 10, a.AggrSuccessDate.Hour())
+}
+
+func TestLocation(t *testing.T) {
+	previous := SessionConfiguration
+	defer func() { SessionConfiguration = previous }()
+
+	pacific := time.FixedZone("PDT", -7*60*60)
+	evening := time.Date(2014, 5, 1, 20, 0, 0, 0, pacific)
+
+	SessionConfiguration = &Configuration{}
+	assert.Equal(t, "2014-05-01", FormatQueryDate(evening))
+	assert.Equal(t, "2014-05-02", FormatQueryDate(evening.UTC()))
+
+	SessionConfiguration.Location = pacific
+	assert.Equal(t, "2014-05-01", FormatQueryDate(evening.UTC()))
+	assert.Equal(t, "2014-05-01", TransactionQuery{End: evening.UTC()}.params(SessionConfiguration)["txnEndDate"])
+
+	d, err := ParseDate("2014-05-01")
+	assert.NoError(t, err)
+	assert.True(t, d.Equal(time.Date(2014, 5, 1, 0, 0, 0, 0, pacific)))
+
+	// Dates with a zone keep it.
+	d, err = ParseDate("2014-05-01Z")
+	assert.NoError(t, err)
+	assert.True(t, d.Equal(time.Date(2014, 5, 1, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,216 @@
+package intuit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+Decimal is an exact decimal number, for summing and comparing amounts without floating point error. Its value is Units × 10^-Scale, so 12.34 is {1234, 2}.
+*/
+type Decimal struct {
+	Units int64
+	Scale int
+}
+
+/*
+Parse a decimal number such as "-12.34" or "1.5e2".
+*/
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("intuit: invalid decimal %q", s)
+		}
+		d, err := ParseDecimal(s[:i])
+		if err != nil {
+			return Decimal{}, err
+		}
+		d.Scale -= e
+		if d.Scale < 0 {
+			d = d.rescale(0)
+		}
+		return d, nil
+	}
+
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if whole == "" || whole == "-" || whole == "+" {
+		whole += "0"
+	}
+	if strings.ContainsAny(fraction, "+-") {
+		return Decimal{}, fmt.Errorf("intuit: invalid decimal %q", s)
+	}
+
+	units, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("intuit: invalid decimal %q", s)
+	}
+
+	return Decimal{Units: units, Scale: len(fraction)}, nil
+}
+
+/*
+Parse a decimal number, panicking if it is invalid. For amounts written in code, such as in tests.
+*/
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+/*
+Return the amount as a Decimal: the shortest decimal which reads back as the same float. For balances and other amounts still decoded as floats; transaction amounts are decoded as Decimals directly.
+*/
+func (a Amount) Decimal() Decimal {
+	d, _ := ParseDecimal(strconv.FormatFloat(float64(a), 'f', -1, 64))
+	return d
+}
+
+/*
+Return the decimal with its units scaled up to the given scale, which must not be less than its own.
+*/
+func (d Decimal) rescale(scale int) Decimal {
+	for d.Scale < scale {
+		d.Units *= 10
+		d.Scale++
+	}
+
+	return d
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{Units: -d.Units, Scale: d.Scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	if d.Units < 0 {
+		return d.Neg()
+	}
+
+	return d
+}
+
+/*
+Return -1, 0 or 1 for a negative, zero or positive decimal.
+*/
+func (d Decimal) Sign() int {
+	return d.Cmp(Decimal{})
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Units == 0
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	scale := d.Scale
+	if o.Scale > scale {
+		scale = o.Scale
+	}
+
+	return Decimal{Units: d.rescale(scale).Units + o.rescale(scale).Units, Scale: scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	return d.Add(o.Neg())
+}
+
+/*
+Compare two decimals, returning -1, 0 or 1.
+*/
+func (d Decimal) Cmp(o Decimal) int {
+	diff := d.Sub(o).Units
+	switch {
+	case diff < 0:
+		return -1
+	case diff > 0:
+		return 1
+	}
+
+	return 0
+}
+
+/*
+Return the value in minor units of a currency with the given number of digits, such as cents for 2, rounding halves away from zero.
+*/
+func (d Decimal) Minor(digits int) int64 {
+	if d.Scale <= digits {
+		return d.rescale(digits).Units
+	}
+
+	p := int64(math.Pow10(d.Scale - digits))
+	q, r := d.Units/p, d.Units%p
+	if r*2 >= p {
+		q++
+	} else if r*2 <= -p {
+		q--
+	}
+
+	return q
+}
+
+/*
+Format the decimal in a currency for display in a locale, rounding it exactly to the currency's digits. See FormatAmount.
+*/
+func (d Decimal) Format(currencyCode string, locale string) string {
+	l, c, local, known := moneyFormat(currencyCode, locale)
+	minor := d.Minor(c.Digits)
+	return formatMoney(minor < 0, Decimal{Units: minor, Scale: c.Digits}.Abs().String(), l, c, local, known)
+}
+
+/*
+Return the decimal as an Amount, which may not represent it exactly.
+*/
+func (d Decimal) Amount() Amount {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return Amount(f)
+}
+
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.Units, 10)
+	if d.Scale <= 0 {
+		return s
+	}
+
+	sign := ""
+	if d.Units < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= d.Scale {
+		s = strings.Repeat("0", d.Scale-len(s)+1) + s
+	}
+
+	return sign + s[:len(s)-d.Scale] + "." + s[len(s)-d.Scale:]
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+/*
+Decode a JSON number, or a number in a string as some CAD payloads send.
+*/
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "null" || s == "" {
+		*d = Decimal{}
+		return nil
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,70 @@
+package intuit
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	d, err := ParseDecimal("-12.34")
+	assert.NoError(t, err)
+	assert.Equal(t, Decimal{Units: -1234, Scale: 2}, d)
+	assert.Equal(t, "-12.34", d.String())
+
+	_, err = ParseDecimal("12.3.4")
+	assert.Error(t, err)
+
+	// Summing as floats drifts; decimals do not.
+	sum := Decimal{}
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(Amount(0.1).Decimal())
+	}
+	assert.Equal(t, "1.0", sum.String())
+	assert.Equal(t, 0, sum.Cmp(Decimal{Units: 1}))
+
+	assert.Equal(t, "0.05", Decimal{Units: 5, Scale: 2}.String())
+	assert.Equal(t, "-0.5", Decimal{Units: -5, Scale: 1}.String())
+	assert.Equal(t, int64(1235), Decimal{Units: 12345, Scale: 3}.Minor(2))
+	assert.Equal(t, int64(-1235), Decimal{Units: -12345, Scale: 3}.Minor(2))
+	assert.Equal(t, int64(1200), Decimal{Units: 12}.Minor(2))
+	assert.Equal(t, Amount(-842.55), Amount(-842.55).Decimal().Amount())
+
+	var v struct{ A, B Decimal }
+	assert.NoError(t, json.Unmarshal([]byte(`{"A": 19.99, "B": "7.5"}`), &v))
+	assert.Equal(t, "19.99", v.A.String())
+	assert.Equal(t, "7.5", v.B.String())
+	b, _ := json.Marshal(v)
+	assert.Equal(t, `{"A":19.99,"B":7.5}`, string(b))
+
+	d, err = ParseDecimal("1.5e2")
+	assert.NoError(t, err)
+	assert.Equal(t, "150", d.String())
+	d, err = ParseDecimal("-125E-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "-1.25", d.String())
+	assert.Panics(t, func() { MustParseDecimal("twelve") })
+
+	d = MustParseDecimal("-12.5")
+	assert.Equal(t, "12.5", d.Neg().String())
+	assert.Equal(t, "12.5", d.Abs().String())
+	assert.Equal(t, -1, d.Sign())
+	assert.Equal(t, 0, MustParseDecimal("0.00").Sign())
+	assert.True(t, MustParseDecimal("0.00").IsZero())
+
+	// Rounded exactly, where the float 2.675 is just below the half.
+	assert.Equal(t, "$2.68", MustParseDecimal("2.675").Format("USD", "en-US"))
+	assert.Equal(t, "-1.234,50 €", MustParseDecimal("-1234.5").Format("EUR", "de-DE"))
+	assert.Equal(t, "$0.00", MustParseDecimal("-0.004").Format("USD", "en-US"))
+}
+
+func TestTransactionAmountPrecision(t *testing.T) {
+	var txn Transaction
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 90071992547409.93}`), &txn))
+	assert.Equal(t, "90071992547409.93", txn.Amount.String())
+	assert.Equal(t, int64(9007199254740993), txn.Amount.Minor(2))
+
+	b, err := json.Marshal(txn)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"amount":90071992547409.93`)
+}
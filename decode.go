@@ -0,0 +1,128 @@
+package intuit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+/*
+Decode a typed response, placing dates without a zone in the configuration's Location. In strict mode, fields present in the response but missing from v are logged as warnings, flagging schema changes before they silently affect data quality.
+*/
+func decodeTyped(configuration *Configuration, endpoint string, b []byte, v interface{}) error {
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	configuration.anchorDates(v)
+
+	if configuration != nil && configuration.StrictDecoding {
+		var raw interface{}
+		if json.Unmarshal(b, &raw) == nil {
+			for _, field := range unknownFields(raw, reflect.TypeOf(v)) {
+				configuration.logf("intuit: warning: unknown field %s in response from %s", field, endpoint)
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+Return the paths of fields in raw which have no corresponding field in t, sorted and deduplicated.
+*/
+func unknownFields(raw interface{}, t reflect.Type) []string {
+	found := make(map[string]bool)
+	walkUnknownFields(raw, t, "", found)
+
+	fields := make([]string, 0, len(found))
+	for f := range found {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+func walkUnknownFields(raw interface{}, t reflect.Type, path string, found map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		fields := jsonFields(t)
+		for key, value := range obj {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				found[strings.TrimPrefix(path+"."+key, ".")] = true
+				continue
+			}
+			walkUnknownFields(value, field, path+"."+key, found)
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := raw.([]interface{}); ok {
+			for _, item := range items {
+				walkUnknownFields(item, t.Elem(), path+"[]", found)
+			}
+		}
+	case reflect.Map:
+		if obj, ok := raw.(map[string]interface{}); ok {
+			for _, value := range obj {
+				walkUnknownFields(value, t.Elem(), path+".*", found)
+			}
+		}
+	}
+}
+
+/*
+Map the lowercased JSON names of a struct's fields, including those promoted from embedded structs, to their types. encoding/json matches names case-insensitively.
+*/
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range jsonFields(embedded) {
+					if _, exists := fields[k]; !exists {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+
+	return fields
+}
@@ -0,0 +1,44 @@
+package intuit
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	var raw interface{}
+	json.Unmarshal([]byte(`{"accounts": [
+		{"accountId": 1, "status": "ACTIVE", "newField": true, "errorInfo": {"errorCode": "108", "severity": "high"}},
+		{"accountId": 2, "newField": false, "another": 1}
+	], "paging": {}}`), &raw)
+
+	fields := unknownFields(raw, reflect.TypeOf(&DiscoverResult{}))
+	assert.Equal(t, []string{"accounts[].another", "accounts[].errorInfo.severity", "accounts[].newField", "paging"}, fields)
+}
+
+func TestDecodeErrorKeepsData(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accounts": [
+			{"accountId": 1, "accountNickname": "Checking", "balanceAmount": "unavailable"},
+			{"accountId": 2, "accountNickname": "Savings", "balanceAmount": 10}
+		]}`))
+	})
+	defer done()
+
+	accounts, err := customerAccounts(context.Background())
+	decodeError, ok := err.(*DecodeError)
+	assert.True(t, ok)
+
+	// Fields which fit are decoded, for every account.
+	assert.Equal(t, 2, len(accounts))
+	assert.Equal(t, "Checking", accounts[0].AccountNickname)
+	assert.Equal(t, Amount(10), accounts[1].BalanceAmount)
+	assert.Equal(t, accounts, decodeError.Partial.(*accountList).Accounts)
+
+	raw := decodeError.Raw.(map[string]interface{})["accounts"].([]interface{})
+	assert.Equal(t, "unavailable", raw[0].(map[string]interface{})["balanceAmount"])
+}
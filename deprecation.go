@@ -0,0 +1,84 @@
+package intuit
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+/*
+Deprecation is a notice that a deprecated function or setting was used, reported through OnDeprecation or the Logger so call sites can be found and migrated.
+*/
+type Deprecation struct {
+	// The deprecated function or setting, such as "RespondToChallenge".
+	Feature string
+
+	// What to use instead.
+	Replacement string
+
+	// File and line of the call site, when known.
+	Caller string
+}
+
+func (d Deprecation) String() string {
+	s := fmt.Sprintf("intuit: %s is deprecated; use %s instead", d.Feature, d.Replacement)
+	if d.Caller != "" {
+		s += " (called from " + d.Caller + ")"
+	}
+
+	return s
+}
+
+// Guards the reported deprecations of all configurations.
+var deprecationMutex sync.Mutex
+
+/*
+Report use of a deprecated feature to the configuration's OnDeprecation, or its Logger, once per feature and call site. The call site is skip frames above the function calling deprecated, so 0 names that function's caller.
+
+Nothing is reported, or remembered as reported, for a configuration with neither set.
+*/
+func (c *Configuration) deprecated(skip int, feature string, replacement string) {
+	if c == nil || (c.OnDeprecation == nil && c.Logger == nil) {
+		return
+	}
+
+	d := Deprecation{Feature: feature, Replacement: replacement}
+	if _, file, line, ok := runtime.Caller(skip + 2); ok {
+		d.Caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	deprecationMutex.Lock()
+	reported := c.reportedDeprecations[d]
+	if !reported {
+		if c.reportedDeprecations == nil {
+			c.reportedDeprecations = make(map[Deprecation]bool)
+		}
+		c.reportedDeprecations[d] = true
+	}
+	deprecationMutex.Unlock()
+	if reported {
+		return
+	}
+
+	if c.OnDeprecation != nil {
+		c.OnDeprecation(d)
+	} else {
+		c.logf("%s", d)
+	}
+}
+
+/*
+Report the deprecated settings of a configuration, naming the caller of the function calling deprecations.
+*/
+func (c *Configuration) deprecations() {
+	if c == nil {
+		return
+	}
+
+	if c.Compat.LegacyEndDateParam {
+		c.deprecated(1, "Compat.LegacyEndDateParam", "the corrected txnEndDate parameter")
+	}
+	if c.Compat.LegacyMFAErrors {
+		c.deprecated(1, "Compat.LegacyMFAErrors", "the returned ChallengeSession to detect challenges")
+	}
+}
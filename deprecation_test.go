@@ -0,0 +1,87 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDeprecations(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accounts": []}`))
+	})
+	defer done()
+
+	var notices []Deprecation
+	configuration := *SessionConfiguration
+	configuration.OnDeprecation = func(d Deprecation) { notices = append(notices, d) }
+	configuration.Compat.LegacyMFAErrors = true
+	Configure(&configuration)
+
+	session := &ChallengeSession{InstitutionId: "100000", contextType: discoverAndAddType}
+	for i := 0; i < 2; i++ {
+		RespondToChallenge(session)
+	}
+
+	assert.Equal(t, 2, len(notices))
+	assert.Equal(t, "Compat.LegacyMFAErrors", notices[0].Feature)
+	assert.Equal(t, "RespondToChallenge", notices[1].Feature)
+	assert.True(t, strings.Contains(notices[1].Caller, "deprecation_test.go:"), notices[1].Caller)
+	assert.True(t, strings.HasPrefix(notices[1].String(), "intuit: RespondToChallenge is deprecated; use ChallengeSession.Respond instead (called from "))
+}
+
+func TestDeprecationCallSite(t *testing.T) {
+	previous := SessionConfiguration
+	defer func() { SessionConfiguration = previous }()
+
+	var notices []Deprecation
+	configuration := &Configuration{OnDeprecation: func(d Deprecation) { notices = append(notices, d) }}
+	configuration.Compat.LegacyEndDateParam = true
+	Configure(configuration)
+
+	// Settings are reported from the call configuring them.
+	if assert.Equal(t, 1, len(notices)) {
+		assert.True(t, strings.Contains(notices[0].Caller, "deprecation_test.go:"), notices[0].Caller)
+	}
+}
+
+func TestClientDeprecations(t *testing.T) {
+	previous := SessionConfiguration
+	defer func() { SessionConfiguration = previous }()
+
+	var session, client []Deprecation
+	SessionConfiguration = &Configuration{OnDeprecation: func(d Deprecation) { session = append(session, d) }}
+
+	configuration := Configuration{OnDeprecation: func(d Deprecation) { client = append(client, d) }}
+	configuration.Compat.LegacyMFAErrors = true
+	NewClient(configuration)
+
+	// The client's notices go to its own handler, naming the call to NewClient.
+	assert.Equal(t, 0, len(session))
+	if assert.Equal(t, 1, len(client)) {
+		assert.Equal(t, "Compat.LegacyMFAErrors", client[0].Feature)
+		assert.True(t, strings.Contains(client[0].Caller, "deprecation_test.go:"), client[0].Caller)
+	}
+}
+
+func TestDeprecationsWithoutHandler(t *testing.T) {
+	previous := SessionConfiguration
+	defer func() { SessionConfiguration = previous }()
+
+	// Without a configuration, a handler or a Logger, there is nowhere to report to.
+	SessionConfiguration = nil
+	defaultClient().Configuration().deprecated(0, "Feature", "Replacement")
+
+	var notices []Deprecation
+	configuration := &Configuration{}
+	for i := 0; i < 3; i++ {
+		if i == 1 {
+			configuration.OnDeprecation = func(d Deprecation) { notices = append(notices, d) }
+		}
+		configuration.deprecated(0, "Feature", "Replacement")
+	}
+
+	// The call made without a handler was not remembered as reported, so the handler set later hears of it once.
+	assert.Equal(t, 1, len(notices))
+}
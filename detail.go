@@ -0,0 +1,84 @@
+package intuit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+/*
+Fetch the account's full detail, replacing the summary returned by the account list. Calling it again refreshes the detail.
+*/
+func (a *CustomerAccount) LoadDetail() error {
+	return defaultClient().LoadDetail(a)
+}
+
+/*
+Fetch an account's full detail through the client. See CustomerAccount.LoadDetail.
+*/
+func (c *Client) LoadDetail(a *CustomerAccount) error {
+	return a.loadDetail(c.background())
+}
+
+/*
+Report whether the account holds its full detail rather than list summary data.
+*/
+func (a *CustomerAccount) HasDetail() bool {
+	return a.detailLoaded
+}
+
+func (a *CustomerAccount) loadDetail(ctx context.Context) error {
+	var list accountList
+	if err := fetch(ctx, GET, fmt.Sprintf("accounts/%s", a.AccountId), nil, nil, nil, &list); err != nil {
+		return notFound("account", a.AccountId.String(), err)
+	}
+
+	if len(list.Accounts) == 0 {
+		return &NotFoundError{Resource: "account", Id: a.AccountId.String()}
+	}
+
+	*a = list.Accounts[0]
+	a.detailLoaded = true
+	return nil
+}
+
+/*
+Load the detail of every account which does not yet hold it, fetching at most concurrency at a time. Every account is attempted, and any failures are reported together in a BatchError.
+*/
+func LoadDetails(accounts []CustomerAccount, concurrency int) error {
+	return defaultClient().LoadDetails(accounts, concurrency)
+}
+
+/*
+Load the detail of every account which does not yet hold it through the client. See LoadDetails.
+*/
+func (c *Client) LoadDetails(accounts []CustomerAccount, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	batch := NewBatchError("load account details", len(accounts))
+
+	for i := range accounts {
+		if accounts[i].detailLoaded {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, a *CustomerAccount) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			id := a.AccountId.String()
+			if err := c.LoadDetail(a); err != nil {
+				batch.Add(i, id, err)
+			}
+		}(i, &accounts[i])
+	}
+
+	wg.Wait()
+	return batch.Err()
+}
@@ -0,0 +1,37 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoadDetails(t *testing.T) {
+	var requests int32
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		id := strings.TrimPrefix(r.URL.Path, "/accounts/")
+		if id == "3" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"accounts": [{"accountId": ` + id + `, "accountNickname": "Detail ` + id + `", "currencyCode": "USD"}]}`))
+	})
+	defer done()
+
+	accounts := []CustomerAccount{{AccountId: "1"}, {AccountId: "2"}, {AccountId: "3"}}
+	err := LoadDetails(accounts, 2)
+	assert.Equal(t, http.StatusNotFound, StatusCode(err))
+
+	assert.True(t, accounts[0].HasDetail())
+	assert.Equal(t, "Detail 1", accounts[0].AccountNickname)
+	assert.Equal(t, "USD", accounts[1].CurrencyCode)
+	assert.False(t, accounts[2].HasDetail())
+	assert.Equal(t, int32(3), requests)
+
+	// Only the account still missing its detail is fetched again.
+	LoadDetails(accounts, 2)
+	assert.Equal(t, int32(4), requests)
+}
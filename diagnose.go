@@ -0,0 +1,263 @@
+package intuit
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+/*
+NTP server used by Diagnose to measure clock skew.
+*/
+var NTPServer = "pool.ntp.org:123"
+
+/*
+Largest clock skew Diagnose accepts. Assertions are valid from five minutes before they are made, so a clock further ahead than this has its assertions rejected as not yet valid.
+*/
+var MaxClockSkew = 5 * time.Minute
+
+/*
+DiagnosticStatus is the outcome of a diagnostic step.
+*/
+type DiagnosticStatus string
+
+const (
+	DiagnosticPass DiagnosticStatus = "PASS"
+	DiagnosticFail DiagnosticStatus = "FAIL"
+
+	// The step could not run, because an earlier step it depends on failed or it does not apply.
+	DiagnosticSkip DiagnosticStatus = "SKIP"
+)
+
+/*
+DiagnosticStep is the outcome of one check made by Diagnose.
+*/
+type DiagnosticStep struct {
+	Name   string
+	Status DiagnosticStatus
+
+	// What was found, or why the step failed or was skipped.
+	Detail string
+
+	// The error behind a failure, if any.
+	Err error
+}
+
+/*
+Diagnosis is the report produced by Diagnose.
+*/
+type Diagnosis struct {
+	Steps []DiagnosticStep
+}
+
+/*
+Report whether no step failed.
+*/
+func (d *Diagnosis) OK() bool {
+	return len(d.Failed()) == 0
+}
+
+/*
+Return the steps which failed.
+*/
+func (d *Diagnosis) Failed() []DiagnosticStep {
+	failed := make([]DiagnosticStep, 0)
+	for _, s := range d.Steps {
+		if s.Status == DiagnosticFail {
+			failed = append(failed, s)
+		}
+	}
+
+	return failed
+}
+
+/*
+Write the report, one step per line.
+*/
+func (d *Diagnosis) Write(w io.Writer) error {
+	width := 0
+	for _, s := range d.Steps {
+		if len(s.Name) > width {
+			width = len(s.Name)
+		}
+	}
+
+	for _, s := range d.Steps {
+		if _, err := fmt.Fprintf(w, "%-4s  %-*s  %s\n", s.Status, width, s.Name, s.Detail); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Diagnosis) add(name string, status DiagnosticStatus, err error, format string, v ...interface{}) bool {
+	detail := fmt.Sprintf(format, v...)
+	if err != nil {
+		detail = strings.TrimPrefix(err.Error(), "intuit: ")
+	}
+
+	d.Steps = append(d.Steps, DiagnosticStep{Name: name, Status: status, Detail: detail, Err: err})
+	return status == DiagnosticPass
+}
+
+/*
+Check the setup step by step, so a misconfiguration shows up as a specific failure rather than an opaque 401: the signing key and its pairing with the public certificate, the clock against NTPServer, the SAML provider Id and consumer key, a token exchange for the scoped customer, and an authenticated request. Steps depending on a failed step are skipped.
+
+A successful token exchange replaces the customer's token, as Authenticate does.
+*/
+func Diagnose(ctx context.Context) *Diagnosis {
+	d := &Diagnosis{}
+	c := configurationFor(ctx)
+	if c == nil {
+		d.add("configuration", DiagnosticFail, nil, "not configured; call Configure first")
+		return d
+	}
+
+	keyOK := d.diagnoseKey(c)
+
+	offset, err := ntpOffset(ctx, NTPServer)
+	switch {
+	case err != nil:
+		d.add("clock", DiagnosticSkip, nil, "could not reach %s: %v", NTPServer, err)
+	case offset > MaxClockSkew || offset < -MaxClockSkew:
+		d.add("clock", DiagnosticFail, nil, "clock is %s off %s, more than the %s allowed; assertions will be rejected", roundOffset(offset), NTPServer, MaxClockSkew)
+	default:
+		d.add("clock", DiagnosticPass, nil, "clock is %s off %s", roundOffset(offset), NTPServer)
+	}
+
+	var idOK, consumerOK bool
+	if c.SamlProviderId == "" {
+		d.add("saml provider id", DiagnosticFail, nil, "SamlProviderId is not set")
+	} else if !samlProviderIdPattern.MatchString(c.SamlProviderId) {
+		d.add("saml provider id", DiagnosticFail, nil, "%q does not look like a SAML provider Id, which is a dotted name such as \"app.1.cc.dev-intuit.ipp.prod\"", c.SamlProviderId)
+	} else {
+		idOK = d.add("saml provider id", DiagnosticPass, nil, "%s", c.SamlProviderId)
+	}
+
+	if strings.TrimSpace(c.OAuthConsumerKey) == "" || strings.TrimSpace(c.OAuthConsumerSecret) == "" {
+		d.add("consumer key", DiagnosticFail, nil, "OAuthConsumerKey and OAuthConsumerSecret must both be set")
+	} else {
+		consumerOK = d.add("consumer key", DiagnosticPass, nil, "set")
+	}
+
+	if !keyOK || !idOK || !consumerOK {
+		d.add("token exchange", DiagnosticSkip, nil, "fix the failures above first")
+		d.add("authenticated request", DiagnosticSkip, nil, "no token")
+		return d
+	}
+
+	customer := customerFor(ctx)
+	if err := Authenticate(ctx); err != nil {
+		d.add("token exchange", DiagnosticFail, err, "")
+		d.add("authenticated request", DiagnosticSkip, nil, "no token")
+		return d
+	}
+	d.add("token exchange", DiagnosticPass, nil, "obtained a token for customer %q", customer)
+
+	institution := &InstitutionDetails{}
+	if err := fetch(ctx, GET, "institutions/"+TestInstitutionId, nil, nil, nil, institution); err != nil {
+		d.add("authenticated request", DiagnosticFail, err, "")
+	} else {
+		d.add("authenticated request", DiagnosticPass, nil, "GET institutions/%s returned %q", TestInstitutionId, institution.InstitutionName)
+	}
+
+	return d
+}
+
+/*
+Check the signing key, and that it pairs with the public certificate when one is configured.
+*/
+/*
+Check the client's setup. See Diagnose.
+*/
+func (c *Client) Diagnose(ctx context.Context) *Diagnosis {
+	return Diagnose(c.Context(ctx))
+}
+
+func (d *Diagnosis) diagnoseKey(c *Configuration) bool {
+	if c.CertificatePath == "" {
+		return d.add("certificate", DiagnosticFail, nil, "CertificatePath is not set; it must point to the PEM-encoded private key registered with the application")
+	}
+
+	key, err := loadPrivateKey(c.CertificatePath)
+	if err != nil {
+		return d.add("certificate", DiagnosticFail, nil, "%s is not a usable RSA private key: %v", c.CertificatePath, err)
+	}
+
+	if c.PublicCertificatePath == "" {
+		return d.add("certificate", DiagnosticPass, nil, "%d-bit RSA key; set PublicCertificatePath to check it against the certificate uploaded to Intuit", key.N.BitLen())
+	}
+
+	publicKey, err := loadCertificateKey(c.PublicCertificatePath)
+	if err != nil {
+		return d.add("certificate", DiagnosticFail, nil, "public certificate %s cannot be used: %v", c.PublicCertificatePath, err)
+	}
+	if !key.PublicKey.Equal(publicKey) {
+		return d.add("certificate", DiagnosticFail, nil, "public certificate %s does not match the signing key at %s", c.PublicCertificatePath, c.CertificatePath)
+	}
+
+	return d.add("certificate", DiagnosticPass, nil, "%d-bit RSA key matches %s", key.N.BitLen(), c.PublicCertificatePath)
+}
+
+func roundOffset(offset time.Duration) time.Duration {
+	return offset.Round(time.Millisecond)
+}
+
+// Seconds from the NTP epoch, 1900, to the Unix epoch.
+const ntpEpochOffset = 2208988800
+
+/*
+Return how far the local clock is behind the NTP server, negative when it is ahead, using a single SNTP query.
+*/
+func ntpOffset(ctx context.Context, server string) (time.Duration, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	// A client request: leap indicator 0, version 4, mode 3.
+	req := make([]byte, 48)
+	req[0] = 0x23
+
+	sent := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+
+	res := make([]byte, 48)
+	n, err := conn.Read(res)
+	received := time.Now()
+	if err != nil {
+		return 0, err
+	}
+	if n < 48 {
+		return 0, fmt.Errorf("short NTP response of %d bytes", n)
+	}
+
+	serverReceived := ntpTime(res[32:40])
+	serverSent := ntpTime(res[40:48])
+	if serverSent.Unix() <= 0 {
+		return 0, fmt.Errorf("NTP server sent no time")
+	}
+
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+func ntpTime(b []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[:4])) - ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(b[4:]))
+	return time.Unix(seconds, fraction*int64(time.Second)>>32)
+}
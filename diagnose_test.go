@@ -0,0 +1,92 @@
+package intuit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+/*
+Serve SNTP responses from a clock offset from the local one.
+*/
+func fakeNTPServer(t *testing.T, offset time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go func() {
+		defer conn.Close()
+		b := make([]byte, 48)
+		_, addr, err := conn.ReadFrom(b)
+		if err != nil {
+			return
+		}
+
+		now := time.Now().Add(offset)
+		res := make([]byte, 48)
+		res[0] = 0x24
+		for _, i := range []int{32, 40} {
+			binary.BigEndian.PutUint32(res[i:], uint32(now.Unix()+ntpEpochOffset))
+			binary.BigEndian.PutUint32(res[i+4:], uint32((int64(now.Nanosecond())<<32)/int64(time.Second)))
+		}
+		conn.WriteTo(res, addr)
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestDiagnose(t *testing.T) {
+	server, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/institutions/100000" {
+			assert.Contains(t, r.Header.Get("Authorization"), `oauth_token="token"`)
+			w.Write([]byte(`{"institutionId": 100000, "institutionName": "CCBank"}`))
+			return
+		}
+		w.Write([]byte("oauth_token=token&oauth_token_secret=secret"))
+	})
+	defer done()
+	SessionConfiguration.BaseURL = server.URL + "/v1/"
+	SessionConfiguration.SamlProviderId = "app.1.cc.dev-intuit.ipp.prod"
+	SessionConfiguration.OAuthConsumerSecret = "secret"
+
+	key, _ := loadPrivateKey(SessionConfiguration.CertificatePath)
+	SessionConfiguration.PublicCertificatePath = writeCertificate(t, key)
+	defer os.Remove(SessionConfiguration.PublicCertificatePath)
+
+	previous := NTPServer
+	defer func() { NTPServer = previous }()
+	NTPServer = fakeNTPServer(t, 0)
+
+	d := Diagnose(context.Background())
+	var b bytes.Buffer
+	d.Write(&b)
+	assert.True(t, d.OK(), b.String())
+	assert.Equal(t, 6, len(d.Steps))
+	assert.Contains(t, b.String(), `PASS  authenticated request  GET institutions/100000 returned "CCBank"`)
+}
+
+func TestDiagnoseFailures(t *testing.T) {
+	_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no token exchange should be attempted")
+	})
+	defer done()
+
+	previous := NTPServer
+	defer func() { NTPServer = previous }()
+	NTPServer = fakeNTPServer(t, time.Hour)
+
+	d := Diagnose(context.Background())
+	assert.False(t, d.OK())
+
+	statuses := make([]string, len(d.Steps))
+	for i, s := range d.Steps {
+		statuses[i] = s.Name + " " + string(s.Status)
+	}
+	assert.Equal(t, []string{"certificate PASS", "clock FAIL", "saml provider id FAIL", "consumer key FAIL", "token exchange SKIP", "authenticated request SKIP"}, statuses)
+	assert.Contains(t, d.Steps[1].Detail, "more than the 5m0s allowed")
+}
@@ -0,0 +1,173 @@
+package intuit
+
+/*
+Diff describes how a customer's accounts changed between two snapshots, such as those taken before and after a refresh.
+*/
+type Diff struct {
+	// Accounts present only in the current snapshot.
+	Added []CustomerAccount
+
+	// Accounts present only in the previous snapshot.
+	Removed []CustomerAccount
+
+	// Accounts which were open in the previous snapshot and are closed in the current one.
+	Closed []CustomerAccount
+
+	// Accounts which were closed in the previous snapshot and are open in the current one.
+	Reopened []CustomerAccount
+
+	// Accounts in both snapshots whose balance differs.
+	BalanceChanges []BalanceChange
+
+	// Transactions in the current snapshot which were not in the previous one, by account. Only reported when the snapshots include transactions.
+	NewTransactions []AccountTransactions
+
+	// Logins which were aggregating successfully in the previous snapshot and are failing in the current one.
+	BrokenLogins []BrokenLogin
+}
+
+/*
+BalanceChange is an account whose balance changed between snapshots.
+*/
+type BalanceChange struct {
+	Account  CustomerAccount
+	Previous Amount
+}
+
+/*
+AccountTransactions are transactions of a single account.
+*/
+type AccountTransactions struct {
+	Account      CustomerAccount
+	Transactions []Transaction
+}
+
+/*
+BrokenLogin is a login whose accounts stopped aggregating, typically because the user changed their password at the institution or a new MFA question is required. Code is the aggregation status code of the first failing account; see ErrorHints.
+*/
+type BrokenLogin struct {
+	LoginId string
+	Account CustomerAccount
+	Code    string
+}
+
+/*
+Report whether nothing changed.
+*/
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Closed) == 0 && len(d.Reopened) == 0 &&
+		len(d.BalanceChanges) == 0 && len(d.NewTransactions) == 0 && len(d.BrokenLogins) == 0
+}
+
+/*
+Compare two snapshots of the same customer. Accounts are matched by Id, and reported in the order they appear in the snapshot they are taken from. Transactions are matched by Fingerprint.
+*/
+func DiffSnapshots(previous *Snapshot, current *Snapshot) *Diff {
+	d := &Diff{}
+	before := previous.accountsById()
+	after := current.accountsById()
+
+	for _, a := range current.accounts() {
+		old, ok := before[a.AccountId.String()]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, a.CustomerAccount)
+		case !old.IsClosed() && a.IsClosed():
+			d.Closed = append(d.Closed, a.CustomerAccount)
+		case old.IsClosed() && !a.IsClosed():
+			d.Reopened = append(d.Reopened, a.CustomerAccount)
+		}
+
+		if ok && old.BalanceAmount != a.BalanceAmount {
+			d.BalanceChanges = append(d.BalanceChanges, BalanceChange{Account: a.CustomerAccount, Previous: old.BalanceAmount})
+		}
+
+		if added := newTransactions(old, a); len(added) > 0 {
+			d.NewTransactions = append(d.NewTransactions, AccountTransactions{Account: a.CustomerAccount, Transactions: added})
+		}
+	}
+
+	for _, l := range current.logins() {
+		for _, a := range l.Accounts {
+			old, ok := before[a.AccountId.String()]
+			if ok && aggregating(old.CustomerAccount) && !aggregating(a.CustomerAccount) {
+				d.BrokenLogins = append(d.BrokenLogins, BrokenLogin{LoginId: l.LoginId, Account: a.CustomerAccount, Code: a.AggrStatusCode})
+				break
+			}
+		}
+	}
+
+	for _, a := range previous.accounts() {
+		if _, ok := after[a.AccountId.String()]; !ok {
+			d.Removed = append(d.Removed, a.CustomerAccount)
+		}
+	}
+
+	return d
+}
+
+/*
+Return the transactions of the current account which were not in its previous snapshot.
+*/
+func newTransactions(previous AccountSnapshot, current AccountSnapshot) []Transaction {
+	seen := make(map[string]bool)
+	for _, f := range Fingerprints(withAccountId(previous)) {
+		seen[f] = true
+	}
+
+	added := make([]Transaction, 0)
+	for i, f := range Fingerprints(withAccountId(current)) {
+		if !seen[f] {
+			added = append(added, current.Transactions[i])
+		}
+	}
+
+	return added
+}
+
+/*
+Return the account's transactions with their AccountId set, which is not kept when snapshots are written, so fingerprints match across snapshots loaded from JSON.
+*/
+func withAccountId(a AccountSnapshot) []Transaction {
+	transactions := make([]Transaction, len(a.Transactions))
+	for i, t := range a.Transactions {
+		t.AccountId = a.AccountId.String()
+		transactions[i] = t
+	}
+
+	return transactions
+}
+
+func aggregating(a CustomerAccount) bool {
+	return a.AggrStatusCode == "" || a.AggrStatusCode == "0"
+}
+
+func (s *Snapshot) logins() []LoginSnapshot {
+	if s == nil {
+		return nil
+	}
+
+	return s.Logins
+}
+
+func (s *Snapshot) accounts() []AccountSnapshot {
+	accounts := make([]AccountSnapshot, 0)
+	if s == nil {
+		return accounts
+	}
+
+	for _, l := range s.Logins {
+		accounts = append(accounts, l.Accounts...)
+	}
+
+	return accounts
+}
+
+func (s *Snapshot) accountsById() map[string]AccountSnapshot {
+	accounts := make(map[string]AccountSnapshot)
+	for _, a := range s.accounts() {
+		accounts[a.AccountId.String()] = a
+	}
+
+	return accounts
+}
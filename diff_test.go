@@ -0,0 +1,90 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func snapshotOf(accounts ...CustomerAccount) *Snapshot {
+	login := LoginSnapshot{LoginId: "1"}
+	for _, a := range accounts {
+		login.Accounts = append(login.Accounts, AccountSnapshot{CustomerAccount: a})
+	}
+
+	return &Snapshot{Logins: []LoginSnapshot{login}}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	previous := snapshotOf(
+		CustomerAccount{AccountId: "1", Status: AccountActive},
+		CustomerAccount{AccountId: "2", Status: AccountActive},
+		CustomerAccount{AccountId: "3", Status: AccountClosed},
+		CustomerAccount{AccountId: "4"},
+	)
+	current := snapshotOf(
+		CustomerAccount{AccountId: "1", Status: AccountActive},
+		CustomerAccount{AccountId: "2", Status: "closed"},
+		CustomerAccount{AccountId: "3", Status: AccountActive},
+		CustomerAccount{AccountId: "5"},
+	)
+
+	d := DiffSnapshots(previous, current)
+	assert.False(t, d.Empty())
+	assert.Equal(t, 1, len(d.Closed))
+	assert.Equal(t, "2", d.Closed[0].AccountId.String())
+	assert.Equal(t, "3", d.Reopened[0].AccountId.String())
+	assert.Equal(t, "5", d.Added[0].AccountId.String())
+	assert.Equal(t, "4", d.Removed[0].AccountId.String())
+
+	assert.True(t, DiffSnapshots(current, current).Empty())
+	assert.Equal(t, 4, len(DiffSnapshots(nil, current).Added))
+}
+
+func TestFilterAccounts(t *testing.T) {
+	accounts := []CustomerAccount{{AccountId: "1", Status: AccountActive}, {AccountId: "2", Status: AccountClosed}}
+
+	closed := FilterAccounts(accounts, CustomerAccount.IsClosed)
+	assert.Equal(t, 1, len(closed))
+	assert.Equal(t, "2", closed[0].AccountId.String())
+}
+
+func TestWatcherEvents(t *testing.T) {
+	txn := Transaction{Id: "1", PayeeName: "Coffee", Amount: MustParseDecimal("-3")}
+	login := func(balance Amount, status string, transactions ...Transaction) *Snapshot {
+		return &Snapshot{Logins: []LoginSnapshot{{LoginId: "9", Accounts: []AccountSnapshot{
+			{CustomerAccount: CustomerAccount{AccountId: "1", BalanceAmount: balance, AggrStatusCode: status}, Transactions: transactions},
+		}}}}
+	}
+
+	snapshots := []*Snapshot{
+		login(100, "0", txn),
+		login(100, "0", txn),
+		login(97, "0", txn, Transaction{Id: "2", PayeeName: "Bakery", Amount: MustParseDecimal("-5")}),
+		login(97, "103", txn),
+	}
+
+	events := make([]Event, 0)
+	w := &Watcher{
+		Snapshot: func(q *TransactionQuery) (*Snapshot, error) {
+			s := snapshots[0]
+			snapshots = snapshots[1:]
+			return s, nil
+		},
+		Handle: func(e Event) {
+			events = append(events, e)
+		},
+	}
+
+	for range snapshots {
+		assert.NoError(t, w.Poll())
+	}
+
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, EventBalanceChanged, events[0].Type)
+	assert.Equal(t, Amount(100), events[0].PreviousBalance)
+	assert.Equal(t, EventTransactionsAdded, events[1].Type)
+	assert.Equal(t, "Bakery", events[1].Transactions[0].PayeeName)
+	assert.Equal(t, EventLoginBroken, events[2].Type)
+	assert.Equal(t, "9", events[2].LoginId)
+	assert.Equal(t, "103", events[2].Code)
+}
@@ -0,0 +1,163 @@
+package intuit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+/*
+DiscoverResult is the full response of a discover and add request, including accounts which were found but could not be added.
+*/
+type DiscoverResult struct {
+	Accounts  []DiscoveredAccount `json:"accounts"`
+	IntuitTid string              `json:"-"`
+}
+
+type DiscoveredAccount struct {
+	CustomerAccount
+	ErrorInfo *ErrorInfo `json:"errorInfo,omitempty"`
+}
+
+type ErrorInfo struct {
+	ErrorType     string `json:"errorType"`
+	ErrorCode     string `json:"errorCode"`
+	ErrorMessage  string `json:"errorMessage"`
+	CorrelationId string `json:"correlationId"`
+}
+
+/*
+Build a DiscoverResult from a raw discover response, such as the data returned by RespondToChallenge.
+*/
+func NewDiscoverResult(data interface{}) (*DiscoverResult, error) {
+	return defaultClient().NewDiscoverResult(data)
+}
+
+/*
+Build a DiscoverResult from a raw discover response, taking dates without a zone to be in the client's Location. See NewDiscoverResult.
+*/
+func (c *Client) NewDiscoverResult(data interface{}) (*DiscoverResult, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiscoverResult{}
+	err = decodeTyped(c.Configuration(), "", b, result)
+	return result, err
+}
+
+func decodeDiscoverResult(configuration *Configuration, institutionId string, body []byte, header http.Header) (*DiscoverResult, error) {
+	result := &DiscoverResult{}
+	if err := decodeTyped(configuration, fmt.Sprintf("institutions/%v/logins", institutionId), body, result); err != nil {
+		return nil, err
+	}
+	result.IntuitTid = intuitTid(header)
+
+	return result, nil
+}
+
+/*
+Report whether the account was added successfully.
+*/
+func (a DiscoveredAccount) Added() bool {
+	return a.ErrorInfo == nil && (a.AggrStatusCode == "" || a.AggrStatusCode == "0")
+}
+
+/*
+Return the code explaining the account's status: that of its ErrorInfo, otherwise its AggrStatusCode. "0" or empty means the account was added.
+*/
+func (a DiscoveredAccount) StatusCode() string {
+	if a.ErrorInfo != nil && a.ErrorInfo.ErrorCode != "" {
+		return a.ErrorInfo.ErrorCode
+	}
+
+	return a.AggrStatusCode
+}
+
+/*
+Return why the account could not be added as an *AccountError, or nil if it was added.
+*/
+func (a DiscoveredAccount) Err() error {
+	if a.Added() {
+		return nil
+	}
+
+	e := &AccountError{AccountId: a.AccountId.String(), AccountNumber: a.AccountNumber, Code: a.StatusCode()}
+	if a.ErrorInfo != nil {
+		e.Type = a.ErrorInfo.ErrorType
+		e.Message = a.ErrorInfo.ErrorMessage
+		e.CorrelationId = a.ErrorInfo.CorrelationId
+	}
+
+	return e
+}
+
+/*
+AccountError is the reason a discovered account could not be added, such as an unsupported account type.
+*/
+type AccountError struct {
+	AccountId     string
+	AccountNumber string
+
+	// The CAD error or aggregation status code, and Intuit's description of it when given.
+	Code          string
+	Type          string
+	Message       string
+	CorrelationId string
+}
+
+func (e *AccountError) Error() string {
+	s := fmt.Sprintf("intuit: account %s not added: code %s", e.AccountId, e.Code)
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+
+	return s
+}
+
+/*
+Return the user message for the error's code in a language. See UserMessages; use Client.UserMessage with the error's Code for a client's TranslateUserMessage.
+*/
+func (e *AccountError) UserMessage(lang string) string {
+	return UserMessage(e.Code, lang)
+}
+
+/*
+Return a BatchError holding an *AccountError for each account which could not be added, or nil if all were.
+*/
+func (r *DiscoverResult) Err() error {
+	batch := NewBatchError("add accounts", len(r.Accounts))
+	for i, a := range r.Accounts {
+		if err := a.Err(); err != nil {
+			batch.Add(i, a.AccountId.String(), err)
+		}
+	}
+
+	return batch.Err()
+}
+
+/*
+Return the accounts which were added successfully.
+*/
+func (r *DiscoverResult) Added() []DiscoveredAccount {
+	return r.filter(true)
+}
+
+/*
+Return the accounts which were discovered but could not be added.
+*/
+func (r *DiscoverResult) Failed() []DiscoveredAccount {
+	return r.filter(false)
+}
+
+func (r *DiscoverResult) filter(added bool) []DiscoveredAccount {
+	accounts := make([]DiscoveredAccount, 0)
+	for _, a := range r.Accounts {
+		if a.Added() == added {
+			accounts = append(accounts, a)
+		}
+	}
+
+	return accounts
+}
@@ -0,0 +1,56 @@
+package intuit
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNewDiscoverResult(t *testing.T) {
+	var data interface{}
+	d := json.NewDecoder(strings.NewReader(`{"accounts": [
+		{"accountId": 400000000001, "institutionLoginId": 7001, "aggrStatusCode": "0"},
+		{"accountId": 400000000002, "institutionLoginId": 7001, "aggrStatusCode": "108",
+			"errorInfo": {"errorType": "APP_ERROR", "errorCode": "108", "errorMessage": "Unsupported account type"}}
+	]}`))
+	d.UseNumber()
+	assert.NoError(t, d.Decode(&data))
+
+	result, err := NewDiscoverResult(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Accounts))
+	assert.Equal(t, "400000000001", result.Added()[0].AccountId.String())
+
+	failed := result.Failed()
+	assert.Equal(t, 1, len(failed))
+	assert.Equal(t, "108", failed[0].ErrorInfo.ErrorCode)
+}
+
+func TestDiscoverResultErr(t *testing.T) {
+	result := &DiscoverResult{Accounts: []DiscoveredAccount{
+		{CustomerAccount: CustomerAccount{AccountId: "1", AggrStatusCode: "0"}},
+		{CustomerAccount: CustomerAccount{AccountId: "2", AccountNumber: "xxxx1234"}, ErrorInfo: &ErrorInfo{ErrorType: "APP_ERROR", ErrorCode: "108", ErrorMessage: "Unsupported account type"}},
+		{CustomerAccount: CustomerAccount{AccountId: "3", AggrStatusCode: "103"}},
+	}}
+
+	assert.Nil(t, result.Accounts[0].Err())
+	assert.Equal(t, "103", result.Accounts[2].StatusCode())
+
+	err := result.Err()
+	var batch *BatchError
+	if assert.True(t, errors.As(err, &batch)) {
+		assert.Equal(t, []string{"2", "3"}, batch.Ids())
+	}
+
+	var accountErr *AccountError
+	if assert.True(t, errors.As(err, &accountErr)) {
+		assert.Equal(t, "108", accountErr.Code)
+		assert.Equal(t, "xxxx1234", accountErr.AccountNumber)
+		assert.Contains(t, accountErr.Error(), "Unsupported account type")
+	}
+
+	result.Accounts = result.Accounts[:1]
+	assert.NoError(t, result.Err())
+}
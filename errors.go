@@ -0,0 +1,188 @@
+package intuit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+/*
+TransportError is returned when a request could not be completed at the network level, such as DNS, connection or TLS failures. No response was received from Intuit.
+*/
+type TransportError struct {
+	Method   string
+	Endpoint string
+	Err      error
+
+	// Client-generated Id of the call. See Request.
+	RequestId string
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("intuit: %s %s: %v", e.Method, e.Endpoint, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+/*
+APIError is returned when Intuit responds with a non-2xx status code. The decoded error payload, when present, is available through Data.
+*/
+type APIError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Status     string
+	Body       []byte
+	Header     http.Header
+	Data       interface{}
+	Message    string
+
+	// Intuit's error code from the payload, and a remediation hint for well-known codes. See ErrorHints.
+	Code string
+	Hint string
+
+	// Intuit's transaction Id for the request, required by Intuit support for any investigation.
+	IntuitTid string
+
+	// Client-generated Id of the call. See Request.
+	RequestId string
+
+	// Configuration the call was made with, whose TranslateUserMessage UserMessage uses.
+	configuration *Configuration
+}
+
+func (e *APIError) Error() string {
+	s := fmt.Sprintf("intuit: %s %s: %s", e.Method, e.Endpoint, e.Status)
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+
+	if e.Hint != "" {
+		s += fmt.Sprintf(" (code %s: %s)", e.Code, e.Hint)
+	} else if e.Code != "" {
+		s += fmt.Sprintf(" (code %s)", e.Code)
+	}
+
+	return s
+}
+
+/*
+DecodeError is returned when a response was received but its body could not be parsed.
+
+When the body is valid JSON that does not fit the typed result, such as after a change to Intuit's schema, no data is lost: Raw holds the whole body decoded generically, and Partial the typed result with every field that did fit filled in.
+*/
+type DecodeError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Body       []byte
+	Err        error
+	IntuitTid  string
+	RequestId  string
+
+	// The body decoded as generic JSON, as returned by Do, or nil if it is not valid JSON.
+	Raw interface{}
+
+	// The typed result being decoded, such as a *CustomerAccount, partially filled. Nil for untyped requests.
+	Partial interface{}
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("intuit: %s %s: decoding response: %v", e.Method, e.Endpoint, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+/*
+InternalError is returned when a request panicked, such as on an unexpected payload deep in decoding or in a middleware, rather than crashing the program. Stack holds the goroutine's stack at the panic.
+*/
+type InternalError struct {
+	Method    string
+	Endpoint  string
+	RequestId string
+
+	// The value passed to panic.
+	Value interface{}
+	Stack []byte
+}
+
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("intuit: %s %s: internal error: %v", e.Method, e.Endpoint, e.Value)
+}
+
+/*
+Return the panic value if it was an error.
+*/
+func (e *InternalError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+/*
+Recover from a panic in the current request, replacing *err with an InternalError. Must be called directly by defer.
+*/
+func recoverInternal(method string, endpoint string, requestId string, err *error) {
+	if v := recover(); v != nil {
+		*err = &InternalError{Method: method, Endpoint: endpoint, RequestId: requestId, Value: v, Stack: debug.Stack()}
+	}
+}
+
+/*
+Return the HTTP status code of the response an error arose from, looking through wrapped errors. Zero is returned when no response was received, as with a TransportError, or the error did not come from a request.
+*/
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return decodeErr.StatusCode
+	}
+
+	return 0
+}
+
+/*
+Matches every NotFoundError with errors.Is.
+*/
+var ErrNotFound = errors.New("intuit: not found")
+
+/*
+NotFoundError is returned when a resource does not exist, such as an account which has been deleted: either Intuit responded 404, held in Err, or a successful response did not include it.
+*/
+type NotFoundError struct {
+	// The kind of resource, such as "account" or "login".
+	Resource string
+	Id       string
+	Err      error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("intuit: %s %s not found", e.Resource, e.Id)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+/*
+Return a NotFoundError for the resource if err is a 404, otherwise err.
+*/
+func notFound(resource string, id string, err error) error {
+	if StatusCode(err) == http.StatusNotFound {
+		return &NotFoundError{Resource: resource, Id: id, Err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,181 @@
+package intuit
+
+import (
+	"context"
+	"time"
+)
+
+/*
+EventType identifies what happened to an account.
+*/
+type EventType string
+
+const (
+	EventAccountAdded      EventType = "ACCOUNT_ADDED"
+	EventAccountRemoved    EventType = "ACCOUNT_REMOVED"
+	EventAccountClosed     EventType = "ACCOUNT_CLOSED"
+	EventAccountReopened   EventType = "ACCOUNT_REOPENED"
+	EventBalanceChanged    EventType = "BALANCE_CHANGED"
+	EventTransactionsAdded EventType = "TRANSACTIONS_ADDED"
+	EventLoginBroken       EventType = "LOGIN_BROKEN"
+)
+
+/*
+Event is a single change to a customer's accounts, as found by comparing snapshots.
+*/
+type Event struct {
+	Type    EventType
+	Account CustomerAccount
+
+	// Time of the snapshot the change was found in.
+	Time time.Time
+
+	// Balance before a BalanceChanged event.
+	PreviousBalance Amount
+
+	// Transactions of a TransactionsAdded event.
+	Transactions []Transaction
+
+	// Login and aggregation status code of a LoginBroken event.
+	LoginId string
+	Code    string
+}
+
+/*
+Return the changes in the diff as events, grouped by type in the order EventAccountAdded, EventAccountRemoved, EventAccountClosed, EventAccountReopened, EventBalanceChanged, EventTransactionsAdded, EventLoginBroken.
+*/
+func (d *Diff) Events(at time.Time) []Event {
+	events := make([]Event, 0)
+	add := func(t EventType, accounts []CustomerAccount) {
+		for _, a := range accounts {
+			events = append(events, Event{Type: t, Account: a, Time: at})
+		}
+	}
+
+	add(EventAccountAdded, d.Added)
+	add(EventAccountRemoved, d.Removed)
+	add(EventAccountClosed, d.Closed)
+	add(EventAccountReopened, d.Reopened)
+
+	for _, c := range d.BalanceChanges {
+		events = append(events, Event{Type: EventBalanceChanged, Account: c.Account, Time: at, PreviousBalance: c.Previous})
+	}
+	for _, t := range d.NewTransactions {
+		events = append(events, Event{Type: EventTransactionsAdded, Account: t.Account, Time: at, Transactions: t.Transactions})
+	}
+	for _, l := range d.BrokenLogins {
+		events = append(events, Event{Type: EventLoginBroken, Account: l.Account, Time: at, LoginId: l.LoginId, Code: l.Code})
+	}
+
+	return events
+}
+
+/*
+Default interval between the snapshots taken by a Watcher.
+*/
+const DefaultWatchInterval = time.Hour
+
+/*
+Watcher polls the scoped customer's accounts, reporting each change as an Event.
+
+	w := &intuit.Watcher{
+		Interval: 15 * time.Minute,
+		Handle: func(e intuit.Event) {
+			if e.Type == intuit.EventLoginBroken {
+				notifyUser(e.LoginId)
+			}
+		},
+	}
+	err := w.Run(ctx)
+*/
+type Watcher struct {
+	// Time between snapshots. Defaults to DefaultWatchInterval.
+	Interval time.Duration
+
+	// Transactions included in each snapshot, for EventTransactionsAdded events. No transactions are fetched when nil.
+	Query *TransactionQuery
+
+	// Snapshot changes are reported against. When nil, the first snapshot taken becomes the baseline. After each poll it holds the latest snapshot, so it can be saved to resume watching later.
+	Previous *Snapshot
+
+	// Take a snapshot. Defaults to CustomerSnapshot.
+	Snapshot func(q *TransactionQuery) (*Snapshot, error)
+
+	// Receives each event.
+	Handle func(Event)
+}
+
+/*
+Poll until the context is cancelled or a snapshot fails, handing each event to Handle.
+*/
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Poll(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+/*
+Take a single snapshot and report the changes since the previous one.
+*/
+func (w *Watcher) Poll() error {
+	snapshot := w.Snapshot
+	if snapshot == nil {
+		snapshot = CustomerSnapshot
+	}
+
+	current, err := snapshot(w.Query)
+	if err != nil {
+		return err
+	}
+
+	if w.Previous != nil && w.Handle != nil {
+		for _, e := range DiffSnapshots(w.Previous, current).Events(current.CreatedAt) {
+			w.Handle(e)
+		}
+	}
+
+	w.Previous = current
+	return nil
+}
+
+/*
+Run the watcher, delivering events on a channel instead of to Handle. Both channels are closed when the watcher stops; the error, if any, is delivered first. Any Handle set on w is replaced.
+*/
+func (w *Watcher) Chan(ctx context.Context) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	w.Handle = func(e Event) {
+		select {
+		case events <- e:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		if err := w.Run(ctx); err != nil && err != ctx.Err() {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
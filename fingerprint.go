@@ -0,0 +1,49 @@
+package intuit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+/*
+Return a stable identifier for the transaction, for use as an idempotency key when ingesting transactions into other systems.
+
+The fingerprint is the hex SHA-256 of the account Id, posted date, amount to the cent, whitespace-normalized payee name, Intuit transaction Id and institution transaction Id, so the same transaction fetched again yields the same fingerprint. Any change to those fields, such as a pending transaction posting with a different amount, yields a new fingerprint.
+
+Transactions which agree on every one of those fields collide, which can happen for repeated identical purchases at institutions that report no transaction Ids. Use Fingerprints to tell such transactions apart within a batch.
+*/
+func (t Transaction) Fingerprint() string {
+	fields := []string{
+		t.AccountId,
+		t.PostedDate.Format(transactionDateFormat),
+		Decimal{Units: t.Amount.Minor(2), Scale: 2}.String(),
+		strings.Join(strings.Fields(t.PayeeName), " "),
+		t.Id.String(),
+		t.InstitutionTransactionId,
+	}
+
+	// The unit separator cannot appear in the fields, so distinct field values never join to the same input.
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+/*
+Return the fingerprint of each transaction in a batch. Where several transactions share a fingerprint, the second and later occurrences are suffixed with their occurrence number ("-2", "-3", ...), in the order given. The result is stable provided the institution reports the transactions in a consistent order.
+*/
+func Fingerprints(transactions []Transaction) []string {
+	fingerprints := make([]string, len(transactions))
+	seen := make(map[string]int)
+
+	for i, t := range transactions {
+		f := t.Fingerprint()
+		seen[f]++
+		if n := seen[f]; n > 1 {
+			f += "-" + strconv.Itoa(n)
+		}
+		fingerprints[i] = f
+	}
+
+	return fingerprints
+}
@@ -0,0 +1,47 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFingerprint(t *testing.T) {
+	posted, _ := ParseDate("2014-05-01T00:00:00-07:00")
+	txn := Transaction{AccountId: "1", Id: "10", InstitutionTransactionId: "abc", PostedDate: posted, Amount: MustParseDecimal("-12.5"), PayeeName: "Corner  Coffee "}
+
+	f := txn.Fingerprint()
+	assert.Equal(t, 64, len(f))
+	assert.Equal(t, "d94e5284f6b41a19c8e4103659a3625ef63a927ffb97a74c300bffce645284d7", f)
+
+	same := txn
+	same.PayeeName = "Corner Coffee"
+	same.Memo = "ignored"
+	same.IntuitTid = "ignored"
+	same.Amount = MustParseDecimal("-12.500000001")
+	assert.Equal(t, f, same.Fingerprint())
+
+	for _, changed := range []Transaction{
+		{AccountId: "2", Id: "10", InstitutionTransactionId: "abc", PostedDate: posted, Amount: MustParseDecimal("-12.5"), PayeeName: "Corner Coffee"},
+		{AccountId: "1", Id: "10", InstitutionTransactionId: "abc", PostedDate: Date{Time: posted.Add(24 * time.Hour)}, Amount: MustParseDecimal("-12.5"), PayeeName: "Corner Coffee"},
+		{AccountId: "1", Id: "10", InstitutionTransactionId: "abc", PostedDate: posted, Amount: MustParseDecimal("-12.51"), PayeeName: "Corner Coffee"},
+		{AccountId: "1", Id: "10", InstitutionTransactionId: "abc", PostedDate: posted, Amount: MustParseDecimal("-12.5"), PayeeName: "Corner Cafe"},
+		{AccountId: "1", Id: "11", InstitutionTransactionId: "abc", PostedDate: posted, Amount: MustParseDecimal("-12.5"), PayeeName: "Corner Coffee"},
+		{AccountId: "1", Id: "10", InstitutionTransactionId: "abd", PostedDate: posted, Amount: MustParseDecimal("-12.5"), PayeeName: "Corner Coffee"},
+	} {
+		assert.NotEqual(t, f, changed.Fingerprint())
+	}
+
+	// Field boundaries are unambiguous.
+	assert.NotEqual(t, Transaction{AccountId: "1", Id: "23"}.Fingerprint(), Transaction{AccountId: "12", Id: "3"}.Fingerprint())
+}
+
+func TestFingerprintsDisambiguateCollisions(t *testing.T) {
+	coffee := Transaction{AccountId: "1", Amount: MustParseDecimal("-3"), PayeeName: "Coffee"}
+	fingerprints := Fingerprints([]Transaction{coffee, {AccountId: "1", Amount: MustParseDecimal("-4")}, coffee, coffee})
+
+	assert.Equal(t, coffee.Fingerprint(), fingerprints[0])
+	assert.Equal(t, coffee.Fingerprint()+"-2", fingerprints[2])
+	assert.Equal(t, coffee.Fingerprint()+"-3", fingerprints[3])
+	assert.NotEqual(t, fingerprints[0], fingerprints[1])
+}
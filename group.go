@@ -0,0 +1,146 @@
+package intuit
+
+import (
+	"sort"
+	"strings"
+)
+
+/*
+InstitutionGroup is an institution with the customer's accounts there, the shape of a typical account list screen.
+*/
+type InstitutionGroup struct {
+	InstitutionId string
+
+	// The institution's details, nil if they could not be looked up.
+	Institution *InstitutionDetails
+
+	// Display name, falling back to the Id when the details are unavailable.
+	Name    string
+	LogoURL string
+
+	// Status of each login at the institution, ordered by Id.
+	Logins []LoginStatus
+
+	// Accounts ordered by type, then by balance, largest first.
+	Accounts []CustomerAccount
+}
+
+/*
+LoginStatus is the aggregation status of a login, taken from its accounts.
+*/
+type LoginStatus struct {
+	LoginId string
+
+	// The first non-zero aggregation status code of the login's accounts, or empty when all are aggregating.
+	Code string
+}
+
+/*
+Report whether the login is aggregating successfully.
+*/
+func (s LoginStatus) Healthy() bool {
+	return s.Code == ""
+}
+
+/*
+Report whether every login at the institution is aggregating successfully.
+*/
+func (g *InstitutionGroup) Healthy() bool {
+	for _, l := range g.Logins {
+		if !l.Healthy() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Display order of account types.
+var accountTypeOrder = map[AccountType]int{
+	BankingType:    0,
+	CreditType:     1,
+	InvestmentType: 2,
+	LoanType:       3,
+	RewardsType:    4,
+	OtherType:      5,
+}
+
+/*
+Return the scoped customer's accounts grouped by institution, ordered by institution name.
+
+As with AccountsWithInstitutions, if any institution lookup fails the groups are still returned, those at the failed institutions without details, alongside a BatchError.
+*/
+func AccountsGrouped() ([]InstitutionGroup, error) {
+	return defaultClient().AccountsGrouped()
+}
+
+/*
+Return the scoped customer's accounts grouped by institution. See AccountsGrouped.
+*/
+func (c *Client) AccountsGrouped() ([]InstitutionGroup, error) {
+	accounts, err := c.AccountsWithInstitutions()
+	if accounts == nil {
+		return nil, err
+	}
+
+	return GroupAccounts(accounts), err
+}
+
+/*
+Group accounts joined with their institutions, as returned by AccountsWithInstitutions. See AccountsGrouped.
+*/
+func GroupAccounts(accounts []AccountWithInstitution) []InstitutionGroup {
+	index := make(map[string]int)
+	groups := make([]InstitutionGroup, 0)
+	logins := make([]map[string]string, 0)
+
+	for _, a := range accounts {
+		id := a.InstitutionId.String()
+		i, ok := index[id]
+		if !ok {
+			i = len(groups)
+			index[id] = i
+			g := InstitutionGroup{InstitutionId: id, Institution: a.Institution, Name: id}
+			if a.Institution != nil {
+				g.Name = a.Institution.InstitutionName
+				g.LogoURL = a.Institution.LogoURL
+			}
+			groups = append(groups, g)
+			logins = append(logins, make(map[string]string))
+		}
+
+		groups[i].Accounts = append(groups[i].Accounts, a.CustomerAccount)
+
+		loginId := a.InstitutionLoginId.String()
+		if _, seen := logins[i][loginId]; !seen {
+			logins[i][loginId] = ""
+		}
+		if logins[i][loginId] == "" && !aggregating(a.CustomerAccount) {
+			logins[i][loginId] = a.AggrStatusCode
+		}
+	}
+
+	for i := range groups {
+		g := &groups[i]
+		for id, code := range logins[i] {
+			g.Logins = append(g.Logins, LoginStatus{LoginId: id, Code: code})
+		}
+		sort.Slice(g.Logins, func(a, b int) bool {
+			return lessId(g.Logins[a].LoginId, g.Logins[b].LoginId)
+		})
+
+		sort.SliceStable(g.Accounts, func(a, b int) bool {
+			x, y := g.Accounts[a], g.Accounts[b]
+			if x.Type() != y.Type() {
+				return accountTypeOrder[x.Type()] < accountTypeOrder[y.Type()]
+			}
+			return x.BalanceAmount > y.BalanceAmount
+		})
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		return strings.ToLower(groups[a].Name) < strings.ToLower(groups[b].Name)
+	})
+
+	return groups
+}
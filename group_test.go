@@ -0,0 +1,35 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGroupAccounts(t *testing.T) {
+	chase := &InstitutionDetails{InstitutionId: "1", InstitutionName: "Chase", LogoURL: "https://example.com/chase.gif"}
+	accounts := []AccountWithInstitution{
+		{CustomerAccount{AccountId: "10", InstitutionId: "1", InstitutionLoginId: "100", CreditAccountType: "CREDITCARD", BalanceAmount: -50}, chase},
+		{CustomerAccount{AccountId: "11", InstitutionId: "1", InstitutionLoginId: "100", BankingAccountType: "SAVINGS", BalanceAmount: 10}, chase},
+		{CustomerAccount{AccountId: "12", InstitutionId: "1", InstitutionLoginId: "101", BankingAccountType: "CHECKING", BalanceAmount: 500, AggrStatusCode: "103"}, chase},
+		{CustomerAccount{AccountId: "20", InstitutionId: "2", InstitutionLoginId: "200", BankingAccountType: "CHECKING"}, nil},
+	}
+
+	groups := GroupAccounts(accounts)
+	assert.Equal(t, 2, len(groups))
+
+	assert.Equal(t, "2", groups[0].Name)
+	assert.Nil(t, groups[0].Institution)
+	assert.True(t, groups[0].Healthy())
+
+	g := groups[1]
+	assert.Equal(t, "Chase", g.Name)
+	assert.Equal(t, "https://example.com/chase.gif", g.LogoURL)
+	assert.Equal(t, []LoginStatus{{LoginId: "100"}, {LoginId: "101", Code: "103"}}, g.Logins)
+	assert.False(t, g.Healthy())
+
+	ids := make([]string, len(g.Accounts))
+	for i, a := range g.Accounts {
+		ids[i] = a.AccountId.String()
+	}
+	assert.Equal(t, []string{"12", "11", "10"}, ids)
+}
@@ -0,0 +1,42 @@
+package intuit
+
+import "fmt"
+
+/*
+Remediation hints for well-known CAD error and aggregation status codes, attached to APIError as Hint. Callers may add or override entries.
+*/
+var ErrorHints = map[string]string{
+	"102": "the institution is temporarily unavailable; retry later",
+	"103": "the login credentials were rejected; the user must re-enter credentials",
+	"106": "the institution's website is down; retry later",
+	"108": "the user must log in to the institution's website to resolve a pending action",
+	"109": "the institution requires a password change; the user must change it on the institution's website, then update the login",
+	"185": "MFA required; answer the challenge with RespondToChallenge",
+	"186": "the MFA answer was incorrect; prompt the user again",
+	"187": "MFA required; call RespondToChallenge with the user's answers",
+}
+
+/*
+Return the first error code in an error payload, which carries either an errorInfo list, a single errorInfo object or a bare errorCode.
+*/
+func errorCode(data interface{}) string {
+	payload, ok := data.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	if code, ok := payload["errorCode"]; ok {
+		return fmt.Sprint(code)
+	}
+
+	switch info := payload["errorInfo"].(type) {
+	case []interface{}:
+		if len(info) > 0 {
+			return errorCode(info[0])
+		}
+	case map[string]interface{}:
+		return errorCode(info)
+	}
+
+	return ""
+}
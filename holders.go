@@ -0,0 +1,103 @@
+package intuit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+/*
+AccountHolderRole is a holder's relationship to an account.
+*/
+type AccountHolderRole string
+
+const (
+	PrimaryHolder   AccountHolderRole = "PRIMARY"
+	SecondaryHolder AccountHolderRole = "SECONDARY"
+	JointHolder     AccountHolderRole = "JOINT"
+	Custodian       AccountHolderRole = "CUSTODIAN"
+	Trustee         AccountHolderRole = "TRUSTEE"
+	AuthorizedUser  AccountHolderRole = "AUTHORIZED_USER"
+)
+
+/*
+AccountHolder is one of the people or entities on an account.
+*/
+type AccountHolder struct {
+	Name      string            `json:"name"`
+	Role      AccountHolderRole `json:"role,omitempty"`
+	Ownership string            `json:"ownership,omitempty"`
+
+	// Share of the account owned by the holder, when reported.
+	OwnershipPercentage float64 `json:"ownershipPercentage,omitempty"`
+}
+
+type AccountHolders []AccountHolder
+
+/*
+Accept holders as a bare array, wrapped in a "holder" element, as a single holder object or as a bare name, as converted from Intuit's XML schema.
+*/
+func (h *AccountHolders) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		*h = nil
+		return nil
+	case bytes.HasPrefix(b, []byte(`"`)):
+		var name string
+		if err := json.Unmarshal(b, &name); err != nil {
+			return err
+		}
+		*h = AccountHolders{{Name: name}}
+		return nil
+	case bytes.HasPrefix(b, []byte("[")):
+		var holders []AccountHolder
+		if err := json.Unmarshal(b, &holders); err != nil {
+			return err
+		}
+		*h = holders
+		return nil
+	}
+
+	var wrapped struct {
+		Holder json.RawMessage `json:"holder"`
+	}
+	if err := json.Unmarshal(b, &wrapped); err != nil {
+		return err
+	}
+	if wrapped.Holder != nil {
+		return h.UnmarshalJSON(wrapped.Holder)
+	}
+
+	var holder AccountHolder
+	if err := json.Unmarshal(b, &holder); err != nil {
+		return err
+	}
+	*h = AccountHolders{holder}
+	return nil
+}
+
+/*
+Return the account's primary holder: the holder with the PRIMARY role, otherwise the first listed.
+*/
+func (h AccountHolders) Primary() (AccountHolder, bool) {
+	for _, holder := range h {
+		if AccountHolderRole(strings.ToUpper(string(holder.Role))) == PrimaryHolder {
+			return holder, true
+		}
+	}
+
+	if len(h) > 0 {
+		return h[0], true
+	}
+
+	return AccountHolder{}, false
+}
+
+/*
+Report whether the account has more than one holder.
+*/
+func (h AccountHolders) Joint() bool {
+	return len(h) > 1
+}
@@ -0,0 +1,31 @@
+package intuit
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccountHolders(t *testing.T) {
+	cases := map[string]int{
+		`{"holders": [{"name": "Ann", "role": "SECONDARY"}, {"name": "Bob", "role": "PRIMARY", "ownership": "JOINT"}]}`: 2,
+		`{"holders": {"holder": [{"name": "Ann"}, {"name": "Bob", "role": "primary"}]}}`:                                2,
+		`{"holders": {"holder": {"name": "Bob"}}}`:                                                                      1,
+		`{"holders": {"name": "Bob", "role": "PRIMARY"}}`:                                                               1,
+		`{"holders": "Bob"}`: 1,
+		`{"holders": null}`:  0,
+	}
+
+	for in, count := range cases {
+		var a CustomerAccount
+		assert.NoError(t, json.Unmarshal([]byte(in), &a), in)
+		assert.Equal(t, count, len(a.Holders), in)
+
+		if count > 0 {
+			primary, ok := a.Holders.Primary()
+			assert.True(t, ok)
+			assert.Equal(t, "Bob", primary.Name, in)
+		}
+		assert.Equal(t, count > 1, a.Holders.Joint())
+	}
+}
@@ -0,0 +1,178 @@
+package intuit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+type InstitutionDetails struct {
+	InstitutionId   json.Number     `json:"institutionId"`
+	InstitutionName string          `json:"institutionName"`
+	HomeURL         string          `json:"homeUrl"`
+	LogoURL         string          `json:"logoUrl,omitempty"`
+	PhoneNumber     string          `json:"phoneNumber"`
+	Keys            InstitutionKeys `json:"keys"`
+	Capabilities    Capabilities    `json:"capabilities,omitempty"`
+	IntuitTid       string          `json:"-"`
+}
+
+/*
+InstitutionKey describes a single credential field of an institution's login form.
+*/
+type InstitutionKey struct {
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	ValueLengthMin int    `json:"valueLengthMin"`
+	ValueLengthMax int    `json:"valueLengthMax"`
+	DisplayFlag    bool   `json:"displayFlag"`
+	DisplayOrder   int    `json:"displayOrder"`
+	Mask           bool   `json:"mask"`
+	Instructions   string `json:"instructions"`
+	Description    string `json:"description"`
+}
+
+/*
+InstitutionKeys are the credential fields of an institution, sorted by display order.
+*/
+type InstitutionKeys []InstitutionKey
+
+/*
+Accept keys either as a bare array or wrapped in a "key" element, as converted from Intuit's XML schema.
+*/
+func (k *InstitutionKeys) UnmarshalJSON(b []byte) error {
+	var keys []InstitutionKey
+	if err := json.Unmarshal(b, &keys); err != nil {
+		var wrapped struct {
+			Key []InstitutionKey `json:"key"`
+		}
+		if err := json.Unmarshal(b, &wrapped); err != nil {
+			return err
+		}
+		keys = wrapped.Key
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].DisplayOrder < keys[j].DisplayOrder
+	})
+	*k = keys
+	return nil
+}
+
+/*
+InstitutionSummary is an institution as listed by Institutions. Fetch its InstitutionDetails for the credential fields of its login form.
+*/
+type InstitutionSummary struct {
+	InstitutionId   json.Number `json:"institutionId"`
+	InstitutionName string      `json:"institutionName"`
+	HomeURL         string      `json:"homeUrl"`
+	PhoneNumber     string      `json:"phoneNumber,omitempty"`
+	Virtual         bool        `json:"virtual,omitempty"`
+}
+
+type institutionList struct {
+	Institution []InstitutionSummary `json:"institution"`
+}
+
+/*
+Return the username and password fields of the institution's login form, to pass to DiscoverAndAddAccounts:
+
+	keys, err := details.CredentialKeys()
+	accounts, session, err := intuit.DiscoverAndAddAccounts(id, username, password, keys.Username, keys.Password)
+
+The username is the first displayed field which is not masked and the password the first masked one. Any other displayed fields are listed in Extra; build the full set with Credentials and log in with DiscoverAndAddAccountsWithCredentials.
+*/
+func (i *InstitutionDetails) CredentialKeys() (CredentialKeys, error) {
+	keys := CredentialKeys{Extra: make([]string, 0)}
+	for _, k := range i.Keys {
+		switch {
+		case !k.DisplayFlag:
+		case !k.Mask && keys.Username == "":
+			keys.Username = k.Name
+		case k.Mask && keys.Password == "":
+			keys.Password = k.Name
+		default:
+			keys.Extra = append(keys.Extra, k.Name)
+		}
+	}
+
+	if keys.Username == "" || keys.Password == "" {
+		return keys, fmt.Errorf("intuit: institution %s has no username and password fields", i.InstitutionId)
+	}
+
+	return keys, nil
+}
+
+func (i *InstitutionDetails) setIntuitTid(tid string) {
+	i.IntuitTid = tid
+}
+
+/*
+Build InstitutionDetails from a raw institution response, such as one returned by Do.
+*/
+func NewInstitutionDetails(data interface{}) (*InstitutionDetails, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &InstitutionDetails{}
+	err = json.Unmarshal(b, details)
+	return details, err
+}
+
+var institutionCache = newPersistentCache("institution", func(b []byte) (interface{}, error) {
+	institution := &InstitutionDetails{}
+	return institution, json.Unmarshal(b, institution)
+})
+
+/*
+Clear the cached institution details used to enrich accounts.
+*/
+func ClearInstitutionCache() {
+	defaultClient().ClearInstitutionCache()
+}
+
+/*
+Clear the cached institution details, including those persisted to the client's KV.
+*/
+func (c *Client) ClearInstitutionCache() {
+	institutionCache.clear(c.Configuration())
+}
+
+/*
+Return an institution's details from the institution cache, fetching them when missing or stale, along with how fresh they are. See CacheTTL and StaleWhileRevalidate.
+*/
+func CachedInstitution(institutionId string) (*InstitutionDetails, Freshness, error) {
+	return defaultClient().CachedInstitution(institutionId)
+}
+
+/*
+Return an institution's details from the institution cache. See CachedInstitution.
+*/
+func (c *Client) CachedInstitution(institutionId string) (*InstitutionDetails, Freshness, error) {
+	return cachedInstitutionContext(c.background(), institutionId)
+}
+
+func cachedInstitutionContext(ctx context.Context, institutionId string) (*InstitutionDetails, Freshness, error) {
+	v, freshness, err := institutionCache.get(configurationFor(ctx), institutionId, func() (interface{}, error) {
+		return fetchInstitution(ctx, institutionId)
+	})
+	if err != nil {
+		return nil, freshness, err
+	}
+
+	return v.(*InstitutionDetails), freshness, nil
+}
+
+func fetchInstitution(ctx context.Context, institutionId string) (*InstitutionDetails, error) {
+	institution := &InstitutionDetails{}
+	err := fetch(ctx, GET, fmt.Sprintf("institutions/%s", institutionId), nil, nil, nil, institution)
+	return institution, err
+}
+
+func cachedInstitution(ctx context.Context, institutionId string) (*InstitutionDetails, error) {
+	institution, _, err := cachedInstitutionContext(ctx, institutionId)
+	return institution, err
+}
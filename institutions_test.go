@@ -0,0 +1,62 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestInstitutions(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/institutions":
+			w.Write([]byte(`{"institution": [{"institutionId": 100000, "institutionName": "DAG Site", "homeUrl": "http://www.intuit.com"}]}`))
+		case "/institutions/100000":
+			w.Write([]byte(`{"institutionId": 100000, "institutionName": "DAG Site", "keys": {"key": [
+				{"name": "Banking Password", "displayFlag": true, "displayOrder": 2, "mask": true},
+				{"name": "Banking Userid", "displayFlag": true, "displayOrder": 1},
+				{"name": "Hidden", "displayFlag": false, "displayOrder": 3},
+				{"name": "PIN", "displayFlag": true, "displayOrder": 4, "mask": true}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer done()
+
+	institutions, err := Institutions()
+	assert.NoError(t, err)
+	if assert.Len(t, institutions, 1) {
+		assert.Equal(t, "100000", institutions[0].InstitutionId.String())
+		assert.Equal(t, "DAG Site", institutions[0].InstitutionName)
+	}
+
+	details, err := Institution("100000")
+	assert.NoError(t, err)
+	keys, err := details.CredentialKeys()
+	assert.NoError(t, err)
+	assert.Equal(t, CredentialKeys{Username: "Banking Userid", Password: "Banking Password", Extra: []string{"PIN"}}, keys)
+
+	_, err = (&InstitutionDetails{InstitutionId: "1"}).CredentialKeys()
+	assert.Error(t, err)
+
+	_, err = Institution("2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestInstitutionsError(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer done()
+
+	institutions, err := Institutions()
+	assert.Nil(t, institutions)
+	_, ok := err.(*APIError)
+	assert.True(t, ok, "%v", err)
+	assert.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+
+	institution, err := Institution("100000")
+	assert.Nil(t, institution)
+	assert.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+}
@@ -0,0 +1,122 @@
+//go:build integration
+
+package intuit
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+/*
+Configure the live environment for an integration test, skipping it when credentials are not set. Run the suite before a release with:
+
+	INTUIT_CERTIFICATE=app.key INTUIT_CONSUMER_KEY=... INTUIT_CONSUMER_SECRET=... INTUIT_SAML_PROVIDER_ID=... \
+		go test -tags integration -run Integration
+
+Each test works on a fresh customer, named from INTUIT_CUSTOMER_ID when set, which is deleted afterwards.
+*/
+func configureIntegration(t *testing.T) func() {
+	configuration := &Configuration{
+		CertificatePath:     os.Getenv("INTUIT_CERTIFICATE"),
+		OAuthConsumerKey:    os.Getenv("INTUIT_CONSUMER_KEY"),
+		OAuthConsumerSecret: os.Getenv("INTUIT_CONSUMER_SECRET"),
+		SamlProviderId:      os.Getenv("INTUIT_SAML_PROVIDER_ID"),
+	}
+	if configuration.CertificatePath == "" || configuration.OAuthConsumerKey == "" {
+		t.Skip("INTUIT_CERTIFICATE and INTUIT_CONSUMER_KEY are not set")
+	}
+
+	prefix := os.Getenv("INTUIT_CUSTOMER_ID")
+	if prefix == "" {
+		prefix = "integration"
+	}
+	configuration.CustomerId = fmt.Sprintf("%s-%s-%d", prefix, t.Name(), time.Now().UnixNano())
+
+	previous := SessionConfiguration
+	Configure(configuration)
+	if err := configuration.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		assert.NoError(t, DeleteCustomer())
+		SessionConfiguration = previous
+	}
+}
+
+func TestIntegrationAuthenticate(t *testing.T) {
+	done := configureIntegration(t)
+	defer done()
+
+	assert.NoError(t, Authenticate(context.Background()))
+	assert.NotNil(t, SessionConfiguration.token(SessionConfiguration.CustomerId))
+}
+
+func TestIntegrationSaml(t *testing.T) {
+	done := configureIntegration(t)
+	defer done()
+
+	token, err := MakeSamlAssertion()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestIntegrationDiscover(t *testing.T) {
+	done := configureIntegration(t)
+	defer done()
+
+	testMode := NewTestMode()
+	accounts, session, err := testMode.Discover(TestScenarioSuccess)
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	assert.NotEmpty(t, accounts)
+
+	customer, err := customerAccounts(context.Background())
+	assert.NoError(t, err)
+	if !assert.NotEmpty(t, customer) {
+		return
+	}
+
+	account := customer[0]
+	assert.Equal(t, TestInstitutionId, account.InstitutionId.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	transactions, errs := TransactionsChan(ctx, account.AccountId.String(), TransactionQuery{Start: time.Now().AddDate(0, -1, 0)})
+	for transaction := range transactions {
+		assert.Equal(t, account.AccountId.String(), transaction.AccountId)
+	}
+	assert.NoError(t, <-errs)
+
+	assert.NoError(t, DeleteAccount(account.AccountId.String()))
+}
+
+func TestIntegrationChallenges(t *testing.T) {
+	scenarios := []TestScenario{TestScenarioTextChallenge, TestScenarioChoiceChallenge, TestScenarioImageChallenge}
+
+	for _, scenario := range scenarios {
+		t.Run(string(scenario), func(t *testing.T) {
+			done := configureIntegration(t)
+			defer done()
+
+			testMode := NewTestMode()
+			_, session, err := testMode.Discover(scenario)
+			assert.NoError(t, err)
+			if !assert.NotNil(t, session) {
+				return
+			}
+			assert.NotEmpty(t, session.Challenges)
+
+			data, err := testMode.Respond(session)
+			assert.NoError(t, err)
+
+			result, err := NewDiscoverResult(data)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, result.Added())
+		})
+	}
+}
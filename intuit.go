@@ -4,9 +4,13 @@ Go client for Intuit's Customer Account Data API
 package intuit
 
 import (
+	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
-	"github.com/MattNewberry/oauth"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 )
 
@@ -56,6 +60,7 @@ type ChallengeResponses struct {
 
 type Challenge struct {
 	Question string
+	Image    []byte
 	Choices  []Choice
 }
 
@@ -76,17 +81,151 @@ type ChallengeSession struct {
 	SessionId     string
 	NodeId        string
 	Challenges    []Challenge
-	Answers       []interface{}
+	Answers       []Answer
 	contextType   challengeContextType
+
+	// The client the session was started with, which answers it.
+	client *Client
 }
 
 type Configuration struct {
 	CustomerId          string
 	OAuthConsumerKey    string
 	OAuthConsumerSecret string
-	oAuthToken          *oauth.AccessToken
+	tokens              map[string]*AccessToken
 	SamlProviderId      string
 	CertificatePath     string
+
+	// Audience the SAML assertion is restricted to. Defaults to SamlProviderId.
+	SamlAudience string
+
+	// Assertion consumer service URL, set as the Recipient of the assertion's subject confirmation. Omitted when empty, as Intuit does not require it.
+	SamlRecipient string
+
+	// PEM-encoded X.509 certificate uploaded to Intuit for the application. When set, each SAML assertion's signature is verified against it before being sent.
+	PublicCertificatePath string
+
+	// Root of the API. Defaults to BaseURL.
+	BaseURL string
+
+	// Client used for all HTTP requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Public key pins by host, such as APIHost and OAuthHost, in the "sha256/..." form returned by SPKIPin. Connections to a pinned host fail with a PinError unless its chain includes one of the pinned keys; list the next key alongside the current one to rotate without downtime. Requires HTTPClient, if set, to use an *http.Transport.
+	Pins   map[string][]string
+	pinned *http.Client
+
+	// Endpoint for exchanging the SAML assertion for an OAuth token. Defaults to SamlTokenURL.
+	TokenURL string
+
+	// Require Authenticate to be called before any API request, instead of authenticating on first use.
+	RequireAuthenticate bool
+
+	// Generates the unique Id of each SAML assertion. Defaults to a random UUID; must be safe for concurrent use.
+	AssertionIdGenerator func() (string, error)
+
+	// Timeout for exchanging the SAML assertion for an OAuth token. Defaults to DefaultTokenExchangeTimeout.
+	TokenExchangeTimeout time.Duration
+
+	// Middleware applied to every API request, in order. See Middleware.
+	Middleware []Middleware
+
+	// Destination for warnings and diagnostics. Nothing is logged when nil.
+	Logger Logger
+
+	// Log the intuit_tid of every response.
+	LogIntuitTid bool
+
+	// Generates the Id sent with each call in the RequestIdHeader header. Defaults to RandomNonce; must be safe for concurrent use.
+	RequestIdGenerator func() (string, error)
+
+	// Log a warning for every response field which is not modeled by the typed result.
+	StrictDecoding bool
+
+	// Sign convention for the amounts of transactions decoded by TransactionsChan, Transactions and the calls built on them, so credit card and banking amounts can be summed together. Defaults to SignAsIs; see SignPolicy.
+	AmountSigns SignPolicy
+
+	// Method used to sign API requests. Defaults to HMAC-SHA1; RSA-SHA1 signs with the key at CertificatePath.
+	SignatureMethod SignatureMethod
+
+	// Source of the OAuth timestamp of each request. Defaults to time.Now.
+	Clock func() time.Time
+
+	// Generates the OAuth nonce of each request. Defaults to RandomNonce; must be safe for concurrent use.
+	NonceGenerator func() (string, error)
+
+	// Sign an oauth_body_hash of each request body, for configurations which validate it.
+	OAuthBodyHash bool
+
+	// Fail transaction queries spanning more than MaxTransactionRangeDays with a RangeError, instead of splitting them into several requests.
+	DisableRangeSplitting bool
+
+	// Expose full account and routing numbers through AccountPaymentDetails and NewPaymentDetails.
+	EnablePaymentDetails bool
+
+	// Stores challenge images larger than MaxImageDataURLSize for Challenge.ImageURL. Images are inlined as data URLs when nil.
+	ImageStore ImageStore
+
+	// Size in bytes above which challenge images are stored with ImageStore. Defaults to DefaultMaxImageDataURLSize.
+	MaxImageDataURLSize int
+
+	// Age after which cached institutions and accounts are fetched again. Cached entries never expire when zero.
+	CacheTTL time.Duration
+
+	// Serve stale cache entries immediately while refreshing them in the background, rather than waiting for the refresh. See Freshness.
+	StaleWhileRevalidate bool
+
+	// Serve Accounts and Account from an in-memory cache per customer, kept for CacheTTL and dropped whenever a refresh, update, discover, challenge response or delete is made with the same configuration. See Client.InvalidateAccounts.
+	ReadThroughAccounts bool
+	reads               *cache
+
+	// Receives every request exactly as sent and every response as received, for debugging and Intuit certification. Credential values, challenge answers, the OAuth Authorization header and challenge session Ids are replaced with Redacted.
+	WireLog io.Writer
+
+	// Zone in which date-only CAD fields are interpreted and query dates are formatted. Dates are interpreted in UTC and query times formatted in their own zone when nil.
+	Location *time.Location
+
+	// Persists OAuth tokens, the institution and account caches and call quotas across restarts, and shares them between instances using the same store. See KV.
+	KV KV
+
+	// Looks up user message templates by language tag and code before UserMessages, for applications with their own translation catalogs. Returns false when it has no message.
+	TranslateUserMessage func(lang string, code string) (string, bool)
+
+	// Receives a notice the first time each deprecated function or setting is used from each call site. Notices are logged to Logger when nil.
+	OnDeprecation func(Deprecation)
+
+	// Deprecations already reported through OnDeprecation or the Logger.
+	reportedDeprecations map[Deprecation]bool
+
+	// Restores earlier behavior changed by fixes, for integrations which depend on it. See Compat.
+	Compat Compat
+
+	// Receives a snapshot of the data about to be removed by DeleteAccount or DeleteCustomer. See Archiver.
+	Archiver Archiver
+
+	// Transactions included in archived snapshots.
+	ArchiveQuery TransactionQuery
+}
+
+func (c *Configuration) httpClient() *http.Client {
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if len(c.Pins) > 0 {
+		return c.pinnedClient(client)
+	}
+
+	return client
+}
+
+func (c *Configuration) baseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+
+	return BaseURL
 }
 
 /*
@@ -94,6 +233,7 @@ Configure the client for access to your application.
 */
 func Configure(configuration *Configuration) {
 	SessionConfiguration = configuration
+	configuration.deprecations()
 }
 
 /*
@@ -111,21 +251,104 @@ func Scope(id string) {
 Discover new accounts for a customer, returning an MFA response if applicable.
 
 In practice, the most efficient workflow is to cache the Institutions list and pass the username and password keys to this method. Without doing so, fetching the instituion's details will be required.
+
+If either key is empty, the keys registered for the institution with RegisterCredentialKeys are used instead.
+*/
+func DiscoverAndAddAccounts(institutionId string, username string, password string, usernameKey string, passwordKey string) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	return defaultClient().DiscoverAndAddAccounts(institutionId, username, password, usernameKey, passwordKey)
+}
+
+/*
+Discover new accounts for a customer, returning an MFA response if applicable. See DiscoverAndAddAccounts.
+*/
+func (c *Client) DiscoverAndAddAccounts(institutionId string, username string, password string, usernameKey string, passwordKey string) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	body, header, challengeSession, err := c.discoverAndAddAccounts(institutionId, username, password, usernameKey, passwordKey)
+
+	if err == nil && challengeSession == nil {
+		accounts, err = c.discoveredAccounts(institutionId, body, header)
+	}
+
+	return
+}
+
+/*
+Discover new accounts for a customer like DiscoverAndAddAccounts, returning the full discover response including the status of accounts which could not be added. A partial failure is not an error; use result.Err to report which accounts could not be linked.
 */
-func DiscoverAndAddAccounts(institutionId string, username string, password string, usernameKey string, passwordKey string) (accounts []interface{}, challengeSession *ChallengeSession, err error) {
+func DiscoverAndAddAccountsDetailed(institutionId string, username string, password string, usernameKey string, passwordKey string) (result *DiscoverResult, challengeSession *ChallengeSession, err error) {
+	return defaultClient().DiscoverAndAddAccountsDetailed(institutionId, username, password, usernameKey, passwordKey)
+}
+
+/*
+Discover new accounts for a customer, returning the full discover response. See DiscoverAndAddAccountsDetailed.
+*/
+func (c *Client) DiscoverAndAddAccountsDetailed(institutionId string, username string, password string, usernameKey string, passwordKey string) (result *DiscoverResult, challengeSession *ChallengeSession, err error) {
+	body, header, challengeSession, err := c.discoverAndAddAccounts(institutionId, username, password, usernameKey, passwordKey)
+
+	if err == nil && challengeSession == nil {
+		result, err = decodeDiscoverResult(c.Configuration(), institutionId, body, header)
+	}
+
+	return
+}
+
+/*
+Discover new accounts for a customer using an arbitrary set of credentials, such as those collected for every field of an institution's login form, returning an MFA response if applicable.
+*/
+func DiscoverAndAddAccountsWithCredentials(institutionId string, credentials []Credential) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	return defaultClient().DiscoverAndAddAccountsWithCredentials(institutionId, credentials)
+}
+
+/*
+Discover new accounts for a customer using an arbitrary set of credentials. See DiscoverAndAddAccountsWithCredentials.
+*/
+func (c *Client) DiscoverAndAddAccountsWithCredentials(institutionId string, credentials []Credential) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	body, header, challengeSession, err := c.discoverAndAddAccountsWithCredentials(institutionId, credentials)
+
+	if err == nil && challengeSession == nil {
+		accounts, err = c.discoveredAccounts(institutionId, body, header)
+	}
+
+	return
+}
+
+/*
+Decode the accounts of a successful discover response.
+*/
+func (c *Client) discoveredAccounts(institutionId string, body []byte, header http.Header) ([]TypedAccount, error) {
+	return c.decodeAccounts(POST, fmt.Sprintf("institutions/%v/logins", institutionId), body, header)
+}
+
+/*
+Decode the accounts of a successful response, each into the struct for its type.
+*/
+func (c *Client) decodeAccounts(method string, endpoint string, body []byte, header http.Header) ([]TypedAccount, error) {
+	r := &rawAccounts{IntuitTid: intuitTid(header)}
+	if err := json.Unmarshal(body, r); err != nil {
+		return nil, &DecodeError{Method: method, Endpoint: endpoint, Body: body, Err: err, IntuitTid: r.IntuitTid}
+	}
+
+	return r.decode(c.Configuration(), endpoint)
+}
+
+func (c *Client) discoverAndAddAccounts(institutionId string, username string, password string, usernameKey string, passwordKey string) (body []byte, header http.Header, challengeSession *ChallengeSession, err error) {
+	usernameKey, passwordKey, err = resolveCredentialKeys(institutionId, usernameKey, passwordKey)
+	if err != nil {
+		return
+	}
+
 	userCredential := Credential{Name: usernameKey, Value: username}
 	passwordCredential := Credential{Name: passwordKey, Value: password}
-	credentials := Credentials{Credentials: []Credential{userCredential, passwordCredential}}
+	return c.discoverAndAddAccountsWithCredentials(institutionId, []Credential{userCredential, passwordCredential})
+}
 
-	payload := &InstitutionLogin{Credentials: credentials, XMLNS: InstitutionXMLNS}
-	data, err := post(fmt.Sprintf("institutions/%v/logins", institutionId), payload, nil, nil)
+func (c *Client) discoverAndAddAccountsWithCredentials(institutionId string, credentials []Credential) (body []byte, header http.Header, challengeSession *ChallengeSession, err error) {
+	payload := &InstitutionLogin{Credentials: Credentials{Credentials: credentials}, XMLNS: InstitutionXMLNS}
+	body, data, header, err := exchangeBody(c.background(), POST, fmt.Sprintf("institutions/%v/logins", institutionId), payload, nil, nil)
 
-	if err == nil {
-		// Success
-		accounts = data.(map[string]interface{})["accounts"].([]interface{})
-	} else if data != nil {
-		challengeSession = parseChallengeSession(discoverAndAddType, data, err)
+	if isChallenge(data) {
+		challengeSession = c.parseChallengeSession(discoverAndAddType, data, header)
 		challengeSession.InstitutionId = institutionId
+		err = challengeError(c.Configuration(), err)
 	}
 
 	return
@@ -134,131 +357,299 @@ func DiscoverAndAddAccounts(institutionId string, username string, password stri
 /*
 Update login information for an account, returning an MFA response if applicable.
 */
-func UpdateLoginAccount(loginId string, username string, password string, usernameKey string, passwordKey string) (accounts []interface{}, challengeSession *ChallengeSession, err error) {
+func UpdateLoginAccount(loginId string, username string, password string, usernameKey string, passwordKey string) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	return defaultClient().UpdateLoginAccount(loginId, username, password, usernameKey, passwordKey)
+}
+
+/*
+Update login information for an account, returning an MFA response if applicable.
+*/
+func (c *Client) UpdateLoginAccount(loginId string, username string, password string, usernameKey string, passwordKey string) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
 	userCredential := Credential{Name: usernameKey, Value: username}
 	passwordCredential := Credential{Name: passwordKey, Value: password}
 	credentials := Credentials{Credentials: []Credential{userCredential, passwordCredential}}
 
 	payload := &InstitutionLogin{Credentials: credentials, XMLNS: InstitutionXMLNS}
-	data, err := put(fmt.Sprintf("logins/%v?refresh=true", loginId), payload, nil, nil)
+	return c.refreshLogin(loginId, payload)
+}
+
+/*
+Refresh a single account, returning an MFA response if applicable.
 
-	if err == nil {
-		// Success
-		accounts = data.(map[string]interface{})["accounts"].([]interface{})
-	} else if data != nil {
-		challengeSession = parseChallengeSession(updateLoginType, data, err)
+The account's login is looked up and refreshed using the stored credentials, which refreshes every account sharing that login.
+*/
+func RefreshAccount(accountId string) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	return defaultClient().RefreshAccount(accountId)
+}
+
+/*
+Refresh a single account and every account sharing its login, returning an MFA response if applicable. See RefreshAccount.
+*/
+func (c *Client) RefreshAccount(accountId string) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	loginId, err := c.LoginForAccount(accountId)
+	if err != nil {
+		return
+	}
+
+	return c.refreshLogin(loginId, nil)
+}
+
+/*
+Refresh a login, with new credentials when payload is set, returning its accounts or an MFA response.
+*/
+func (c *Client) refreshLogin(loginId string, payload interface{}) (accounts []TypedAccount, challengeSession *ChallengeSession, err error) {
+	endpoint := fmt.Sprintf("logins/%v?refresh=true", loginId)
+	b, data, header, err := exchangeBody(c.background(), PUT, endpoint, payload, nil, nil)
+
+	if isChallenge(data) {
+		challengeSession = c.parseChallengeSession(updateLoginType, data, header)
 		challengeSession.LoginId = loginId
+		err = challengeError(c.Configuration(), err)
+	} else if err == nil {
+		accounts, err = c.decodeAccounts(PUT, endpoint, b, header)
 	}
 
 	return
 }
 
 /*
-Return all accounts stored for the scoped customer.
+Return the accounts of a login, each decoded into the struct for its type. A NotFoundError is returned for a login which does not exist or has been deleted.
+*/
+func LoginAccounts(loginId string) ([]TypedAccount, error) {
+	return defaultClient().LoginAccounts(loginId)
+}
+
+/*
+Return the accounts of a login. See LoginAccounts.
 */
-func LoginAccounts(loginId string) ([]interface{}, error) {
-	res, err := get(fmt.Sprintf("logins/%v/accounts", loginId), nil)
+func (c *Client) LoginAccounts(loginId string) ([]TypedAccount, error) {
+	endpoint := fmt.Sprintf("logins/%v/accounts", loginId)
+	r := &rawAccounts{}
+	if err := fetch(c.background(), GET, endpoint, nil, nil, nil, r); err != nil {
+		return nil, notFound("login", loginId, err)
+	}
 
-	data := res.(map[string]interface{})
-	return data["accounts"].([]interface{}), err
+	return r.decode(c.Configuration(), endpoint)
 }
 
 /*
 When prompted with an MFA challenge, reply with an answer to the challenges.
+
+If the institution asks further questions, ErrChallengeRequired is returned; use ChallengeSession.Respond to receive the next session.
+
+Deprecated: use ChallengeSession.Respond, which returns further challenges.
 */
 func RespondToChallenge(session *ChallengeSession) (data interface{}, err error) {
-	responses := make([]ChallengeResponse, len(session.Challenges))
-	for i, r := range session.Answers {
-		responses[i] = ChallengeResponse{Answer: r, XMLNS: ChallengeXMLNS}
+	session.sessionClient().Configuration().deprecated(0, "RespondToChallenge", "ChallengeSession.Respond")
+	return respondToChallenge(session)
+}
+
+func respondToChallenge(session *ChallengeSession) (data interface{}, err error) {
+	data, next, err := session.Respond()
+	if next != nil && err == nil {
+		err = ErrChallengeRequired
+	}
+	return
+}
+
+/*
+Reply to the session's challenges with its answers. Institutions may ask several rounds of questions; when the reply is met with further challenges, they are returned as the next session to answer.
+
+The reply is sent with the client the session was started with. Sessions restored from a ChallengeStore no longer know it; answer them with Client.Respond instead.
+*/
+func (s *ChallengeSession) Respond() (data interface{}, next *ChallengeSession, err error) {
+	return s.sessionClient().Respond(s)
+}
+
+/*
+Return the client the session was started with, or the default client for a restored session.
+*/
+func (s *ChallengeSession) sessionClient() *Client {
+	if s.client == nil {
+		return defaultClient()
+	}
+
+	return s.client
+}
+
+/*
+Reply to a session's challenges with its answers, returning any further challenges as the next session. See ChallengeSession.Respond.
+*/
+func (c *Client) Respond(s *ChallengeSession) (data interface{}, next *ChallengeSession, err error) {
+	if err = s.Validate(); err != nil {
+		return
+	}
+
+	responses := make([]ChallengeResponse, 0, len(s.Challenges))
+	for _, r := range s.Answers {
+		responses = append(responses, r.responses()...)
 	}
 
 	response := ChallengeResponses{ChallengeResponses: responses}
 	payload := &InstitutionLoginMFA{ChallengeResponses: response, XMLNS: InstitutionXMLNS}
 	headers := map[string][]string{
-		"challengeNodeId":    []string{session.NodeId},
-		"challengeSessionId": []string{session.SessionId},
+		"challengeNodeId":    []string{s.NodeId},
+		"challengeSessionId": []string{s.SessionId},
 	}
 
-	switch session.contextType {
+	var header http.Header
+	switch s.contextType {
 	case discoverAndAddType:
-		data, err = post(fmt.Sprintf("institutions/%v/logins", session.InstitutionId), payload, nil, headers)
+		data, header, err = exchange(c.background(), POST, fmt.Sprintf("institutions/%v/logins", s.InstitutionId), payload, nil, headers)
 	case updateLoginType:
-		data, err = put(fmt.Sprintf("logins/%v", session.LoginId), payload, nil, headers)
+		data, header, err = exchange(c.background(), PUT, fmt.Sprintf("logins/%v", s.LoginId), payload, nil, headers)
+	}
+
+	if isChallenge(data) {
+		next = c.parseChallengeSession(s.contextType, data, header)
+		next.InstitutionId = s.InstitutionId
+		next.LoginId = s.LoginId
+		err = challengeError(c.Configuration(), err)
 	}
 
 	return
 }
 
 /*
-Return all accounts stored for the scoped customer.
+Return all accounts stored for the scoped customer, each decoded into the struct for its type. See TypedAccount.
 */
-func Accounts() ([]interface{}, error) {
-	res, err := get("accounts", nil)
-
-	data := res.(map[string]interface{})
-	return data["accounts"].([]interface{}), err
+func Accounts() ([]TypedAccount, error) {
+	return defaultClient().Accounts()
 }
 
 /*
-Return a specific account for the scoped customer, given it's Id.
+Return all accounts stored for the scoped customer. See Accounts.
 */
-func Account(accountId string) (map[string]interface{}, error) {
-	res, err := get(fmt.Sprintf("accounts/%s", accountId), nil)
+func (c *Client) Accounts() ([]TypedAccount, error) {
+	v, err := c.readThrough("accounts", func(ctx context.Context) (interface{}, error) {
+		r := &rawAccounts{}
+		if err := fetch(ctx, GET, "accounts", nil, nil, nil, r); err != nil {
+			return nil, err
+		}
+
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Decoded on every call, so callers never share the cached accounts.
+	return v.(*rawAccounts).decode(c.Configuration(), "accounts")
+}
 
-	data := res.(map[string]interface{})
-	account := data["accounts"].([]interface{})
-	return account[0].(map[string]interface{}), err
+/*
+Return a specific account for the scoped customer, given it's Id, decoded into the struct for its type. A NotFoundError is returned for an account which does not exist or has been deleted.
+*/
+func Account(accountId string) (TypedAccount, error) {
+	return defaultClient().Account(accountId)
 }
 
 /*
-Get all transactions for an account, filtered by the given start and end times.
+Return a specific account for the scoped customer. See Account.
 */
-func Transactions(accountId string, start time.Time, end time.Time) (map[string]interface{}, error) {
+func (c *Client) Account(accountId string) (TypedAccount, error) {
+	endpoint := fmt.Sprintf("accounts/%s", accountId)
+	v, err := c.readThrough(endpoint, func(ctx context.Context) (interface{}, error) {
+		r := &rawAccounts{}
+		if err := fetch(ctx, GET, endpoint, nil, nil, nil, r); err != nil {
+			return nil, notFound("account", accountId, err)
+		}
+		if len(r.Accounts) == 0 {
+			return nil, &NotFoundError{Resource: "account", Id: accountId}
+		}
 
-	params := make(map[string]string)
-	const timeFormat = "2006-01-02"
-	params["txnStartDate"] = start.Format(timeFormat)
-	params["tnxEndDate"] = end.Format(timeFormat)
-	res, err := get(fmt.Sprintf("accounts/%s/transactions", accountId), params)
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := v.(*rawAccounts).decode(c.Configuration(), endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts[0], nil
+}
+
+/*
+Get all transactions for an account, filtered by the given start and end times. A NotFoundError is returned for an account which does not exist or has been deleted. Use TransactionsChan for long histories.
+*/
+func Transactions(accountId string, start time.Time, end time.Time) ([]Transaction, error) {
+	return defaultClient().Transactions(accountId, start, end)
+}
 
-	var data map[string]interface{}
-	if err == nil {
-		data = res.(map[string]interface{})
+/*
+Get all transactions for an account between the given times. See Transactions.
+*/
+func (c *Client) Transactions(accountId string, start time.Time, end time.Time) ([]Transaction, error) {
+	transactions, err := collectTransactions(c.background(), accountId, TransactionQuery{Start: start, End: end})
+	if err != nil {
+		return nil, err
 	}
 
-	return data, err
+	return transactions, nil
 }
 
 /*
-Retrieve all known institutions.
+Retrieve all known institutions. Fetch an institution's InstitutionDetails for the credential fields of its login form.
 
 Given the volume of institutions supported, this call can be very time consuming.
 */
-func Institutions() ([]interface{}, error) {
-	res, err := get("institutions", nil)
+func Institutions() ([]InstitutionSummary, error) {
+	return defaultClient().Institutions()
+}
 
-	data := res.(map[string]interface{})
-	all := data["institution"].([]interface{})
-	return all, err
+/*
+Retrieve all known institutions. See Institutions.
+*/
+func (c *Client) Institutions() ([]InstitutionSummary, error) {
+	list, err := Get[institutionList](c.background(), "institutions", nil)
+	if err != nil {
+		return nil, err
+	}
+	if list.Institution == nil {
+		return make([]InstitutionSummary, 0), nil
+	}
+
+	return list.Institution, nil
 }
 
 /*
-Retrieve an institution's detailed information.
+Retrieve an institution's details, including its credential fields. A NotFoundError is returned for an unknown institution. Unlike CachedInstitution, the details are always fetched.
 */
-func Institution(institutionId string) (data map[string]interface{}, err error) {
-	res, err := get(fmt.Sprintf("institutions/%s", institutionId), nil)
+func Institution(institutionId string) (*InstitutionDetails, error) {
+	return defaultClient().Institution(institutionId)
+}
 
-	if res != nil {
-		data = res.(map[string]interface{})
+/*
+Retrieve an institution's details. See Institution.
+*/
+func (c *Client) Institution(institutionId string) (*InstitutionDetails, error) {
+	institution, err := fetchInstitution(c.background(), institutionId)
+	if err != nil {
+		return nil, notFound("institution", institutionId, err)
 	}
-	return
+
+	return institution, nil
 }
 
 /*
 Delete the scoped customer and all related accounts.
 */
 func DeleteCustomer() error {
-	_, err := request(DELETE, "customers", "", nil, nil)
+	return defaultClient().DeleteCustomer()
+}
+
+/*
+Delete the scoped customer and all related accounts.
+*/
+func (c *Client) DeleteCustomer() error {
+	if err := c.archiveCustomer(); err != nil {
+		return err
+	}
+
+	_, err := request(c.background(), DELETE, "customers", nil, nil, nil)
 	return err
 }
 
@@ -266,22 +657,96 @@ func DeleteCustomer() error {
 Delete an account for the scoped customer.
 */
 func DeleteAccount(accountId string) error {
-	_, err := request(DELETE, "accounts/"+accountId, "", nil, nil)
+	return defaultClient().DeleteAccount(accountId)
+}
+
+/*
+Delete an account for the scoped customer.
+*/
+func (c *Client) DeleteAccount(accountId string) error {
+	if err := c.archiveAccount(accountId); err != nil {
+		return err
+	}
+
+	_, err := request(c.background(), DELETE, "accounts/"+accountId, nil, nil, nil)
 	return err
 }
 
-func parseChallengeSession(contextType challengeContextType, data interface{}, err error) *ChallengeSession {
-	challengeData := data.(map[string]interface{})
-	httpError := err.(oauth.HTTPExecuteError)
-	headers := httpError.ResponseHeaders
+/*
+Delete several accounts for the scoped customer, one at a time. Every account is attempted, and any failures are reported together in a BatchError.
+*/
+func DeleteAccounts(accountIds []string) error {
+	return defaultClient().DeleteAccounts(accountIds)
+}
+
+/*
+Delete several accounts for the scoped customer, one at a time. See DeleteAccounts.
+*/
+func (c *Client) DeleteAccounts(accountIds []string) error {
+	batch := NewBatchError("delete accounts", len(accountIds))
+	for i, id := range accountIds {
+		if err := c.DeleteAccount(id); err != nil {
+			batch.Add(i, id, err)
+		}
+	}
+
+	return batch.Err()
+}
+
+/*
+Value of the type indicator in a response body carrying MFA challenges.
+*/
+const ChallengeEnvelopeType = "challenge"
+
+/*
+ChallengeEnvelope is a response body carrying MFA challenges. Challenges are recognized from the body itself, whether by its type indicator or its challenge list, so they are detected however the response reached the client: typically a 401, but also a successful response.
+*/
+type ChallengeEnvelope struct {
+	// The type indicator, ChallengeEnvelopeType when present.
+	Type string
+
+	// The challenge elements, each a map of a prompt kind to the prompt followed by its choices.
+	Challenge []interface{}
+}
+
+/*
+Return the challenge envelope of a decoded response body, or nil if it carries no challenges. The challenge list is accepted directly under "challenge" or wrapped in a "challenges" element.
+*/
+func NewChallengeEnvelope(data interface{}) *ChallengeEnvelope {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	envelope := &ChallengeEnvelope{}
+	envelope.Type, _ = m["type"].(string)
+
+	list, found := m["challenge"].([]interface{})
+	if wrapped, ok := m["challenges"].(map[string]interface{}); ok && !found {
+		list, found = wrapped["challenge"].([]interface{})
+	}
+	if !found && !strings.EqualFold(envelope.Type, ChallengeEnvelopeType) {
+		return nil
+	}
+
+	envelope.Challenge = list
+	return envelope
+}
+
+/*
+Report whether a response carries MFA challenges.
+*/
+func isChallenge(data interface{}) bool {
+	return NewChallengeEnvelope(data) != nil
+}
 
+func parseChallengeSession(contextType challengeContextType, data interface{}, headers http.Header) *ChallengeSession {
 	var challengeSession = &ChallengeSession{contextType: contextType}
-	challengeSession.SessionId = headers.Get("Challengesessionid")
-	challengeSession.NodeId = headers.Get("Challengenodeid")
+	challengeSession.SessionId = headerValue(headers, "challengeSessionId")
+	challengeSession.NodeId = headerValue(headers, "challengeNodeId")
 	challengeSession.Challenges = make([]Challenge, 0)
-	challenges := challengeData["challenge"].([]interface{})
 
-	for _, c := range challenges {
+	for _, c := range NewChallengeEnvelope(data).Challenge {
 		chal := c.(map[string]interface{})
 
 		for _, v := range chal {
@@ -290,7 +755,7 @@ func parseChallengeSession(contextType challengeContextType, data interface{}, e
 
 			for i, val := range vData {
 				if i == 0 {
-					challenge.Question = val.(string)
+					challenge.Question, challenge.Image = parseChallengePrompt(val)
 					challenge.Choices = make([]Choice, 0)
 				} else {
 					cData := val.(map[string]interface{})
@@ -305,3 +770,34 @@ func parseChallengeSession(contextType challengeContextType, data interface{}, e
 
 	return challengeSession
 }
+
+/*
+Parse a challenge session to be answered with the client.
+*/
+func (c *Client) parseChallengeSession(contextType challengeContextType, data interface{}, headers http.Header) *ChallengeSession {
+	s := parseChallengeSession(contextType, data, headers)
+	s.client = c
+	return s
+}
+
+/*
+Look up a header regardless of how its name was cased or punctuated, since the challenge headers have been seen as "challengeSessionId", "Challengesessionid" and "Challenge-Session-Id".
+*/
+func headerValue(headers http.Header, name string) string {
+	if v := headers.Get(name); v != "" {
+		return v
+	}
+
+	normalized := normalizeHeaderName(name)
+	for k, v := range headers {
+		if len(v) > 0 && normalizeHeaderName(k) == normalized {
+			return v[0]
+		}
+	}
+
+	return ""
+}
+
+func normalizeHeaderName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+}
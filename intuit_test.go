@@ -0,0 +1,243 @@
+package intuit
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func challengeData(t *testing.T) interface{} {
+	var data interface{}
+	d := json.NewDecoder(strings.NewReader(`{"challenge": [
+		{"textOrImageAndChoice": ["What is your favorite color?"]},
+		{"textOrImageAndChoice": ["Pick a city", {"val": "1", "text": "Paris"}, {"val": "2", "text": "Rome"}]}
+	]}`))
+	d.UseNumber()
+	assert.NoError(t, d.Decode(&data))
+	return data
+}
+
+func TestParseChallengeSessionHeaderCasing(t *testing.T) {
+	casings := [][2]string{
+		{"Challengesessionid", "Challengenodeid"},
+		{"challengeSessionId", "challengeNodeId"},
+		{"CHALLENGESESSIONID", "CHALLENGENODEID"},
+		{"Challenge-Session-Id", "Challenge-Node-Id"},
+	}
+
+	for _, c := range casings {
+		// Build the map directly so the keys keep their casing
+		headers := http.Header{c[0]: {"session"}, c[1]: {"node"}}
+		err := &APIError{StatusCode: http.StatusUnauthorized, Header: headers}
+
+		session := parseChallengeSession(discoverAndAddType, challengeData(t), err.Header)
+		assert.Equal(t, "session", session.SessionId, c[0])
+		assert.Equal(t, "node", session.NodeId, c[1])
+	}
+}
+
+func TestParseChallengeSessionChallenges(t *testing.T) {
+	err := &APIError{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	session := parseChallengeSession(discoverAndAddType, challengeData(t), err.Header)
+
+	assert.Equal(t, 2, len(session.Challenges))
+	assert.Equal(t, "What is your favorite color?", session.Challenges[0].Question)
+	assert.Equal(t, 0, len(session.Challenges[0].Choices))
+	assert.Equal(t, "Rome", session.Challenges[1].Choices[1].Text)
+}
+
+func TestRespondFollowUpChallenge(t *testing.T) {
+	rounds := 0
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		rounds++
+		assert.Equal(t, "/institutions/100000/logins", r.URL.Path)
+		assert.Equal(t, "session", r.Header.Get("challengeSessionId"))
+
+		if rounds == 1 {
+			w.Header().Set("challengeSessionId", "session")
+			w.Header().Set("challengeNodeId", "node-2")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"challenge": [{"textOrImageAndChoice": ["Mother's maiden name?"]}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"accounts": []}`))
+	})
+	defer done()
+
+	session := &ChallengeSession{InstitutionId: "100000", SessionId: "session", NodeId: "node-1", contextType: discoverAndAddType}
+	session.Challenges = []Challenge{{Question: "Favorite color?"}}
+	session.Answers = []Answer{TextAnswer("blue")}
+
+	_, next, err := session.Respond()
+	assert.NoError(t, err)
+	assert.Equal(t, "100000", next.InstitutionId)
+	assert.Equal(t, "node-2", next.NodeId)
+	assert.Equal(t, "Mother's maiden name?", next.Challenges[0].Question)
+
+	next.Answers = []Answer{TextAnswer("smith")}
+	data, next, err := next.Respond()
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.NotNil(t, data)
+}
+
+func TestCompat(t *testing.T) {
+	var endDate, legacyEndDate string
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts/1/transactions" {
+			endDate, legacyEndDate = r.URL.Query().Get("txnEndDate"), r.URL.Query().Get("tnxEndDate")
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		w.Header().Set("challengeSessionId", "session")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"challenge": [{"textOrImageAndChoice": ["Favorite color?"]}]}`))
+	})
+	defer done()
+
+	start, end := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2014, 1, 31, 0, 0, 0, 0, time.UTC)
+	_, err := Transactions("1", start, end)
+	assert.NoError(t, err)
+	assert.Equal(t, "2014-01-31", endDate)
+	assert.Equal(t, "", legacyEndDate)
+
+	credentials := []Credential{{Name: "user", Value: "u"}}
+	_, session, err := DiscoverAndAddAccountsWithCredentials("1", credentials)
+	assert.NotNil(t, session)
+	assert.NoError(t, err)
+
+	SessionConfiguration.Compat = Compat{LegacyEndDateParam: true, LegacyMFAErrors: true}
+
+	_, err = Transactions("1", start, end)
+	assert.NoError(t, err)
+	assert.Equal(t, "", endDate)
+	assert.Equal(t, "2014-01-31", legacyEndDate)
+
+	_, session, err = DiscoverAndAddAccountsWithCredentials("1", credentials)
+	assert.NotNil(t, session)
+	assert.Equal(t, http.StatusUnauthorized, StatusCode(err))
+}
+
+func TestChallengeEnvelope(t *testing.T) {
+	assert.Nil(t, NewChallengeEnvelope(map[string]interface{}{"accounts": []interface{}{}}))
+	assert.Nil(t, NewChallengeEnvelope([]interface{}{}))
+
+	question := map[string]interface{}{"textOrImageAndChoice": []interface{}{"Favorite color?"}}
+	for _, data := range []map[string]interface{}{
+		{"challenge": []interface{}{question}},
+		{"type": "challenge", "challenges": map[string]interface{}{"challenge": []interface{}{question}}},
+		{"type": "CHALLENGE", "challenge": []interface{}{question}},
+	} {
+		envelope := NewChallengeEnvelope(data)
+		if assert.NotNil(t, envelope, "%v", data) {
+			assert.Equal(t, 1, len(envelope.Challenge))
+		}
+	}
+
+	// The type indicator alone marks a challenge.
+	envelope := NewChallengeEnvelope(map[string]interface{}{"type": "challenge"})
+	assert.NotNil(t, envelope)
+	assert.Equal(t, 0, len(envelope.Challenge))
+}
+
+func TestChallengeWithoutErrorStatus(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("challengeSessionId", "session")
+		w.Header().Set("challengeNodeId", "node")
+		w.Write([]byte(`{"type": "challenge", "challenges": {"challenge": [{"textOrImageAndChoice": ["Favorite color?"]}]}}`))
+	})
+	defer done()
+
+	accounts, session, err := DiscoverAndAddAccountsWithCredentials("100000", []Credential{{Name: "user", Value: "direct"}})
+	assert.NoError(t, err)
+	assert.Nil(t, accounts)
+	assert.Equal(t, "session", session.SessionId)
+	assert.Equal(t, "Favorite color?", session.Challenges[0].Question)
+
+	updated, session, err := UpdateLoginAccount("9", "user", "pass", "u", "p")
+	assert.NoError(t, err)
+	assert.Nil(t, updated)
+	assert.Equal(t, "9", session.LoginId)
+}
+
+func TestNotFound(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/2":
+			// A deleted account may also be answered with an empty list.
+			w.Write([]byte(`{"accounts": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorInfo": [{"errorCode": "0", "errorMessage": "not found"}]}`))
+		}
+	})
+	defer done()
+
+	_, err := Account("1")
+	assert.EqualError(t, err, "intuit: account 1 not found")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, http.StatusNotFound, StatusCode(err))
+
+	var notFound *NotFoundError
+	_, err = Account("2")
+	if assert.True(t, errors.As(err, &notFound)) {
+		assert.Equal(t, "account", notFound.Resource)
+		assert.Equal(t, "2", notFound.Id)
+		assert.Nil(t, notFound.Err)
+	}
+
+	_, err = LoginAccounts("10")
+	assert.EqualError(t, err, "intuit: login 10 not found")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	_, err = Transactions("1", time.Now().AddDate(0, 0, -7), time.Now())
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	_, err = Accounts()
+	assert.False(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, http.StatusNotFound, StatusCode(err))
+}
+
+func TestLoginAccountsTyped(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/logins/9", "/logins/9/accounts":
+			w.Write([]byte(`{"accounts": [{"accountId": 1, "institutionLoginId": 9, "bankingAccountType": "CHECKING"}, {"accountId": 2, "institutionLoginId": 9, "creditAccountType": "CREDITCARD"}]}`))
+		case "/accounts/1":
+			w.Write([]byte(`{"accounts": [{"accountId": 1, "institutionLoginId": 9}]}`))
+		default:
+			// Malformed accounts are reported rather than panicking.
+			w.Write([]byte(`{"accounts": {"accountId": 3}}`))
+		}
+	})
+	defer done()
+
+	accounts, session, err := UpdateLoginAccount("9", "user", "pass", "u", "p")
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	if assert.Len(t, accounts, 2) {
+		assert.Equal(t, BankingType, accounts[0].Type())
+		assert.Equal(t, CreditType, accounts[1].Type())
+	}
+
+	accounts, _, err = RefreshAccount("1")
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 2)
+
+	accounts, err = LoginAccounts("9")
+	assert.NoError(t, err)
+	if assert.Len(t, accounts, 2) {
+		assert.Equal(t, "2", accounts[1].Common().AccountId.String())
+	}
+
+	_, _, err = UpdateLoginAccount("10", "user", "pass", "u", "p")
+	assert.IsType(t, &DecodeError{}, err)
+	_, err = LoginAccounts("10")
+	assert.IsType(t, &DecodeError{}, err)
+}
@@ -0,0 +1,69 @@
+package intuittest
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+/*
+Bundle is a set of recorded CAD responses served by a fake Server. Responses are kept in the raw form Intuit returns them, so a bundle captured from a live customer replays exactly.
+*/
+type Bundle struct {
+	CustomerId string `json:"customerId"`
+
+	// Accounts of the customer, each as returned within an accounts response.
+	Accounts []map[string]interface{} `json:"accounts"`
+
+	// Transaction list responses, keyed by account Id.
+	Transactions map[string]map[string]interface{} `json:"transactions"`
+
+	// Institution detail responses, keyed by institution Id.
+	Institutions map[string]map[string]interface{} `json:"institutions"`
+
+	// Position list responses of investment accounts, keyed by account Id.
+	Positions map[string]map[string]interface{} `json:"positions,omitempty"`
+}
+
+func NewBundle() *Bundle {
+	return &Bundle{
+		Accounts:     make([]map[string]interface{}, 0),
+		Transactions: make(map[string]map[string]interface{}),
+		Institutions: make(map[string]map[string]interface{}),
+		Positions:    make(map[string]map[string]interface{}),
+	}
+}
+
+/*
+Read a bundle written by WriteBundle.
+*/
+func LoadBundle(r io.Reader) (*Bundle, error) {
+	b := NewBundle()
+	d := json.NewDecoder(r)
+	d.UseNumber()
+
+	if err := d.Decode(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+/*
+Write a bundle as indented JSON.
+*/
+func WriteBundle(w io.Writer, b *Bundle) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(b)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,257 @@
+package intuittest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+Record the scoped customer's accounts, their transactions within q, the positions of investment accounts and their institutions into a bundle. The bundle holds real data; pass it through Sanitize before sharing it.
+*/
+func Capture(q intuit.TransactionQuery) (*Bundle, error) {
+	b := NewBundle()
+	b.CustomerId = intuit.SessionConfiguration.CustomerId
+
+	res, err := intuit.Do(intuit.GET, "accounts", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, _ := res.(map[string]interface{})["accounts"].([]interface{})
+	for _, a := range accounts {
+		account, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		b.Accounts = append(b.Accounts, account)
+
+		id := fmt.Sprint(account["accountId"])
+		params := make(map[string]string)
+		if !q.Start.IsZero() {
+			params["txnStartDate"] = intuit.FormatQueryDate(q.Start)
+		}
+		if !q.End.IsZero() {
+			params["txnEndDate"] = intuit.FormatQueryDate(q.End)
+		}
+
+		res, err := intuit.Do(intuit.GET, "accounts/"+id+"/transactions", nil, params, nil)
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := res.(map[string]interface{}); ok {
+			b.Transactions[id] = t
+		}
+
+		if _, ok := account["investmentAccountType"]; ok {
+			res, err := intuit.Do(intuit.GET, "accounts/"+id+"/positions", nil, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			if p, ok := res.(map[string]interface{}); ok {
+				b.Positions[id] = p
+			}
+		}
+
+		institutionId := fmt.Sprint(account["institutionId"])
+		if _, ok := b.Institutions[institutionId]; !ok {
+			res, err := intuit.Do(intuit.GET, "institutions/"+institutionId, nil, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			if i, ok := res.(map[string]interface{}); ok {
+				b.Institutions[institutionId] = i
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// Default fraction by which Sanitize varies amounts.
+const DefaultJitter = 0.1
+
+var (
+	demoPayees = []string{"Corner Coffee", "Grocery Mart", "City Utilities", "Payroll Deposit", "Gas & Go", "Book Nook", "Transit Authority", "Streaming Service", "Hardware Depot", "Pharmacy Plus"}
+	demoNames  = []string{"Alex Morgan", "Sam Taylor", "Jordan Lee", "Casey Reed", "Riley Quinn", "Avery Brooks"}
+)
+
+/*
+Sanitizer anonymizes a captured bundle for use in demos. Account, login and transaction Ids are replaced consistently throughout, account numbers and other identifiers are randomized keeping their shape, payees and holder names are replaced with stock values, memos are dropped and amounts are varied by up to Jitter. Institution data is public and left as is.
+*/
+type Sanitizer struct {
+	Rand   *rand.Rand
+	Jitter float64
+
+	ids map[string]string
+
+	// The first Id which could not be replaced.
+	err error
+}
+
+// Attempts at drawing an unused replacement for an Id before giving up.
+const idAttempts = 1000
+
+/*
+Sanitize a bundle with a sanitizer seeded by seed and the default jitter. The same seed always produces the same output for the same bundle.
+*/
+func Sanitize(b *Bundle, seed int64) (*Bundle, error) {
+	s := &Sanitizer{Rand: rand.New(rand.NewSource(seed)), Jitter: DefaultJitter}
+	return s.Sanitize(b)
+}
+
+/*
+Return a sanitized copy of the bundle. An error is returned when an Id cannot be given a replacement of the same shape distinct from every other, such as a one-digit Id among ten.
+*/
+func (s *Sanitizer) Sanitize(b *Bundle) (*Bundle, error) {
+	s.ids = make(map[string]string)
+	s.err = nil
+
+	out := NewBundle()
+	out.CustomerId = "demo"
+
+	for _, a := range b.Accounts {
+		out.Accounts = append(out.Accounts, s.value("", a).(map[string]interface{}))
+	}
+
+	// Iterate accounts rather than the map so the random stream is consumed in a stable order.
+	for _, a := range b.Accounts {
+		id := fmt.Sprint(a["accountId"])
+		if t, ok := b.Transactions[id]; ok {
+			out.Transactions[s.id(id)] = s.value("", t).(map[string]interface{})
+		}
+		if p, ok := b.Positions[id]; ok {
+			out.Positions[s.id(id)] = s.value("", p).(map[string]interface{})
+		}
+	}
+
+	for id, i := range b.Institutions {
+		out.Institutions[id] = i
+	}
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return out, nil
+}
+
+func (s *Sanitizer) value(key string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for _, k := range sortedKeys(v) {
+			if sanitized := s.field(k, v[k]); sanitized != nil {
+				m[k] = sanitized
+			}
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, item := range v {
+			l[i] = s.value(key, item)
+		}
+		return l
+	}
+
+	return v
+}
+
+func (s *Sanitizer) field(key string, v interface{}) interface{} {
+	lower := strings.ToLower(key)
+
+	switch {
+	case lower == "accountid" || lower == "institutionloginid" || lower == "id":
+		if n, ok := v.(json.Number); ok {
+			return json.Number(s.id(n.String()))
+		}
+		return s.id(fmt.Sprint(v))
+	case lower == "accountnumber" || lower == "institutiontransactionid" || lower == "checknumber":
+		return scramble(s.Rand, fmt.Sprint(v))
+	case lower == "payeename" || lower == "accountnickname" || lower == "description":
+		return demoPayees[s.Rand.Intn(len(demoPayees))]
+	case lower == "name" || lower == "holdername":
+		return demoNames[s.Rand.Intn(len(demoNames))]
+	case lower == "memo":
+		return nil
+	case strings.Contains(lower, "amount") || strings.Contains(lower, "balance"):
+		if f, err := strconv.ParseFloat(fmt.Sprint(v), 64); err == nil {
+			return s.jitter(f)
+		}
+	}
+
+	return s.value(key, v)
+}
+
+func (s *Sanitizer) id(id string) string {
+	if mapped, ok := s.ids[id]; ok {
+		return mapped
+	}
+
+	// Ids without digits or letters, including empty ones, identify nothing and have no other shape.
+	if strings.IndexFunc(id, scrambled) < 0 {
+		return id
+	}
+
+	for i := 0; i < idAttempts; i++ {
+		mapped := scramble(s.Rand, id)
+		if id[0] != '0' && mapped[0] == '0' {
+			continue
+		}
+		if _, taken := s.reverse(mapped); !taken && mapped != id {
+			s.ids[id] = mapped
+			return mapped
+		}
+	}
+
+	if s.err == nil {
+		s.err = fmt.Errorf("intuittest: no unused replacement for Id %q", id)
+	}
+	return id
+}
+
+func (s *Sanitizer) reverse(mapped string) (string, bool) {
+	for k, v := range s.ids {
+		if v == mapped {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *Sanitizer) jitter(f float64) float64 {
+	f *= 1 + s.Jitter*(2*s.Rand.Float64()-1)
+	return math.Round(f*100) / 100
+}
+
+/*
+Report whether scramble replaces a character.
+*/
+func scrambled(c rune) bool {
+	return unicode.IsDigit(c) || unicode.IsUpper(c) || unicode.IsLower(c)
+}
+
+/*
+Replace each digit and letter with a random one of the same kind, keeping punctuation, so identifiers keep their format.
+*/
+func scramble(r *rand.Rand, s string) string {
+	out := []rune(s)
+	for i, c := range out {
+		switch {
+		case unicode.IsDigit(c):
+			out[i] = rune('0' + r.Intn(10))
+		case unicode.IsUpper(c):
+			out[i] = rune('A' + r.Intn(26))
+		case unicode.IsLower(c):
+			out[i] = rune('a' + r.Intn(26))
+		}
+	}
+
+	return string(out)
+}
@@ -0,0 +1,21 @@
+package intuittest
+
+import (
+	"bytes"
+	_ "embed"
+)
+
+//go:embed demo.json
+var demo []byte
+
+/*
+Return a fresh copy of the demo bundle: a customer with checking, savings, credit card, mortgage and IRA accounts at two institutions, and a few weeks of categorized transactions. Signing in to the DAG Site with the usernames of intuit.TestScenario triggers its MFA challenges.
+*/
+func DemoBundle() *Bundle {
+	b, err := LoadBundle(bytes.NewReader(demo))
+	if err != nil {
+		panic("intuittest: invalid demo bundle: " + err.Error())
+	}
+
+	return b
+}
@@ -0,0 +1,212 @@
+package intuittest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const fixture = `{
+	"customerId": "real-customer",
+	"accounts": [
+		{"accountId": 4001, "institutionId": 100000, "institutionLoginId": 77, "accountNumber": "1234-5678", "accountNickname": "Jane's Checking", "balanceAmount": 1500.25, "bankingAccountType": "CHECKING", "holders": [{"name": "Jane Doe", "role": "PRIMARY"}]},
+		{"accountId": 4002, "institutionId": 100000, "institutionLoginId": 77, "accountNumber": "9999", "balanceAmount": -320.1, "creditAccountType": "CREDITCARD"}
+	],
+	"transactions": {
+		"4001": {"bankingTransactions": [{"id": 9001, "amount": -12.5, "payeeName": "Jane's Landlord", "memo": "rent for 12 Elm St", "institutionTransactionId": "TX-0001"}]}
+	},
+	"institutions": {
+		"100000": {"institutionId": 100000, "institutionName": "DAG Site", "homeUrl": "http://www.intuit.com"}
+	}
+}`
+
+func configure(t *testing.T, b *Bundle) *Server {
+	server := NewServer(b)
+	configuration, err := server.Configuration()
+	assert.NoError(t, err)
+	intuit.Configure(configuration)
+
+	return server
+}
+
+func load(t *testing.T) *Bundle {
+	b, err := LoadBundle(strings.NewReader(fixture))
+	assert.NoError(t, err)
+	return b
+}
+
+func TestServer(t *testing.T) {
+	server := configure(t, load(t))
+	defer server.Close()
+
+	logins, err := intuit.AccountsByLogin()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(logins["77"]))
+
+	transactions, errs := intuit.TransactionsChan(context.Background(), "4001", intuit.TransactionQuery{})
+	txn := <-transactions
+	assert.NoError(t, <-errs)
+	assert.Equal(t, intuit.MustParseDecimal("-12.5"), txn.Amount)
+
+	details, err := intuit.Institution("100000")
+	assert.NoError(t, err)
+	assert.Equal(t, "DAG Site", details.InstitutionName)
+
+	assert.NoError(t, intuit.DeleteAccount("4002"))
+	_, err = intuit.Do(intuit.GET, "accounts/4002", nil, nil, nil)
+	assert.Equal(t, 404, intuit.StatusCode(err))
+}
+
+func TestDemo(t *testing.T) {
+	server := configure(t, DemoBundle())
+	defer server.Close()
+	assert.NoError(t, intuit.SessionConfiguration.Validate())
+
+	accounts, err := intuit.OpenAccounts()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(accounts))
+
+	positions, err := intuit.Positions("400100000005")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(positions))
+
+	mode := intuit.NewTestMode()
+	added, session, err := mode.Discover(intuit.TestScenarioSuccess)
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	assert.Equal(t, 3, len(added))
+
+	_, session, err = mode.Discover(intuit.TestScenarioChoiceChallenge)
+	assert.NoError(t, err)
+	if assert.NotNil(t, session) {
+		assert.Equal(t, intuit.ChoiceChallenge, session.Challenges[0].Kind())
+		data, err := mode.Respond(session)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(data.(map[string]interface{})["accounts"].([]interface{})))
+	}
+}
+
+func testMode(t *testing.T, mode *intuit.TestMode) {
+	added, session, err := mode.Discover(intuit.TestScenarioSuccess)
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	assert.Equal(t, 2, len(added))
+
+	kinds := map[intuit.TestScenario]intuit.ChallengeKind{
+		intuit.TestScenarioTextChallenge:   intuit.TextChallenge,
+		intuit.TestScenarioChoiceChallenge: intuit.ChoiceChallenge,
+		intuit.TestScenarioImageChallenge:  intuit.ImageChallenge,
+	}
+	for scenario, kind := range kinds {
+		added, session, err := mode.Discover(scenario)
+		assert.NoError(t, err)
+		assert.Nil(t, added)
+		if !assert.NotNil(t, session, string(scenario)) {
+			continue
+		}
+		assert.Equal(t, kind, session.Challenges[0].Kind(), string(scenario))
+
+		data, err := mode.Respond(session)
+		assert.NoError(t, err)
+		result, err := intuit.NewDiscoverResult(data)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(result.Accounts), string(scenario))
+	}
+}
+
+func TestTestMode(t *testing.T) {
+	server := configure(t, load(t))
+	defer server.Close()
+
+	testMode(t, intuit.NewTestMode())
+}
+
+func TestTestModeClient(t *testing.T) {
+	server := NewServer(load(t))
+	defer server.Close()
+
+	configuration, err := server.Configuration()
+	assert.NoError(t, err)
+
+	// The package-level configuration is left pointing elsewhere.
+	mode := intuit.NewTestMode()
+	mode.Client = intuit.NewClient(*configuration)
+	testMode(t, mode)
+}
+
+func TestCaptureAndSanitize(t *testing.T) {
+	server := configure(t, load(t))
+	captured, err := Capture(intuit.TransactionQuery{})
+	server.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(captured.Accounts))
+
+	sanitized, err := Sanitize(captured, 1)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteBundle(&buf, sanitized))
+	out := buf.String()
+	for _, secret := range []string{"real-customer", "4001", "1234-5678", "Jane", "Elm St", "TX-0001", "1500.25"} {
+		assert.NotContains(t, out, secret)
+	}
+
+	account := sanitized.Accounts[0]
+	assert.True(t, regexp.MustCompile(`^\d{4}-\d{4}$`).MatchString(account["accountNumber"].(string)))
+	balance := account["balanceAmount"].(float64)
+	assert.InDelta(t, 1500.25, balance, 1500.25*DefaultJitter)
+
+	id := account["accountId"].(json.Number).String()
+	_, ok := sanitized.Transactions[id]
+	assert.True(t, ok)
+	assert.Equal(t, sanitized.Accounts[1]["institutionLoginId"], account["institutionLoginId"])
+	assert.Equal(t, captured.Institutions, sanitized.Institutions)
+
+	again, err := Sanitize(captured, 1)
+	assert.NoError(t, err)
+	var buf2 bytes.Buffer
+	WriteBundle(&buf2, again)
+	assert.Equal(t, out, buf2.String())
+
+	// The sanitized bundle replays through the fake server.
+	loaded, err := LoadBundle(&buf)
+	assert.NoError(t, err)
+	server = configure(t, loaded)
+	defer server.Close()
+
+	transactions, errs := intuit.TransactionsChan(context.Background(), id, intuit.TransactionQuery{})
+	txn := <-transactions
+	assert.NoError(t, <-errs)
+	assert.Empty(t, txn.Memo)
+	assert.InDelta(t, -12.5, float64(txn.Amount.Amount()), 12.5*DefaultJitter)
+}
+
+func TestSanitizeIds(t *testing.T) {
+	s := &Sanitizer{Rand: rand.New(rand.NewSource(1)), ids: make(map[string]string)}
+
+	// Ids with nothing to replace are kept.
+	assert.Equal(t, "", s.id(""))
+	assert.Equal(t, "--", s.id("--"))
+
+	// One-digit Ids have nine replacements, so the tenth cannot be given one.
+	for d := 1; d <= 9; d++ {
+		assert.NotEqual(t, fmt.Sprint(d), s.id(fmt.Sprint(d)))
+	}
+	assert.NoError(t, s.err)
+	s.id("0")
+	assert.Error(t, s.err)
+
+	b := NewBundle()
+	for d := 0; d <= 9; d++ {
+		b.Accounts = append(b.Accounts, map[string]interface{}{"accountId": json.Number(fmt.Sprint(d))})
+	}
+	_, err := Sanitize(b, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,286 @@
+/*
+A fake Customer Account Data server for tests and demos, serving recorded responses from a Bundle.
+
+	server := intuittest.NewServer(bundle)
+	defer server.Close()
+
+	configuration, err := server.Configuration()
+	if err != nil {
+		return err
+	}
+	intuit.Configure(configuration)
+*/
+package intuittest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+)
+
+const tokenPath = "/oauth/v1/get_access_token_by_saml"
+
+/*
+Server is a running fake CAD server. Deletions are applied to its copy of the bundle, so a session behaves consistently.
+*/
+type Server struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	bundle   *Bundle
+	keyFiles []string
+}
+
+func NewServer(b *Bundle) *Server {
+	s := &Server{bundle: b}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+/*
+Return a configuration pointing at the server, including a freshly generated signing key. The key file is removed by Close.
+*/
+func (s *Server) Configuration() (*intuit.Configuration, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := ioutil.TempFile("", "intuittest-key")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := pem.Encode(f, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}); err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.keyFiles = append(s.keyFiles, f.Name())
+	s.mutex.Unlock()
+
+	return &intuit.Configuration{
+		CustomerId:          s.bundle.CustomerId,
+		OAuthConsumerKey:    "intuittest",
+		OAuthConsumerSecret: "intuittest",
+		SamlProviderId:      "app.1.cc.intuittest.ipp.local",
+		CertificatePath:     f.Name(),
+		BaseURL:             s.URL + "/v1/",
+		TokenURL:            s.URL + tokenPath,
+	}, nil
+}
+
+func (s *Server) Close() {
+	s.Server.Close()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, f := range s.keyFiles {
+		os.Remove(f)
+	}
+	s.keyFiles = nil
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == tokenPath {
+		w.Write([]byte("oauth_token=intuittest&oauth_token_secret=intuittest"))
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1"), "/"), "/")
+	route := r.Method + " " + parts[0]
+	if len(parts) > 1 {
+		route += "/*"
+	}
+	if len(parts) > 2 {
+		route += "/" + strings.Join(parts[2:], "/")
+	}
+
+	switch route {
+	case "GET accounts":
+		respond(w, map[string]interface{}{"accounts": s.bundle.Accounts})
+	case "GET accounts/*":
+		if a := s.account(parts[1]); a != nil {
+			respond(w, map[string]interface{}{"accounts": []interface{}{a}})
+		} else {
+			notFound(w, "account", parts[1])
+		}
+	case "DELETE accounts/*":
+		if !s.deleteAccount(parts[1]) {
+			notFound(w, "account", parts[1])
+		}
+	case "GET accounts/*/transactions":
+		if s.account(parts[1]) == nil {
+			notFound(w, "account", parts[1])
+		} else if t, ok := s.bundle.Transactions[parts[1]]; ok {
+			respond(w, t)
+		} else {
+			respond(w, map[string]interface{}{})
+		}
+	case "GET accounts/*/positions":
+		if s.account(parts[1]) == nil {
+			notFound(w, "account", parts[1])
+		} else if p, ok := s.bundle.Positions[parts[1]]; ok {
+			respond(w, p)
+		} else {
+			respond(w, map[string]interface{}{})
+		}
+	case "GET logins/*/accounts":
+		respond(w, map[string]interface{}{"accounts": s.filter("institutionLoginId", parts[1])})
+	case "PUT logins/*":
+		if !challenge(w, r) {
+			respond(w, map[string]interface{}{"accounts": s.filter("institutionLoginId", parts[1])})
+		}
+	case "POST institutions/*/logins":
+		if !challenge(w, r) {
+			respond(w, map[string]interface{}{"accounts": s.filter("institutionId", parts[1])})
+		}
+	case "GET institutions":
+		list := make([]interface{}, 0)
+		for _, i := range s.bundle.Institutions {
+			list = append(list, map[string]interface{}{
+				"institutionId":   i["institutionId"],
+				"institutionName": i["institutionName"],
+				"homeUrl":         i["homeUrl"],
+			})
+		}
+		respond(w, map[string]interface{}{"institution": list})
+	case "GET institutions/*":
+		if i, ok := s.bundle.Institutions[parts[1]]; ok {
+			respond(w, i)
+		} else {
+			notFound(w, "institution", parts[1])
+		}
+	case "DELETE customers":
+		s.bundle.Accounts = make([]map[string]interface{}, 0)
+		s.bundle.Transactions = make(map[string]map[string]interface{})
+		s.bundle.Positions = make(map[string]map[string]interface{})
+	default:
+		notFound(w, "resource", r.URL.Path)
+	}
+}
+
+func (s *Server) account(id string) map[string]interface{} {
+	for _, a := range s.bundle.Accounts {
+		if fmt.Sprint(a["accountId"]) == id {
+			return a
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) filter(field string, value string) []interface{} {
+	accounts := make([]interface{}, 0)
+	for _, a := range s.bundle.Accounts {
+		if fmt.Sprint(a[field]) == value {
+			accounts = append(accounts, a)
+		}
+	}
+
+	return accounts
+}
+
+func (s *Server) deleteAccount(id string) bool {
+	for i, a := range s.bundle.Accounts {
+		if fmt.Sprint(a["accountId"]) == id {
+			s.bundle.Accounts = append(s.bundle.Accounts[:i], s.bundle.Accounts[i+1:]...)
+			delete(s.bundle.Transactions, id)
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Challenges asked when a login's username is one of the DAG Site's MFA scenarios. Any answer is accepted.
+*/
+var challenges = map[intuit.TestScenario][]interface{}{
+	intuit.TestScenarioTextChallenge: {
+		map[string]interface{}{"textOrImageAndChoice": []interface{}{"What was the name of your first pet?"}},
+	},
+	intuit.TestScenarioChoiceChallenge: {
+		map[string]interface{}{"textOrImageAndChoice": []interface{}{
+			"Which city were you born in?",
+			map[string]interface{}{"val": "1", "text": "Portland"},
+			map[string]interface{}{"val": "2", "text": "Austin"},
+			map[string]interface{}{"val": "3", "text": "Boston"},
+		}},
+	},
+	intuit.TestScenarioImageChallenge: {
+		map[string]interface{}{"textOrImageAndChoice": []interface{}{
+			// A transparent 1x1 GIF.
+			map[string]interface{}{"image": "R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"},
+		}},
+	},
+}
+
+/*
+Respond with the challenges of the login's scenario, unless the request is itself a challenge response. Report whether it did.
+*/
+func challenge(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("challengeSessionId") != "" {
+		return false
+	}
+
+	var login struct {
+		Credentials []struct {
+			Name  string `xml:"name"`
+			Value string `xml:"value"`
+		} `xml:"credentials>credential"`
+	}
+	if xml.NewDecoder(r.Body).Decode(&login) != nil {
+		return false
+	}
+
+	var list []interface{}
+	for _, c := range login.Credentials {
+		if l, ok := challenges[intuit.TestScenario(c.Value)]; ok {
+			list = l
+		}
+	}
+	if list == nil {
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("challengeSessionId", "intuittest-session")
+	w.Header().Set("challengeNodeId", "intuittest-node")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(map[string]interface{}{"challenge": list})
+	return true
+}
+
+func respond(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func notFound(w http.ResponseWriter, kind string, id string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errorInfo": []interface{}{map[string]interface{}{
+			"errorType":    "APP_ERROR",
+			"errorCode":    "404",
+			"errorMessage": fmt.Sprintf("%s %s not found", kind, id),
+		}},
+	})
+}
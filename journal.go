@@ -0,0 +1,311 @@
+package intuit
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+OperationKind identifies a mutating API call recorded in a Journal.
+*/
+type OperationKind string
+
+const (
+	OperationDiscover       OperationKind = "DISCOVER"
+	OperationUpdateLogin    OperationKind = "UPDATE_LOGIN"
+	OperationAnswer         OperationKind = "ANSWER_CHALLENGE"
+	OperationDeleteLogin    OperationKind = "DELETE_LOGIN"
+	OperationDeleteAccount  OperationKind = "DELETE_ACCOUNT"
+	OperationDeleteCustomer OperationKind = "DELETE_CUSTOMER"
+)
+
+/*
+OperationStatus is how far an operation is known to have got.
+*/
+type OperationStatus string
+
+const (
+	// The request was started but its outcome was never recorded, as after a crash; it may or may not have taken effect.
+	OperationPending OperationStatus = "PENDING"
+
+	OperationSucceeded OperationStatus = "SUCCEEDED"
+	OperationFailed    OperationStatus = "FAILED"
+
+	// The institution asked MFA questions; the operation completes when they are answered.
+	OperationChallenged OperationStatus = "CHALLENGED"
+)
+
+/*
+Operation is a journal entry for a mutating API call.
+*/
+type Operation struct {
+	// The call's request Id, shared by its retries.
+	Id         string          `json:"id"`
+	Kind       OperationKind   `json:"kind"`
+	Status     OperationStatus `json:"status"`
+	CustomerId string          `json:"customerId"`
+
+	// Identify what the operation acted on; only those relevant to its kind are set.
+	InstitutionId string `json:"institutionId,omitempty"`
+	LoginId       string `json:"loginId,omitempty"`
+	AccountId     string `json:"accountId,omitempty"`
+
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+/*
+How long finished operations stay in a journal, for auditing.
+*/
+var JournalRetention = 24 * time.Hour
+
+/*
+Journal is a write-ahead record of discover, update, answer and delete calls, kept in a KV so that after a crash an application can find the operations which may have partially completed and reconcile them with Reconcile.
+
+	journal := intuit.NewJournal(kv)
+	configuration.Middleware = append([]intuit.Middleware{journal.Middleware()}, configuration.Middleware...)
+
+	// On startup:
+	reconciled, err := journal.Reconcile()
+*/
+type Journal struct {
+	kv KV
+}
+
+func NewJournal(kv KV) *Journal {
+	return &Journal{kv: kv}
+}
+
+/*
+Return middleware recording each mutating call as pending before it is sent, then with its outcome. Place it first, so retries are journaled as one operation.
+*/
+func (j *Journal) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			op, ok := journalOperation(req)
+			if !ok {
+				return next(req)
+			}
+
+			op.StartedAt = time.Now().UTC()
+			if err := j.save(op); err != nil {
+				// The call is not made unless it can be journaled first.
+				return nil, &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: err, RequestId: req.RequestId}
+			}
+
+			res, err := next(req)
+
+			op.FinishedAt = time.Now().UTC()
+			switch apiError, _ := err.(*APIError); {
+			case err == nil:
+				op.Status = OperationSucceeded
+			case apiError != nil && isChallenge(apiError.Data):
+				op.Status = OperationChallenged
+			default:
+				op.Status = OperationFailed
+				op.Error = err.Error()
+			}
+			if saveErr := j.save(op); saveErr != nil {
+				configurationFor(req.Context).logf("intuit: warning: journaling operation %s: %v", op.Id, saveErr)
+			}
+
+			return res, err
+		}
+	}
+}
+
+/*
+Return the operation a request performs, if it is a mutating call.
+*/
+func journalOperation(req *Request) (*Operation, bool) {
+	op := &Operation{Id: req.RequestId, Status: OperationPending, CustomerId: customerFor(req.Context)}
+
+	path := req.Endpoint
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	answering := headerValue(http.Header(req.Header), "challengeSessionId") != ""
+
+	switch {
+	case req.Method == POST && len(parts) == 3 && parts[0] == "institutions" && parts[2] == "logins":
+		op.Kind, op.InstitutionId = OperationDiscover, parts[1]
+	case req.Method == PUT && len(parts) == 2 && parts[0] == "logins":
+		op.Kind, op.LoginId = OperationUpdateLogin, parts[1]
+	case req.Method == DELETE && len(parts) == 2 && parts[0] == "logins":
+		op.Kind, op.LoginId = OperationDeleteLogin, parts[1]
+	case req.Method == DELETE && len(parts) == 2 && parts[0] == "accounts":
+		op.Kind, op.AccountId = OperationDeleteAccount, parts[1]
+	case req.Method == DELETE && len(parts) == 1 && parts[0] == "customers":
+		op.Kind = OperationDeleteCustomer
+	default:
+		return nil, false
+	}
+
+	if answering && (op.Kind == OperationDiscover || op.Kind == OperationUpdateLogin) {
+		op.Kind = OperationAnswer
+	}
+
+	return op, true
+}
+
+func (j *Journal) save(op *Operation) error {
+	b, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+
+	ttl := JournalRetention
+	if op.Status == OperationPending {
+		ttl = 0
+	}
+
+	return j.kv.Set("journal/"+op.Id, b, ttl)
+}
+
+/*
+Return the journaled operations, oldest first.
+*/
+func (j *Journal) Operations() ([]Operation, error) {
+	keys, err := j.kv.Keys("journal/")
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]Operation, 0, len(keys))
+	for _, k := range keys {
+		b, err := j.kv.Get(k)
+		if err == ErrKeyNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		var op Operation
+		if err := json.Unmarshal(b, &op); err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	sort.SliceStable(ops, func(a, b int) bool { return ops[a].StartedAt.Before(ops[b].StartedAt) })
+	return ops, nil
+}
+
+/*
+Return the operations whose outcome was never recorded, which may have partially completed.
+*/
+func (j *Journal) Pending() ([]Operation, error) {
+	ops, err := j.Operations()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Operation, 0)
+	for _, op := range ops {
+		if op.Status == OperationPending {
+			pending = append(pending, op)
+		}
+	}
+
+	return pending, nil
+}
+
+/*
+Reconciliation is the outcome of a pending operation, as determined from the customer's accounts.
+*/
+type Reconciliation struct {
+	Operation Operation
+
+	// OperationSucceeded if the operation took effect, otherwise OperationFailed; it is safe to retry a failed operation.
+	Status OperationStatus
+
+	// The customer's accounts the operation concerns: those at the institution for a discover, at the login for an update or answer, and any remaining for a delete.
+	Accounts []CustomerAccount
+}
+
+/*
+Determine the outcome of every pending operation from its customer's current accounts, and record it in the journal.
+
+A discover succeeded if the customer has accounts at the institution, and an update or answer if the login aggregated after the operation started. A delete succeeded if what it deleted is gone.
+*/
+func (j *Journal) Reconcile() ([]Reconciliation, error) {
+	return defaultClient().Reconcile(j)
+}
+
+/*
+Reconcile a journal's pending operations against the accounts the client sees. See Journal.Reconcile.
+*/
+func (c *Client) Reconcile(j *Journal) ([]Reconciliation, error) {
+	pending, err := j.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Reconciliation, 0, len(pending))
+	customers := make(map[string][]CustomerAccount)
+	for _, op := range pending {
+		accounts, ok := customers[op.CustomerId]
+		if !ok {
+			ctx := WithCustomer(c.background(), op.CustomerId)
+			var list accountList
+			if err := fetch(ctx, GET, "accounts", nil, nil, nil, &list); err != nil {
+				if op.Kind != OperationDeleteCustomer || StatusCode(err) != http.StatusNotFound {
+					return results, err
+				}
+			}
+			accounts = list.Accounts
+			customers[op.CustomerId] = accounts
+		}
+
+		r := reconcile(op, accounts)
+		r.Operation.Status = r.Status
+		r.Operation.FinishedAt = time.Now().UTC()
+		if err := j.save(&r.Operation); err != nil {
+			return results, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+func reconcile(op Operation, accounts []CustomerAccount) Reconciliation {
+	r := Reconciliation{Operation: op, Status: OperationFailed}
+
+	switch op.Kind {
+	case OperationDiscover:
+		r.Accounts = FilterAccounts(accounts, func(a CustomerAccount) bool { return a.InstitutionId.String() == op.InstitutionId })
+		if len(r.Accounts) > 0 {
+			r.Status = OperationSucceeded
+		}
+	case OperationUpdateLogin, OperationAnswer:
+		r.Accounts = FilterAccounts(accounts, func(a CustomerAccount) bool {
+			if op.LoginId != "" {
+				return a.InstitutionLoginId.String() == op.LoginId
+			}
+			return a.InstitutionId.String() == op.InstitutionId
+		})
+		for _, a := range r.Accounts {
+			if !a.AggrSuccessDate.Before(op.StartedAt) {
+				r.Status = OperationSucceeded
+			}
+		}
+	case OperationDeleteLogin:
+		r.Accounts = FilterAccounts(accounts, func(a CustomerAccount) bool { return a.InstitutionLoginId.String() == op.LoginId })
+	case OperationDeleteAccount:
+		r.Accounts = FilterAccounts(accounts, func(a CustomerAccount) bool { return a.AccountId.String() == op.AccountId })
+	case OperationDeleteCustomer:
+		r.Accounts = accounts
+	}
+
+	if strings.HasPrefix(string(op.Kind), "DELETE_") && len(r.Accounts) == 0 {
+		r.Status = OperationSucceeded
+	}
+
+	return r
+}
@@ -0,0 +1,90 @@
+package intuit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestJournalMiddleware(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts/2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer done()
+
+	journal := NewJournal(NewMemoryKV())
+	SessionConfiguration.Middleware = []Middleware{journal.Middleware()}
+
+	assert.NoError(t, DeleteAccount("1"))
+	assert.Error(t, DeleteAccount("2"))
+	res, err := send(context.Background(), GET, "accounts/3", nil, nil, nil)
+	if assert.NoError(t, err) {
+		res.Body.Close()
+	}
+
+	ops, err := journal.Operations()
+	assert.NoError(t, err)
+	if assert.Len(t, ops, 2) {
+		assert.Equal(t, OperationDeleteAccount, ops[0].Kind)
+		assert.Equal(t, "1", ops[0].AccountId)
+		assert.Equal(t, OperationSucceeded, ops[0].Status)
+		assert.NotEmpty(t, ops[0].Id)
+		assert.Equal(t, OperationFailed, ops[1].Status)
+		assert.NotEmpty(t, ops[1].Error)
+	}
+
+	pending, err := journal.Pending()
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestJournalReconcile(t *testing.T) {
+	started := time.Date(2014, 6, 30, 12, 0, 0, 0, time.UTC)
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accounts": [
+			{"accountId": 1, "institutionId": 100000, "institutionLoginId": 10, "aggrSuccessDate": "2014-06-30T13:00:00Z"},
+			{"accountId": 2, "institutionId": 200000, "institutionLoginId": 20, "aggrSuccessDate": "2014-06-29T00:00:00Z"}
+		]}`))
+	})
+	defer done()
+
+	journal := NewJournal(NewMemoryKV())
+	for _, op := range []Operation{
+		{Id: "a", Kind: OperationDiscover, InstitutionId: "100000"},
+		{Id: "b", Kind: OperationDiscover, InstitutionId: "300000"},
+		{Id: "c", Kind: OperationUpdateLogin, LoginId: "10"},
+		{Id: "d", Kind: OperationUpdateLogin, LoginId: "20"},
+		{Id: "e", Kind: OperationDeleteAccount, AccountId: "2"},
+		{Id: "f", Kind: OperationDeleteLogin, LoginId: "30"},
+	} {
+		op.Status, op.StartedAt = OperationPending, started
+		assert.NoError(t, journal.save(&op))
+	}
+	assert.NoError(t, journal.save(&Operation{Id: "g", Kind: OperationDeleteAccount, AccountId: "1", Status: OperationSucceeded, StartedAt: started}))
+
+	results, err := journal.Reconcile()
+	assert.NoError(t, err)
+
+	statuses := make(map[string]OperationStatus)
+	for _, r := range results {
+		statuses[r.Operation.Id] = r.Status
+	}
+	assert.Equal(t, map[string]OperationStatus{
+		"a": OperationSucceeded,
+		"b": OperationFailed,
+		"c": OperationSucceeded,
+		"d": OperationFailed,
+		"e": OperationFailed,
+		"f": OperationSucceeded,
+	}, statuses)
+
+	pending, err := journal.Pending()
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
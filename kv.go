@@ -0,0 +1,221 @@
+package intuit
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Returned by KV.Get for keys which are missing or have expired.
+*/
+var ErrKeyNotFound = errors.New("intuit: key not found")
+
+/*
+KV is a key-value store persisting the package's state across restarts and, with a shared store, across instances. Setting Configuration.KV enables it for OAuth tokens, the institution and account caches and snapshots saved with SaveSnapshot and the call counts of QuotaMiddleware; NewKVChallengeStore uses it for MFA challenge sessions.
+
+Keys are namespaced by subsystem, such as "token/", "institution/" and "quota/", and then by OAuth consumer key, so several applications can share a store. MemoryKV and FileKV are provided, and a bbolt store is available in the boltkv package.
+*/
+type KV interface {
+	// Return ErrKeyNotFound for keys which are missing or expired.
+	Get(key string) ([]byte, error)
+
+	// Store a value, expiring after ttl, or never when ttl is zero.
+	Set(key string, value []byte, ttl time.Duration) error
+
+	Delete(key string) error
+
+	// Return the live keys starting with prefix, in order.
+	Keys(prefix string) ([]string, error)
+}
+
+type kvEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func (e kvEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
+
+func kvExpiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(ttl)
+}
+
+/*
+MemoryKV is a KV held in memory, for tests and single instances which need no persistence.
+*/
+type MemoryKV struct {
+	mutex   sync.Mutex
+	entries map[string]kvEntry
+}
+
+func NewMemoryKV() *MemoryKV {
+	return &MemoryKV{entries: make(map[string]kvEntry)}
+}
+
+func (m *MemoryKV) Get(key string) ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok || e.expired(time.Now()) {
+		return nil, ErrKeyNotFound
+	}
+
+	return append([]byte(nil), e.value...), nil
+}
+
+func (m *MemoryKV) Set(key string, value []byte, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.entries[key] = kvEntry{value: append([]byte(nil), value...), expires: kvExpiry(ttl)}
+	return nil
+}
+
+func (m *MemoryKV) Delete(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *MemoryKV) Keys(prefix string) ([]string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	keys := make([]string, 0)
+	for k, e := range m.entries {
+		if strings.HasPrefix(k, prefix) && !e.expired(now) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+/*
+FileKV is a KV keeping each value in its own file in a directory, for single instances which should keep their state across restarts.
+*/
+type FileKV struct {
+	Dir string
+}
+
+/*
+Return a FileKV in dir, creating the directory if needed.
+*/
+func NewFileKV(dir string) (*FileKV, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileKV{Dir: dir}, nil
+}
+
+// File names are the hex-encoded keys, so any key is a valid name.
+func (f *FileKV) path(key string) string {
+	return filepath.Join(f.Dir, hex.EncodeToString([]byte(key)))
+}
+
+/*
+Each file holds the expiry in Unix nanoseconds, zero for none, followed by the value.
+*/
+func (f *FileKV) Get(key string) ([]byte, error) {
+	b, err := ioutil.ReadFile(f.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrKeyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if len(b) < 8 {
+		return nil, ErrKeyNotFound
+	}
+
+	if expires := int64(binary.BigEndian.Uint64(b)); expires != 0 && time.Now().UnixNano() > expires {
+		os.Remove(f.path(key))
+		return nil, ErrKeyNotFound
+	}
+
+	return b[8:], nil
+}
+
+func (f *FileKV) Set(key string, value []byte, ttl time.Duration) error {
+	b := make([]byte, 8, 8+len(value))
+	if expires := kvExpiry(ttl); !expires.IsZero() {
+		binary.BigEndian.PutUint64(b, uint64(expires.UnixNano()))
+	}
+	b = append(b, value...)
+
+	// Write to a temporary file and rename it, so readers never see a partial value.
+	tmp, err := ioutil.TempFile(f.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(b); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path(key))
+}
+
+func (f *FileKV) Delete(key string) error {
+	err := os.Remove(f.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (f *FileKV) Keys(prefix string) ([]string, error) {
+	files, err := ioutil.ReadDir(f.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0)
+	for _, file := range files {
+		b, err := hex.DecodeString(file.Name())
+		if err != nil || !strings.HasPrefix(string(b), prefix) {
+			continue
+		}
+		if _, err := f.Get(string(b)); err == nil {
+			keys = append(keys, string(b))
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+/*
+Return the configured KV, or nil.
+*/
+func (c *Configuration) kv() KV {
+	if c == nil {
+		return nil
+	}
+
+	return c.KV
+}
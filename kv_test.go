@@ -0,0 +1,102 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testKV(t *testing.T, kv KV) {
+	_, err := kv.Get("missing")
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	assert.NoError(t, kv.Set("token/a", []byte("one"), 0))
+	assert.NoError(t, kv.Set("token/b", []byte("two"), 0))
+	assert.NoError(t, kv.Set("other/c", []byte("three"), 0))
+	assert.NoError(t, kv.Set("token/expired", []byte("four"), time.Nanosecond))
+	time.Sleep(time.Millisecond)
+
+	b, err := kv.Get("token/a")
+	assert.NoError(t, err)
+	assert.Equal(t, "one", string(b))
+
+	_, err = kv.Get("token/expired")
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	keys, err := kv.Keys("token/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"token/a", "token/b"}, keys)
+
+	assert.NoError(t, kv.Delete("token/a"))
+	assert.NoError(t, kv.Delete("token/a"))
+	_, err = kv.Get("token/a")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func TestMemoryKV(t *testing.T) {
+	testKV(t, NewMemoryKV())
+}
+
+func TestFileKV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "intuit-kv")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kv, err := NewFileKV(dir)
+	assert.NoError(t, err)
+	testKV(t, kv)
+}
+
+func TestKVPersistence(t *testing.T) {
+	var requests int32
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"institutionId": 1, "institutionName": "First"}`))
+	})
+	defer done()
+	SessionConfiguration.KV = NewMemoryKV()
+	defer ClearInstitutionCache()
+
+	_, _, err := CachedInstitution("1")
+	assert.NoError(t, err)
+
+	// A restarted instance loads the institution from the KV rather than fetching it.
+	institutionCache.entries = make(map[string]*cacheEntry)
+	institution, _, err := CachedInstitution("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "First", institution.InstitutionName)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	ClearInstitutionCache()
+	keys, _ := SessionConfiguration.KV.Keys("institution/")
+	assert.Equal(t, 0, len(keys))
+
+	SessionConfiguration.setToken("customer", &AccessToken{Token: "token", Secret: "secret"})
+	SessionConfiguration.tokens = nil
+	assert.Equal(t, "secret", SessionConfiguration.token("customer").Secret)
+
+	snapshot := &Snapshot{CustomerId: "customer", CreatedAt: time.Now().UTC()}
+	assert.NoError(t, SaveSnapshot(snapshot))
+	loaded, err := LoadSnapshot("customer")
+	assert.NoError(t, err)
+	assert.Equal(t, "customer", loaded.CustomerId)
+
+	// Another application sharing the store sees neither.
+	other := NewClient(Configuration{OAuthConsumerKey: "other", KV: SessionConfiguration.KV})
+	assert.Nil(t, other.Configuration().token("customer"))
+	_, err = other.LoadSnapshot("customer")
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	store := NewKVChallengeStore(SessionConfiguration.KV)
+	assert.NoError(t, store.Save("key", &ChallengeSession{SessionId: "session"}, time.Minute))
+	session, err := store.Load("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "session", session.SessionId)
+	assert.NoError(t, store.Delete("key"))
+	_, err = store.Load("key")
+	assert.Equal(t, ErrChallengeExpired, err)
+}
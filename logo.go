@@ -0,0 +1,172 @@
+package intuit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+/*
+Logo is an institution's logo image.
+*/
+type Logo struct {
+	Data        []byte
+	ContentType string
+}
+
+/*
+Returned by InstitutionLogo for institutions whose details carry no logo URL.
+*/
+var ErrNoLogo = errors.New("intuit: institution has no logo")
+
+var logoCache = struct {
+	sync.RWMutex
+	entries map[string]*Logo
+}{entries: make(map[string]*Logo)}
+
+/*
+Return an institution's logo, downloading it from the logo URL in the institution's details on first use and caching it for later calls.
+*/
+func InstitutionLogo(institutionId string) (*Logo, error) {
+	return defaultClient().InstitutionLogo(institutionId)
+}
+
+/*
+Return an institution's logo, downloading it on first use. See InstitutionLogo.
+*/
+func (c *Client) InstitutionLogo(institutionId string) (*Logo, error) {
+	return institutionLogo(c.background(), institutionId)
+}
+
+/*
+Fetch and cache the logos of the given institutions, at most concurrency at a time, so a connect screen can render them without waiting. Institutions without a logo are skipped; other failures are reported together in a BatchError.
+*/
+func PrefetchLogos(institutionIds []string, concurrency int) error {
+	return defaultClient().PrefetchLogos(institutionIds, concurrency)
+}
+
+/*
+Fetch and cache the logos of the given institutions. See PrefetchLogos.
+*/
+func (c *Client) PrefetchLogos(institutionIds []string, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	batch := NewBatchError("prefetch logos", len(institutionIds))
+
+	for i, id := range institutionIds {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if _, err := c.InstitutionLogo(id); err != nil && err != ErrNoLogo {
+				batch.Add(i, id, err)
+			}
+		}(i, id)
+	}
+
+	wg.Wait()
+	return batch.Err()
+}
+
+/*
+Prefetch the logos of every institution in PopularInstitutions. See PrefetchLogos.
+*/
+func PrefetchPopularLogos(concurrency int) error {
+	return defaultClient().PrefetchPopularLogos(concurrency)
+}
+
+/*
+Prefetch the logos of every institution in PopularInstitutions. See PrefetchLogos.
+*/
+func (c *Client) PrefetchPopularLogos(concurrency int) error {
+	popular := PopularInstitutions()
+	ids := make([]string, len(popular))
+	for i, p := range popular {
+		ids[i] = p.InstitutionId
+	}
+
+	return c.PrefetchLogos(ids, concurrency)
+}
+
+/*
+Clear the cached institution logos.
+*/
+func ClearLogoCache() {
+	logoCache.Lock()
+	defer logoCache.Unlock()
+
+	logoCache.entries = make(map[string]*Logo)
+}
+
+func institutionLogo(ctx context.Context, institutionId string) (*Logo, error) {
+	logoCache.RLock()
+	logo, ok := logoCache.entries[institutionId]
+	logoCache.RUnlock()
+
+	if ok {
+		return logo, nil
+	}
+
+	institution, err := cachedInstitution(ctx, institutionId)
+	if err != nil {
+		return nil, err
+	}
+	if institution.LogoURL == "" {
+		return nil, ErrNoLogo
+	}
+
+	if logo, err = downloadLogo(ctx, institution.LogoURL); err != nil {
+		return nil, err
+	}
+
+	logoCache.Lock()
+	logoCache.entries[institutionId] = logo
+	logoCache.Unlock()
+
+	return logo, nil
+}
+
+/*
+Download a logo. Logos are served from Intuit's public CDN, so the request is not signed.
+*/
+func downloadLogo(ctx context.Context, logoURL string) (*Logo, error) {
+	req, err := http.NewRequest(GET, logoURL, nil)
+	if err != nil {
+		return nil, &TransportError{Method: GET, Endpoint: logoURL, Err: err}
+	}
+
+	configuration := configurationFor(ctx)
+	res, err := configuration.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, &TransportError{Method: GET, Endpoint: logoURL, Err: err}
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, &TransportError{Method: GET, Endpoint: logoURL, Err: err}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, &APIError{Method: GET, Endpoint: logoURL, StatusCode: res.StatusCode, Status: res.Status, Body: b, Header: res.Header, configuration: configuration}
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(b)
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("intuit: logo at %s is empty", logoURL)
+	}
+
+	return &Logo{Data: b, ContentType: contentType}, nil
+}
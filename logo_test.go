@@ -0,0 +1,43 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestInstitutionLogo(t *testing.T) {
+	var server string
+	logos := 0
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/institutions/1":
+			w.Write([]byte(`{"institutionId": 1, "logoUrl": "` + server + `logos/1.gif"}`))
+		case "/institutions/2":
+			w.Write([]byte(`{"institutionId": 2}`))
+		case "/institutions/3":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/logos/1.gif":
+			logos++
+			w.Write(gifPixel)
+		}
+	})
+	defer done()
+	server = SessionConfiguration.BaseURL
+	ClearInstitutionCache()
+	ClearLogoCache()
+	defer ClearInstitutionCache()
+	defer ClearLogoCache()
+
+	logo, err := InstitutionLogo("1")
+	assert.NoError(t, err)
+	assert.Equal(t, gifPixel, logo.Data)
+	assert.Equal(t, "image/gif", logo.ContentType)
+
+	_, err = InstitutionLogo("2")
+	assert.Equal(t, ErrNoLogo, err)
+
+	err = PrefetchLogos([]string{"1", "2", "3"}, 2)
+	assert.Equal(t, []string{"3"}, err.(*BatchError).Ids())
+	assert.Equal(t, 1, logos)
+}
@@ -0,0 +1,95 @@
+package intuit
+
+import (
+	"sort"
+	"strings"
+)
+
+/*
+AccountConflict is a set of accounts with different Ids but the same masked account number at the same institution, typically the same bank account added through two logins.
+*/
+type AccountConflict struct {
+	InstitutionId string
+	AccountNumber string
+	Accounts      []CustomerAccount
+}
+
+/*
+Merge the accounts discovered at several institutions, such as after onboarding a customer to several banks in one session, into one list in canonical order: by institution, then display position, then account Id.
+
+An account appearing in more than one result is included once, as it appears in the last. Accounts which look like the same bank account are all included, and reported as conflicts for the caller to resolve.
+*/
+func MergeCustomerAccounts(results ...[]CustomerAccount) ([]CustomerAccount, []AccountConflict) {
+	byId := make(map[string]CustomerAccount)
+	for _, result := range results {
+		for _, a := range result {
+			byId[a.AccountId.String()] = a
+		}
+	}
+
+	merged := make([]CustomerAccount, 0, len(byId))
+	for _, a := range byId {
+		merged = append(merged, a)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.InstitutionId != b.InstitutionId {
+			return lessId(a.InstitutionId.String(), b.InstitutionId.String())
+		}
+		if a.DisplayPosition != b.DisplayPosition {
+			return a.DisplayPosition < b.DisplayPosition
+		}
+		return lessId(a.AccountId.String(), b.AccountId.String())
+	})
+
+	conflicts := make([]AccountConflict, 0)
+	index := make(map[[2]string]int)
+	for _, a := range merged {
+		number := strings.TrimSpace(a.AccountNumber)
+		if number == "" {
+			continue
+		}
+
+		key := [2]string{a.InstitutionId.String(), number}
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(conflicts)
+			conflicts = append(conflicts, AccountConflict{InstitutionId: key[0], AccountNumber: number})
+			i = len(conflicts) - 1
+		}
+		conflicts[i].Accounts = append(conflicts[i].Accounts, a)
+	}
+
+	found := make([]AccountConflict, 0)
+	for _, c := range conflicts {
+		if len(c.Accounts) > 1 {
+			found = append(found, c)
+		}
+	}
+
+	return merged, found
+}
+
+/*
+Order Ids numerically when both are numbers, as CAD Ids are, and otherwise as strings.
+*/
+func lessId(a string, b string) bool {
+	if isDigits(a) && isDigits(b) {
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+	}
+
+	return a < b
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
@@ -0,0 +1,34 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMergeCustomerAccounts(t *testing.T) {
+	chase := []CustomerAccount{
+		{AccountId: "30", InstitutionId: "100", AccountNumber: "xxxx1234", DisplayPosition: 2},
+		{AccountId: "9", InstitutionId: "100", AccountNumber: "xxxx5678", DisplayPosition: 1},
+	}
+	wellsFargo := []CustomerAccount{
+		{AccountId: "40", InstitutionId: "20", AccountNumber: "xxxx1111"},
+	}
+	chaseAgain := []CustomerAccount{
+		{AccountId: "31", InstitutionId: "100", AccountNumber: "xxxx1234", DisplayPosition: 3},
+		{AccountId: "9", InstitutionId: "100", AccountNumber: "xxxx5678", DisplayPosition: 1, AccountNickname: "Renamed"},
+	}
+
+	merged, conflicts := MergeCustomerAccounts(chase, wellsFargo, chaseAgain)
+
+	ids := make([]string, len(merged))
+	for i, a := range merged {
+		ids[i] = a.AccountId.String()
+	}
+	assert.Equal(t, []string{"40", "9", "30", "31"}, ids)
+	assert.Equal(t, "Renamed", merged[1].AccountNickname)
+
+	assert.Equal(t, 1, len(conflicts))
+	assert.Equal(t, "100", conflicts[0].InstitutionId)
+	assert.Equal(t, "xxxx1234", conflicts[0].AccountNumber)
+	assert.Equal(t, 2, len(conflicts[0].Accounts))
+}
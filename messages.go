@@ -0,0 +1,146 @@
+package intuit
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+/*
+Language used when no messages exist for the requested one.
+*/
+const DefaultLanguage = "en"
+
+/*
+Messages suitable for showing end users for well-known CAD error and aggregation status codes, keyed by language tag and then code. The empty code holds the message for unknown codes. Callers may add languages or override entries; to use an existing translation catalog instead, set Configuration.TranslateUserMessage.
+
+Messages are text/template templates executed with a UserMessageData, so they can include the reference Intuit support needs, as in {{.IntuitTid}}.
+*/
+var UserMessages = map[string]map[string]string{
+	"en": {
+		"":    "Something went wrong while connecting to your bank. Please try again later.",
+		"102": "Your bank is temporarily unavailable. Please try again later.",
+		"103": "Your bank didn't accept your sign-in details. Please check your username and password and try again.",
+		"106": "Your bank's website is down. Please try again later.",
+		"108": "Your bank needs your attention. Please sign in on your bank's website, then try again.",
+		"109": "Your bank requires a new password. Please change it on your bank's website, then update it here.",
+		"185": "Your bank needs to verify it's you. Please answer the security questions.",
+		"186": "That answer didn't match. Please try again.",
+		"187": "Your bank needs to verify it's you. Please answer the security questions.",
+	},
+	"fr": {
+		"":    "Une erreur s'est produite lors de la connexion à votre banque. Veuillez réessayer plus tard.",
+		"102": "Votre banque est temporairement indisponible. Veuillez réessayer plus tard.",
+		"103": "Votre banque n'a pas accepté vos identifiants. Veuillez vérifier votre nom d'utilisateur et votre mot de passe, puis réessayer.",
+		"106": "Le site Web de votre banque est hors service. Veuillez réessayer plus tard.",
+		"108": "Votre banque requiert votre attention. Veuillez vous connecter au site Web de votre banque, puis réessayer.",
+		"109": "Votre banque exige un nouveau mot de passe. Veuillez le modifier sur le site Web de votre banque, puis le mettre à jour ici.",
+		"185": "Votre banque doit confirmer votre identité. Veuillez répondre aux questions de sécurité.",
+		"186": "Cette réponse ne correspond pas. Veuillez réessayer.",
+		"187": "Votre banque doit confirmer votre identité. Veuillez répondre aux questions de sécurité.",
+	},
+	"es": {
+		"":    "Se produjo un error al conectar con su banco. Vuelva a intentarlo más tarde.",
+		"102": "Su banco no está disponible temporalmente. Vuelva a intentarlo más tarde.",
+		"103": "Su banco no aceptó sus datos de acceso. Compruebe su usuario y contraseña y vuelva a intentarlo.",
+		"106": "El sitio web de su banco no está disponible. Vuelva a intentarlo más tarde.",
+		"108": "Su banco requiere su atención. Inicie sesión en el sitio web de su banco y vuelva a intentarlo.",
+		"109": "Su banco requiere una nueva contraseña. Cámbiela en el sitio web de su banco y luego actualícela aquí.",
+		"185": "Su banco necesita verificar su identidad. Responda las preguntas de seguridad.",
+		"186": "La respuesta no coincide. Vuelva a intentarlo.",
+		"187": "Su banco necesita verificar su identidad. Responda las preguntas de seguridad.",
+	},
+}
+
+/*
+UserMessageData is the data user message templates are executed with.
+*/
+type UserMessageData struct {
+	Code      string
+	IntuitTid string
+	RequestId string
+}
+
+/*
+Return the user message for the error's code in a language, such as "fr-CA". See UserMessages.
+*/
+func (e *APIError) UserMessage(lang string) string {
+	configuration := e.configuration
+	if configuration == nil {
+		configuration = defaultClient().Configuration()
+	}
+
+	return configuration.userMessage(lang, UserMessageData{Code: e.Code, IntuitTid: e.IntuitTid, RequestId: e.RequestId})
+}
+
+/*
+Return the user message for a CAD error or aggregation status code, such as an account's AggrStatusCode, in a language. See UserMessages.
+*/
+func UserMessage(code string, lang string) string {
+	return defaultClient().UserMessage(code, lang)
+}
+
+/*
+Return the user message for a code in a language, trying the client's TranslateUserMessage first. See UserMessage.
+*/
+func (c *Client) UserMessage(code string, lang string) string {
+	return c.Configuration().userMessage(lang, UserMessageData{Code: code})
+}
+
+func (c *Configuration) userMessage(lang string, data UserMessageData) string {
+	text := c.lookupUserMessage(lang, data.Code)
+
+	t, err := template.New(data.Code).Parse(text)
+	if err != nil {
+		return text
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return text
+	}
+
+	return b.String()
+}
+
+/*
+Find the template for a code, trying the configured translator, then the language tag, each shorter prefix of it and DefaultLanguage in turn. Within a language, the message for unknown codes is preferred to a message in another language.
+*/
+func (c *Configuration) lookupUserMessage(lang string, code string) string {
+	lang = strings.Replace(lang, "_", "-", -1)
+
+	if translate := c.translateUserMessage(); translate != nil {
+		if text, ok := translate(lang, code); ok {
+			return text
+		}
+	}
+
+	tags := make([]string, 0)
+	for tag := lang; tag != ""; {
+		tags = append(tags, tag)
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	tags = append(tags, DefaultLanguage)
+
+	for _, tag := range tags {
+		for _, c := range []string{code, ""} {
+			if text, ok := UserMessages[tag][c]; ok {
+				return text
+			}
+		}
+	}
+
+	return ""
+}
+
+func (c *Configuration) translateUserMessage() func(lang string, code string) (string, bool) {
+	if c == nil {
+		return nil
+	}
+
+	return c.TranslateUserMessage
+}
@@ -0,0 +1,47 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUserMessage(t *testing.T) {
+	err := &APIError{Code: "103", IntuitTid: "tid-1"}
+	assert.Equal(t, UserMessages["en"]["103"], err.UserMessage("en-US"))
+	assert.Equal(t, UserMessages["fr"]["103"], err.UserMessage("fr_CA"))
+	assert.Equal(t, UserMessages["en"]["103"], err.UserMessage("de"))
+
+	// Unknown codes get the generic message in the requested language.
+	assert.Equal(t, UserMessages["es"][""], UserMessage("999", "es-MX"))
+
+	UserMessages["en"]["500"] = "Please contact support, quoting {{.IntuitTid}}."
+	defer delete(UserMessages["en"], "500")
+	assert.Equal(t, "Please contact support, quoting tid-1.", (&APIError{Code: "500", IntuitTid: "tid-1"}).UserMessage("en"))
+}
+
+func TestTranslateUserMessage(t *testing.T) {
+	previous := SessionConfiguration
+	defer func() { SessionConfiguration = previous }()
+
+	SessionConfiguration = &Configuration{TranslateUserMessage: func(lang string, code string) (string, bool) {
+		if lang == "pt-BR" && code == "103" {
+			return "Credenciais recusadas ({{.Code}}).", true
+		}
+		return "", false
+	}}
+
+	assert.Equal(t, "Credenciais recusadas (103).", UserMessage("103", "pt-BR"))
+	assert.Equal(t, UserMessages["en"]["102"], UserMessage("102", "pt-BR"))
+}
+
+func TestClientTranslateUserMessage(t *testing.T) {
+	client := NewClient(Configuration{TranslateUserMessage: func(lang string, code string) (string, bool) {
+		return "Credenciais recusadas.", lang == "pt-BR"
+	}})
+
+	assert.Equal(t, "Credenciais recusadas.", client.UserMessage("103", "pt-BR"))
+	assert.Equal(t, "Credenciais recusadas.", (&APIError{Code: "103", configuration: client.Configuration()}).UserMessage("pt-BR"))
+
+	// The session's messages are unaffected.
+	assert.Equal(t, UserMessages["en"]["103"], UserMessage("103", "pt-BR"))
+}
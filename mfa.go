@@ -0,0 +1,237 @@
+package intuit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+MFAHint summarizes how an institution has behaved during the caller's own logins to it, so apps can set expectations such as "this bank usually asks a security question".
+*/
+type MFAHint struct {
+	InstitutionId string `json:"institutionId"`
+
+	// Login attempts, not counting challenge responses.
+	Logins int `json:"logins"`
+
+	// Logins which were met with at least one challenge.
+	Challenged int `json:"challenged"`
+
+	// Challenges asked across every round of every login, by kind.
+	Challenges map[ChallengeKind]int `json:"challenges"`
+
+	// Requests made to log in or answer challenges, and the total time the institution took to respond to them.
+	Requests     int           `json:"requests"`
+	ResponseTime time.Duration `json:"responseTime"`
+}
+
+/*
+Return the fraction of logins which were challenged.
+*/
+func (h MFAHint) ChallengeRate() float64 {
+	if h.Logins == 0 {
+		return 0
+	}
+
+	return float64(h.Challenged) / float64(h.Logins)
+}
+
+/*
+Return the average number of challenges asked of a challenged login.
+*/
+func (h MFAHint) AverageChallenges() float64 {
+	if h.Challenged == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, n := range h.Challenges {
+		total += n
+	}
+
+	return float64(total) / float64(h.Challenged)
+}
+
+/*
+Return the average time the institution took to respond to a login or challenge response.
+*/
+func (h MFAHint) AverageResponseTime() time.Duration {
+	if h.Requests == 0 {
+		return 0
+	}
+
+	return h.ResponseTime / time.Duration(h.Requests)
+}
+
+/*
+Return the kind of challenge the institution asks most often, if it has asked any.
+*/
+func (h MFAHint) CommonChallenge() (kind ChallengeKind, ok bool) {
+	most := 0
+	for _, k := range []ChallengeKind{TextChallenge, ChoiceChallenge, ImageChallenge} {
+		if h.Challenges[k] > most {
+			kind, most, ok = k, h.Challenges[k], true
+		}
+	}
+
+	return
+}
+
+/*
+MFAHints is a database of MFAHint values, populated by its Middleware from the caller's own login history. It is safe for concurrent use.
+*/
+type MFAHints struct {
+	mutex sync.Mutex
+	hints map[string]*MFAHint
+}
+
+/*
+Return an empty hints database.
+*/
+func NewMFAHints() *MFAHints {
+	return &MFAHints{hints: make(map[string]*MFAHint)}
+}
+
+/*
+Read a hints database written by Save.
+*/
+func LoadMFAHints(r io.Reader) (*MFAHints, error) {
+	var list []MFAHint
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	h := NewMFAHints()
+	for i := range list {
+		h.hints[list[i].InstitutionId] = &list[i]
+	}
+
+	return h, nil
+}
+
+/*
+Write the database as JSON, for loading with LoadMFAHints.
+*/
+func (h *MFAHints) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(h.All())
+}
+
+/*
+Return the hint for an institution, if any of its logins have been observed.
+*/
+func (h *MFAHints) Hint(institutionId string) (MFAHint, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	hint, ok := h.hints[institutionId]
+	if !ok {
+		return MFAHint{}, false
+	}
+
+	return hint.copy(), true
+}
+
+/*
+Return the hints for every observed institution, ordered by institution Id.
+*/
+func (h *MFAHints) All() []MFAHint {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	all := make([]MFAHint, 0, len(h.hints))
+	for _, hint := range h.hints {
+		all = append(all, hint.copy())
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].InstitutionId < all[j].InstitutionId
+	})
+
+	return all
+}
+
+/*
+Return middleware recording the outcome of every discover and add request, and of the challenge responses that follow it, into the database.
+
+Only logins through DiscoverAndAddAccounts and its variants are observed, since updates to existing logins do not identify the institution.
+*/
+func (h *MFAHints) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			institutionId, ok := loginInstitution(req)
+			if !ok {
+				return next(req)
+			}
+
+			start := time.Now()
+			res, err := next(req)
+			h.observe(institutionId, req, time.Since(start), err)
+
+			return res, err
+		}
+	}
+}
+
+func (h *MFAHints) observe(institutionId string, req *Request, elapsed time.Duration, err error) {
+	apiError, isAPIError := err.(*APIError)
+	if err != nil && !isAPIError {
+		return
+	}
+
+	var session *ChallengeSession
+	if isAPIError && isChallenge(apiError.Data) {
+		session = parseChallengeSession(discoverAndAddType, apiError.Data, apiError.Header)
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	hint, ok := h.hints[institutionId]
+	if !ok {
+		hint = &MFAHint{InstitutionId: institutionId, Challenges: make(map[ChallengeKind]int)}
+		h.hints[institutionId] = hint
+	}
+
+	hint.Requests++
+	hint.ResponseTime += elapsed
+
+	answering := headerValue(http.Header(req.Header), "challengeSessionId") != ""
+	if !answering {
+		hint.Logins++
+	}
+
+	if session != nil {
+		if !answering {
+			hint.Challenged++
+		}
+		for _, c := range session.Challenges {
+			hint.Challenges[c.Kind()]++
+		}
+	}
+}
+
+/*
+Return the institution a discover and add request logs in to.
+*/
+func loginInstitution(req *Request) (string, bool) {
+	parts := strings.Split(strings.Trim(req.Endpoint, "/"), "/")
+	if req.Method != POST || len(parts) != 3 || parts[0] != "institutions" || parts[2] != "logins" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+func (h *MFAHint) copy() MFAHint {
+	c := *h
+	c.Challenges = make(map[ChallengeKind]int, len(h.Challenges))
+	for k, v := range h.Challenges {
+		c.Challenges[k] = v
+	}
+
+	return c
+}
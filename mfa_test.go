@@ -0,0 +1,60 @@
+package intuit
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestMFAHints(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/institutions/100000/logins" && r.Header.Get("challengeSessionId") == "" {
+			w.Header().Set("challengeSessionId", "session")
+			w.Header().Set("challengeNodeId", "node")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"challenge": [
+				{"textOrImageAndChoice": ["Favorite color?"]},
+				{"textOrImageAndChoice": ["Pick a city", {"val": "1", "text": "Paris"}]}
+			]}`))
+			return
+		}
+
+		w.Write([]byte(`{"accounts": []}`))
+	})
+	defer done()
+
+	hints := NewMFAHints()
+	SessionConfiguration.Middleware = []Middleware{hints.Middleware()}
+	credentials := []Credential{{Name: "user", Value: "u"}, {Name: "password", Value: "p"}}
+
+	_, session, err := DiscoverAndAddAccountsWithCredentials("100000", credentials)
+	assert.NoError(t, err)
+	session.Answers = []Answer{TextAnswer("blue"), ChoiceAnswer(session.Challenges[1].Choices[0])}
+	_, err = RespondToChallenge(session)
+	assert.NoError(t, err)
+
+	_, _, err = DiscoverAndAddAccountsWithCredentials("200000", credentials)
+	assert.NoError(t, err)
+	_, err = Do(GET, "accounts", nil, nil, nil)
+	assert.NoError(t, err)
+
+	hint, ok := hints.Hint("100000")
+	assert.True(t, ok)
+	assert.Equal(t, 1, hint.Logins)
+	assert.Equal(t, 2, hint.Requests)
+	assert.Equal(t, 1.0, hint.ChallengeRate())
+	assert.Equal(t, 2.0, hint.AverageChallenges())
+	assert.Equal(t, map[ChallengeKind]int{TextChallenge: 1, ChoiceChallenge: 1}, hint.Challenges)
+
+	hint, _ = hints.Hint("200000")
+	assert.Equal(t, 0.0, hint.ChallengeRate())
+	_, ok = hint.CommonChallenge()
+	assert.False(t, ok)
+
+	var b bytes.Buffer
+	assert.NoError(t, hints.Save(&b))
+	loaded, err := LoadMFAHints(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, hints.All(), loaded.All())
+}
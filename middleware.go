@@ -0,0 +1,359 @@
+package intuit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Request is a single API call as seen by middleware.
+*/
+type Request struct {
+	Context  context.Context
+	Method   string
+	Endpoint string
+	Body     interface{}
+	Params   map[string]string
+	Header   map[string][]string
+
+	// Client-generated Id of the call, sent in the RequestIdHeader header and reported in logs, metrics and errors. Retries of the call share it.
+	RequestId string
+
+	// OAuth token used to sign the request, set by the authentication stage.
+	Token *AccessToken
+}
+
+/*
+Handler executes a request, returning the raw response. Non-2xx responses are returned as an *APIError and network failures as a *TransportError.
+*/
+type Handler func(req *Request) (*http.Response, error)
+
+/*
+Middleware wraps a Handler to add behavior around every API call.
+
+Requests pass through authentication first, then each configured middleware in order, before being signed and sent. Responses are decoded once they leave the pipeline, so middleware always sees the raw response and may read or replace its body. A typical configuration is:
+
+	Middleware: []intuit.Middleware{
+		intuit.RetryMiddleware(3, time.Second),
+		intuit.RateLimitMiddleware(100 * time.Millisecond),
+		intuit.LoggingMiddleware(log.New(os.Stderr, "intuit: ", log.LstdFlags)),
+	}
+*/
+type Middleware func(next Handler) Handler
+
+func pipeline(middleware []Middleware) Handler {
+	return authenticate(chain(transport, middleware))
+}
+
+/*
+Wrap h with the middleware so that the first middleware runs outermost.
+*/
+func chain(h Handler, middleware []Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+
+	return h
+}
+
+/*
+Obtain an OAuth token for the request's customer through the SAML exchange on first use and attach it to the request. When RequireAuthenticate is set, requests fail with ErrNotAuthenticated until Authenticate has been called.
+*/
+func authenticate(next Handler) Handler {
+	return func(req *Request) (*http.Response, error) {
+		configuration := configurationFor(req.Context)
+		customerId := customerFor(req.Context)
+
+		token := configuration.token(customerId)
+		if token == nil {
+			if configuration.RequireAuthenticate {
+				return nil, ErrNotAuthenticated
+			}
+
+			if err := Authenticate(req.Context); err != nil {
+				return nil, err
+			}
+			token = configuration.token(customerId)
+		}
+
+		req.Token = token
+		return next(req)
+	}
+}
+
+/*
+Logger receives diagnostic output. *log.Logger satisfies it.
+*/
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+func (c *Configuration) logf(format string, v ...interface{}) {
+	if c != nil && c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+/*
+Return middleware logging the method, endpoint, outcome and duration of every request.
+*/
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next(req)
+
+			if err != nil {
+				logger.Printf("%s %s failed after %v: %v request_id=%s", req.Method, req.Endpoint, time.Since(start), err, req.RequestId)
+			} else {
+				logger.Printf("%s %s %d in %v request_id=%s", req.Method, req.Endpoint, res.StatusCode, time.Since(start), req.RequestId)
+			}
+
+			return res, err
+		}
+	}
+}
+
+/*
+RequestMetrics describes a completed request.
+*/
+type RequestMetrics struct {
+	RequestId  string
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+/*
+Return middleware reporting the metrics of every request to observe.
+*/
+func MetricsMiddleware(observe func(RequestMetrics)) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next(req)
+
+			m := RequestMetrics{RequestId: req.RequestId, Method: req.Method, Endpoint: req.Endpoint, Duration: time.Since(start), Err: err}
+			if res != nil {
+				m.StatusCode = res.StatusCode
+			} else if apiError, ok := err.(*APIError); ok {
+				m.StatusCode = apiError.StatusCode
+			}
+			observe(m)
+
+			return res, err
+		}
+	}
+}
+
+/*
+Return middleware retrying transport failures of idempotent requests, and 5xx responses to GET requests, up to attempts times in total, doubling the backoff after each attempt.
+
+POST requests are never retried, since a request which failed in transport may still have reached the API, and repeating it could add a login or answer a challenge twice.
+*/
+func RetryMiddleware(attempts int, backoff time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (res *http.Response, err error) {
+			delay := backoff
+
+			for i := 0; i < attempts || i == 0; i++ {
+				if i > 0 {
+					select {
+					case <-time.After(delay):
+					case <-req.Context.Done():
+						return nil, err
+					}
+					delay *= 2
+				}
+
+				res, err = next(req)
+				if !retryable(req, err) {
+					return
+				}
+			}
+
+			return
+		}
+	}
+}
+
+func retryable(req *Request, err error) bool {
+	switch e := err.(type) {
+	case *TransportError:
+		return idempotent(req.Method) && e.Err != context.Canceled && e.Err != context.DeadlineExceeded
+	case *APIError:
+		return req.Method == GET && e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case GET, PUT, DELETE, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+/*
+Return middleware spacing requests at least interval apart.
+*/
+func RateLimitMiddleware(interval time.Duration) Middleware {
+	var (
+		mutex sync.Mutex
+		next  time.Time
+	)
+
+	return func(h Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			mutex.Lock()
+			now := time.Now()
+			wait := next.Sub(now)
+			if wait < 0 {
+				wait = 0
+			}
+			next = now.Add(wait + interval)
+			mutex.Unlock()
+
+			if wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-req.Context.Done():
+					return nil, &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: req.Context.Err(), RequestId: req.RequestId}
+				}
+			}
+
+			return h(req)
+		}
+	}
+}
+
+type coalescedCall struct {
+	done chan struct{}
+	res  *http.Response
+	body []byte
+	err  error
+}
+
+/*
+Return a copy of the shared response with its own body, so each caller can read and close it.
+*/
+func (c *coalescedCall) response() (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	res := *c.res
+	res.Header = c.res.Header.Clone()
+	res.Body = ioutil.NopCloser(bytes.NewReader(c.body))
+	return &res, nil
+}
+
+/*
+Return middleware coalescing identical GET requests which are in flight at the same time, such as the same account fetched by several parts of a UI at once: the first is sent, and the others wait for and share its response. Requests are identical when they are for the same customer, endpoint, params and headers.
+
+Only endpoints matching one of the patterns, in the syntax of path.Match such as "accounts/*", are coalesced; all GET requests are when none are given. Responses are not cached once complete.
+*/
+func CoalesceMiddleware(endpoints ...string) Middleware {
+	var mutex sync.Mutex
+	calls := make(map[string]*coalescedCall)
+
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			if req.Method != GET || !matchEndpoint(endpoints, req.Endpoint) {
+				return next(req)
+			}
+
+			key := coalesceKey(req)
+			mutex.Lock()
+			if c, ok := calls[key]; ok {
+				mutex.Unlock()
+
+				select {
+				case <-c.done:
+					return c.response()
+				case <-req.Context.Done():
+					return nil, &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: req.Context.Err(), RequestId: req.RequestId}
+				}
+			}
+
+			c := &coalescedCall{done: make(chan struct{})}
+			calls[key] = c
+			mutex.Unlock()
+
+			defer func() {
+				if c.res == nil && c.err == nil {
+					c.err = &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: errors.New("coalesced request did not complete"), RequestId: req.RequestId}
+				}
+
+				mutex.Lock()
+				delete(calls, key)
+				mutex.Unlock()
+				close(c.done)
+			}()
+
+			res, err := next(req)
+			if err != nil {
+				c.err = err
+				return nil, err
+			}
+
+			defer res.Body.Close()
+			if c.body, c.err = ioutil.ReadAll(res.Body); c.err != nil {
+				c.err = &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: c.err, RequestId: req.RequestId}
+				return nil, c.err
+			}
+			c.res = res
+
+			return c.response()
+		}
+	}
+}
+
+func matchEndpoint(patterns []string, endpoint string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, endpoint); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func coalesceKey(req *Request) string {
+	params := make(url.Values)
+	for k, v := range req.Params {
+		params.Set(k, v)
+	}
+
+	header := make([]string, 0, len(req.Header))
+	for k, v := range req.Header {
+		header = append(header, k+"="+strings.Join(v, ","))
+	}
+	sort.Strings(header)
+
+	// The token identifies the customer.
+	token := ""
+	if req.Token != nil {
+		token = req.Token.Token
+	}
+
+	return strings.Join([]string{token, req.Endpoint, params.Encode(), strings.Join(header, "&")}, "\n")
+}
@@ -0,0 +1,193 @@
+package intuit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryMiddleware(t *testing.T) {
+	calls := 0
+	h := RetryMiddleware(3, 0)(func(req *Request) (*http.Response, error) {
+		calls++
+		if calls < 3 {
+			return nil, &APIError{StatusCode: http.StatusServiceUnavailable}
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	res, err := h(&Request{Context: context.Background(), Method: GET})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryMiddlewareSkipsClientErrors(t *testing.T) {
+	calls := 0
+	h := RetryMiddleware(3, 0)(func(req *Request) (*http.Response, error) {
+		calls++
+		return nil, &APIError{StatusCode: http.StatusNotFound}
+	})
+
+	_, err := h(&Request{Context: context.Background(), Method: GET})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	h = RetryMiddleware(3, 0)(func(req *Request) (*http.Response, error) {
+		calls++
+		return nil, &TransportError{Err: errors.New("connection reset")}
+	})
+
+	_, err = h(&Request{Context: context.Background(), Method: GET})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryMiddlewareSkipsPost(t *testing.T) {
+	calls := 0
+	h := RetryMiddleware(3, 0)(func(req *Request) (*http.Response, error) {
+		calls++
+		return nil, &TransportError{Err: errors.New("connection reset")}
+	})
+
+	_, err := h(&Request{Context: context.Background(), Method: POST})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	_, err = h(&Request{Context: context.Background(), Method: DELETE})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	order := make([]string, 0)
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+
+	h := chain(func(req *Request) (*http.Response, error) {
+		order = append(order, "transport")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}, []Middleware{trace("first"), trace("second")})
+	h(&Request{Context: context.Background()})
+
+	assert.Equal(t, []string{"first", "second", "transport"}, order)
+}
+
+func TestRequestId(t *testing.T) {
+	calls := 0
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "call-1", r.Header.Get(RequestIdHeader))
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer done()
+
+	var metrics []RequestMetrics
+	n := 0
+	SessionConfiguration.RequestIdGenerator = func() (string, error) {
+		n++
+		return fmt.Sprintf("call-%d", n), nil
+	}
+	SessionConfiguration.Middleware = []Middleware{
+		MetricsMiddleware(func(m RequestMetrics) { metrics = append(metrics, m) }),
+		RetryMiddleware(2, 0),
+	}
+
+	_, err := Do(GET, "accounts/1", nil, nil, nil)
+	assert.Equal(t, "call-1", err.(*APIError).RequestId)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "call-1", metrics[0].RequestId)
+}
+
+func TestPanicRecovery(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	defer done()
+
+	cause := errors.New("malformed")
+	SessionConfiguration.Middleware = []Middleware{func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			panic(cause)
+		}
+	}}
+
+	_, err := Do(GET, "accounts", nil, nil, nil)
+	internal, ok := err.(*InternalError)
+	assert.True(t, ok)
+	assert.Equal(t, "accounts", internal.Endpoint)
+	assert.NotEmpty(t, internal.RequestId)
+	assert.Contains(t, string(internal.Stack), "TestPanicRecovery")
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, "intuit: GET accounts: internal error: malformed", err.Error())
+}
+
+func TestCoalesceMiddleware(t *testing.T) {
+	var mutex sync.Mutex
+	calls := make(map[string]int)
+	release := make(chan struct{})
+	h := CoalesceMiddleware("accounts/*")(func(req *Request) (*http.Response, error) {
+		mutex.Lock()
+		calls[req.Method+" "+req.Endpoint]++
+		mutex.Unlock()
+		if req.Endpoint == "accounts/1" {
+			<-release
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(req.Endpoint))}, nil
+	})
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 5)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := h(&Request{Context: context.Background(), Method: GET, Endpoint: "accounts/1"})
+			assert.NoError(t, err)
+			b, _ := ioutil.ReadAll(res.Body)
+			bodies[i] = string(b)
+		}(i)
+	}
+
+	// Wait for the first request to be in flight before letting it complete.
+	for {
+		mutex.Lock()
+		n := calls["GET accounts/1"]
+		mutex.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, []string{"accounts/1", "accounts/1", "accounts/1", "accounts/1", "accounts/1"}, bodies)
+	assert.Equal(t, 1, calls["GET accounts/1"])
+
+	// Other methods and endpoints pass through.
+	h(&Request{Context: context.Background(), Method: DELETE, Endpoint: "accounts/1"})
+	h(&Request{Context: context.Background(), Method: GET, Endpoint: "institutions"})
+	assert.Equal(t, 1, calls["DELETE accounts/1"])
+	assert.Equal(t, 1, calls["GET institutions"])
+}
@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"github.com/MattNewberry/intuit"
+	"strconv"
+)
+
+// Neutral account types
+const (
+	Checking     = "checking"
+	Savings      = "savings"
+	MoneyMarket  = "money_market"
+	CD           = "cd"
+	CreditCard   = "credit_card"
+	LineOfCredit = "line_of_credit"
+	Mortgage     = "mortgage"
+	AutoLoan     = "auto_loan"
+	StudentLoan  = "student_loan"
+	Loan         = "loan"
+	Brokerage    = "brokerage"
+	Retirement   = "retirement"
+	Other        = "other"
+)
+
+/*
+Mapping is an account type in a target provider's model.
+*/
+type Mapping struct {
+	Type    string `json:"type"`
+	Subtype string `json:"subtype,omitempty"`
+}
+
+/*
+Target providers with mapping tables, in the order their columns are written.
+*/
+var Providers = []string{"plaid", "mx", "finicity"}
+
+/*
+Mapping tables from neutral account types to each provider's account model. Tables may be amended by callers before exporting.
+*/
+var Mappings = map[string]map[string]Mapping{
+	"plaid": {
+		Checking:     {"depository", "checking"},
+		Savings:      {"depository", "savings"},
+		MoneyMarket:  {"depository", "money market"},
+		CD:           {"depository", "cd"},
+		CreditCard:   {"credit", "credit card"},
+		LineOfCredit: {"loan", "line of credit"},
+		Mortgage:     {"loan", "mortgage"},
+		AutoLoan:     {"loan", "auto"},
+		StudentLoan:  {"loan", "student"},
+		Loan:         {"loan", "other"},
+		Brokerage:    {"investment", "brokerage"},
+		Retirement:   {"investment", "retirement"},
+		Other:        {"other", "other"},
+	},
+	"mx": {
+		Checking:     {"CHECKING", ""},
+		Savings:      {"SAVINGS", ""},
+		MoneyMarket:  {"SAVINGS", "MONEY_MARKET"},
+		CD:           {"SAVINGS", "CERTIFICATE_OF_DEPOSIT"},
+		CreditCard:   {"CREDIT_CARD", ""},
+		LineOfCredit: {"LINE_OF_CREDIT", ""},
+		Mortgage:     {"MORTGAGE", ""},
+		AutoLoan:     {"LOAN", "AUTO"},
+		StudentLoan:  {"LOAN", "STUDENT"},
+		Loan:         {"LOAN", ""},
+		Brokerage:    {"INVESTMENT", "BROKERAGE"},
+		Retirement:   {"INVESTMENT", "RETIREMENT"},
+		Other:        {"ANY", ""},
+	},
+	"finicity": {
+		Checking:     {"checking", ""},
+		Savings:      {"savings", ""},
+		MoneyMarket:  {"moneyMarket", ""},
+		CD:           {"cd", ""},
+		CreditCard:   {"creditCard", ""},
+		LineOfCredit: {"lineOfCredit", ""},
+		Mortgage:     {"mortgage", ""},
+		AutoLoan:     {"autoLoan", ""},
+		StudentLoan:  {"studentLoan", ""},
+		Loan:         {"loan", ""},
+		Brokerage:    {"investment", ""},
+		Retirement:   {"investment", ""},
+		Other:        {"unknown", ""},
+	},
+}
+
+/*
+Return the neutral type of a CAD account, derived from its banking, credit, loan or investment subtype.
+*/
+func NeutralType(a intuit.CustomerAccount) string {
+	switch a.Subtype() {
+	case intuit.Checking:
+		return Checking
+	case intuit.Savings:
+		return Savings
+	case intuit.MoneyMarket:
+		return MoneyMarket
+	case intuit.CD:
+		return CD
+	case intuit.LineOfCredit:
+		return LineOfCredit
+	case intuit.Mortgage, intuit.HomeEquity:
+		return Mortgage
+	case intuit.AutoLoan:
+		return AutoLoan
+	case intuit.StudentLoan:
+		return StudentLoan
+	case intuit.Taxable, intuit.Brokerage:
+		return Brokerage
+	}
+
+	switch {
+	case a.IsCredit():
+		return CreditCard
+	case a.IsLoan():
+		return Loan
+	case a.IsInvestment():
+		return Retirement
+	}
+
+	return Other
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,126 @@
+/*
+Export Customer Account Data accounts in a provider-neutral format, with mappings to the account models of common successor aggregation APIs.
+*/
+package migrate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"github.com/MattNewberry/intuit"
+	"io"
+	"strings"
+)
+
+/*
+Record is a single account in the neutral export format. CAD account payloads do not carry routing numbers, so RoutingNumber is left for callers to fill from their own records.
+*/
+type Record struct {
+	InstitutionId   string             `json:"institutionId"`
+	InstitutionName string             `json:"institutionName"`
+	LoginId         string             `json:"loginId"`
+	AccountId       string             `json:"accountId"`
+	Nickname        string             `json:"nickname"`
+	Type            string             `json:"type"`
+	MaskedNumber    string             `json:"maskedNumber"`
+	RoutingNumber   string             `json:"routingNumber,omitempty"`
+	CurrencyCode    string             `json:"currencyCode"`
+	Balance         float64            `json:"balance"`
+	Targets         map[string]Mapping `json:"targets"`
+}
+
+/*
+Export every account of the scoped customer as JSON.
+*/
+func Export(w io.Writer) error {
+	accounts, err := intuit.AccountsWithInstitutions()
+	if err != nil {
+		return err
+	}
+
+	return WriteJSON(w, Records(accounts))
+}
+
+/*
+Build export records from accounts joined with their institutions, as returned by intuit.AccountsWithInstitutions.
+*/
+func Records(accounts []intuit.AccountWithInstitution) []Record {
+	records := make([]Record, len(accounts))
+
+	for i, a := range accounts {
+		t := NeutralType(a.CustomerAccount)
+		r := Record{
+			InstitutionId: a.InstitutionId.String(),
+			LoginId:       a.InstitutionLoginId.String(),
+			AccountId:     a.AccountId.String(),
+			Nickname:      a.AccountNickname,
+			Type:          t,
+			MaskedNumber:  Mask(a.AccountNumber),
+			CurrencyCode:  a.CurrencyCode,
+			Balance:       float64(a.BalanceAmount),
+			Targets:       make(map[string]Mapping),
+		}
+
+		if a.Institution != nil {
+			r.InstitutionName = a.Institution.InstitutionName
+		}
+
+		for provider, table := range Mappings {
+			if m, ok := table[t]; ok {
+				r.Targets[provider] = m
+			}
+		}
+
+		records[i] = r
+	}
+
+	return records
+}
+
+/*
+Write records as a JSON array.
+*/
+func WriteJSON(w io.Writer, records []Record) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(records)
+}
+
+/*
+Write records as CSV with a header row. Target mappings are written as one type and subtype column per provider.
+*/
+func WriteCSV(w io.Writer, records []Record) error {
+	header := []string{"institutionId", "institutionName", "loginId", "accountId", "nickname", "type", "maskedNumber", "routingNumber", "currencyCode", "balance"}
+	for _, p := range Providers {
+		header = append(header, p+"Type", p+"Subtype")
+	}
+
+	c := csv.NewWriter(w)
+	if err := c.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		row := []string{r.InstitutionId, r.InstitutionName, r.LoginId, r.AccountId, r.Nickname, r.Type, r.MaskedNumber, r.RoutingNumber, r.CurrencyCode, formatFloat(r.Balance)}
+		for _, p := range Providers {
+			row = append(row, r.Targets[p].Type, r.Targets[p].Subtype)
+		}
+
+		if err := c.Write(row); err != nil {
+			return err
+		}
+	}
+
+	c.Flush()
+	return c.Error()
+}
+
+/*
+Mask all but the last four characters of an account number.
+*/
+func Mask(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+
+	return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+}
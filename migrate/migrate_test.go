@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRecords(t *testing.T) {
+	var a intuit.AccountWithInstitution
+	json.Unmarshal([]byte(`{"accountId": 1, "institutionId": 100000, "institutionLoginId": 7, "accountNumber": "8000006666", "bankingAccountType": "MONEYMRKT", "balanceAmount": 10.5, "currencyCode": "USD"}`), &a)
+	a.Institution = &intuit.InstitutionDetails{InstitutionName: "CCBank"}
+
+	records := Records([]intuit.AccountWithInstitution{a})
+	r := records[0]
+	assert.Equal(t, MoneyMarket, r.Type)
+	assert.Equal(t, "******6666", r.MaskedNumber)
+	assert.Equal(t, "CCBank", r.InstitutionName)
+	assert.Equal(t, Mapping{"depository", "money market"}, r.Targets["plaid"])
+	assert.Equal(t, Mapping{"SAVINGS", "MONEY_MARKET"}, r.Targets["mx"])
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCSV(&buf, records))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, "100000,CCBank,7,1,,money_market,******6666,,USD,10.5,depository,money market,SAVINGS,MONEY_MARKET,moneyMarket,", lines[1])
+}
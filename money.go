@@ -0,0 +1,148 @@
+package intuit
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+Amount is a monetary amount in the currency of its account.
+*/
+type Amount float64
+
+/*
+Locale describes how amounts are written in a region.
+*/
+type Locale struct {
+	Decimal string
+	Group   string
+
+	// Write the currency symbol after the number rather than before it.
+	SymbolAfter bool
+
+	// Separate the currency symbol from the number with a space.
+	SymbolSpace bool
+
+	// Currency whose local symbol is used. Other currencies are written with their international symbol, such as US$ in Canada.
+	Currency string
+}
+
+/*
+Currency describes how amounts in a currency are written.
+*/
+type Currency struct {
+	Symbol              string
+	InternationalSymbol string
+	Digits              int
+}
+
+const DefaultLocale = "en-US"
+
+/*
+Locales known to FormatAmount, keyed by BCP 47 tag. Callers may add their own.
+*/
+var Locales = map[string]Locale{
+	"en-US": {Decimal: ".", Group: ",", Currency: "USD"},
+	"en-CA": {Decimal: ".", Group: ",", Currency: "CAD"},
+	"fr-CA": {Decimal: ",", Group: "\u00a0", SymbolAfter: true, SymbolSpace: true, Currency: "CAD"},
+	"en-IE": {Decimal: ".", Group: ",", Currency: "EUR"},
+	"de-DE": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true, Currency: "EUR"},
+	"fr-FR": {Decimal: ",", Group: "\u202f", SymbolAfter: true, SymbolSpace: true, Currency: "EUR"},
+	"es-ES": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true, Currency: "EUR"},
+	"it-IT": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true, Currency: "EUR"},
+	"nl-NL": {Decimal: ",", Group: ".", SymbolSpace: true, Currency: "EUR"},
+}
+
+/*
+Currencies known to FormatAmount, keyed by ISO 4217 code. Amounts in other currencies are written with their code and two decimal places.
+*/
+var Currencies = map[string]Currency{
+	"USD": {Symbol: "$", InternationalSymbol: "US$", Digits: 2},
+	"CAD": {Symbol: "$", InternationalSymbol: "CA$", Digits: 2},
+	"EUR": {Symbol: "€", InternationalSymbol: "€", Digits: 2},
+}
+
+/*
+Format an amount in a currency for display in a locale, such as "-$1,234.50" for en-US or "1 234,50 $" for fr-CA. Unknown locales fall back to DefaultLocale; both "en-US" and "en_US" forms are accepted.
+*/
+func FormatAmount(amount Amount, currencyCode string, locale string) string {
+	l, c, local, known := moneyFormat(currencyCode, locale)
+	negative := math.Round(float64(amount)*math.Pow10(c.Digits)) < 0
+	return formatMoney(negative, strconv.FormatFloat(math.Abs(float64(amount)), 'f', c.Digits, 64), l, c, local, known)
+}
+
+/*
+Return the locale and currency an amount is written with, whether the currency is the locale's own and whether it is known.
+*/
+func moneyFormat(currencyCode string, locale string) (Locale, Currency, bool, bool) {
+	l, ok := Locales[strings.Replace(locale, "_", "-", -1)]
+	if !ok {
+		l = Locales[DefaultLocale]
+	}
+
+	code := strings.ToUpper(currencyCode)
+	c, known := Currencies[code]
+	if !known {
+		c = Currency{Symbol: code, InternationalSymbol: code, Digits: 2}
+	}
+
+	return l, c, code == l.Currency, known
+}
+
+/*
+Write an unsigned number, such as "1234.50", with its sign and currency symbol as the locale writes them.
+*/
+func formatMoney(negative bool, number string, l Locale, c Currency, local bool, known bool) string {
+	symbol := c.InternationalSymbol
+	if local {
+		symbol = c.Symbol
+	}
+
+	number = groupDigits(number, l)
+
+	space := ""
+	if l.SymbolSpace || !known {
+		space = " "
+	}
+
+	formatted := symbol + space + number
+	if l.SymbolAfter {
+		formatted = number + space + symbol
+	}
+
+	if negative {
+		formatted = "-" + formatted
+	}
+
+	return formatted
+}
+
+/*
+Format the amount in a currency for display in a locale. See FormatAmount.
+*/
+func (a Amount) Format(currencyCode string, locale string) string {
+	return FormatAmount(a, currencyCode, locale)
+}
+
+func groupDigits(s string, l Locale) string {
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(d)
+	}
+
+	if fraction != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(fraction)
+	}
+
+	return b.String()
+}
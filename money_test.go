@@ -0,0 +1,38 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFormatAmount(t *testing.T) {
+	cases := []struct {
+		amount   Amount
+		currency string
+		locale   string
+		expected string
+	}{
+		{1234.5, "USD", "en-US", "$1,234.50"},
+		{-1234.5, "USD", "en_US", "-$1,234.50"},
+		{0.004, "USD", "en-US", "$0.00"},
+		{-0.004, "USD", "en-US", "$0.00"},
+		{1234567.891, "usd", "en-US", "$1,234,567.89"},
+		{12, "CAD", "en-US", "CA$12.00"},
+		{12, "USD", "en-CA", "US$12.00"},
+		{1234.5, "CAD", "en-CA", "$1,234.50"},
+		{1234.5, "CAD", "fr-CA", "1\u00a0234,50 $"},
+		{-1234.5, "EUR", "de-DE", "-1.234,50 €"},
+		{999.99, "EUR", "fr-FR", "999,99 €"},
+		{1234.5, "EUR", "nl-NL", "€ 1.234,50"},
+		{1234.5, "EUR", "en-US", "€1,234.50"},
+		{1234.5, "GBP", "en-US", "GBP 1,234.50"},
+		{1234.5, "USD", "xx-XX", "$1,234.50"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, FormatAmount(c.amount, c.currency, c.locale), "%v %s %s", c.amount, c.currency, c.locale)
+	}
+
+	account := CustomerAccount{BalanceAmount: 50, CurrencyCode: "USD"}
+	assert.Equal(t, "$50.00", account.BalanceAmount.Format(account.CurrencyCode, "en-US"))
+}
@@ -0,0 +1,162 @@
+package intuit
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+/*
+AccountClass is the side of a balance sheet an account falls on.
+*/
+type AccountClass string
+
+const (
+	AssetAccount     AccountClass = "ASSET"
+	LiabilityAccount AccountClass = "LIABILITY"
+
+	// Accounts with no monetary balance, such as rewards programs, or of unknown type.
+	UnclassifiedAccount AccountClass = "UNCLASSIFIED"
+)
+
+/*
+Return whether the account is an asset or a liability: banking and investment accounts are assets, except overdraft lines, and credit and loan accounts are liabilities.
+*/
+func (a CustomerAccount) Class() AccountClass {
+	switch a.Type() {
+	case BankingType:
+		if a.Subtype() == Overdraft {
+			return LiabilityAccount
+		}
+		return AssetAccount
+	case InvestmentType:
+		return AssetAccount
+	case CreditType, LoanType:
+		return LiabilityAccount
+	}
+
+	return UnclassifiedAccount
+}
+
+/*
+NetWorthTotals are the totals of a set of accounts in one currency.
+*/
+type NetWorthTotals struct {
+	Assets      Amount
+	Liabilities Amount
+
+	// Assets less liabilities.
+	NetWorth Amount
+}
+
+func (t *NetWorthTotals) add(a CustomerAccount) {
+	switch a.Class() {
+	case AssetAccount:
+		t.Assets = roundCents(t.Assets + a.BalanceAmount)
+	case LiabilityAccount:
+		// Institutions differ in the sign they report amounts owed with.
+		t.Liabilities = roundCents(t.Liabilities + Amount(math.Abs(float64(a.BalanceAmount))))
+	}
+	t.NetWorth = roundCents(t.Assets - t.Liabilities)
+}
+
+func roundCents(a Amount) Amount {
+	return Amount(math.Round(float64(a)*100) / 100)
+}
+
+/*
+InstitutionNetWorth is the part of a net worth held at one institution.
+*/
+type InstitutionNetWorth struct {
+	InstitutionId string
+
+	// Display name, falling back to the Id when the institution's details are unavailable.
+	Name string
+
+	// Totals keyed by currency code.
+	Totals map[string]NetWorthTotals
+}
+
+/*
+NetWorth is a roll-up of a customer's open accounts into assets and liabilities. Amounts in different currencies are never added together.
+*/
+type NetWorth struct {
+	// Totals keyed by currency code.
+	Totals map[string]NetWorthTotals
+
+	// Totals per institution, ordered by name.
+	Institutions []InstitutionNetWorth
+
+	// Open accounts left out of the totals because they are neither assets nor liabilities.
+	Unclassified []CustomerAccount
+}
+
+/*
+Return the scoped customer's net worth, broken down by currency and institution. Closed accounts are left out.
+
+As with AccountsWithInstitutions, if any institution lookup fails the summary is still returned, with those institutions named by Id, alongside a BatchError.
+*/
+func NetWorthSummary() (*NetWorth, error) {
+	return defaultClient().NetWorthSummary()
+}
+
+/*
+Return the scoped customer's net worth. See NetWorthSummary.
+*/
+func (c *Client) NetWorthSummary() (*NetWorth, error) {
+	accounts, err := c.AccountsWithInstitutions()
+	if accounts == nil {
+		return nil, err
+	}
+
+	return SummarizeNetWorth(accounts), err
+}
+
+/*
+Summarize accounts joined with their institutions, as returned by AccountsWithInstitutions. Balances without a currency code are taken to be in the currency of DefaultLocale. See NetWorthSummary.
+*/
+func SummarizeNetWorth(accounts []AccountWithInstitution) *NetWorth {
+	n := &NetWorth{Totals: make(map[string]NetWorthTotals), Institutions: make([]InstitutionNetWorth, 0), Unclassified: make([]CustomerAccount, 0)}
+	index := make(map[string]int)
+
+	for _, a := range accounts {
+		if a.IsClosed() {
+			continue
+		}
+		if a.Class() == UnclassifiedAccount {
+			n.Unclassified = append(n.Unclassified, a.CustomerAccount)
+			continue
+		}
+
+		currency := strings.ToUpper(a.CurrencyCode)
+		if currency == "" {
+			currency = Locales[DefaultLocale].Currency
+		}
+
+		id := a.InstitutionId.String()
+		i, ok := index[id]
+		if !ok {
+			i = len(n.Institutions)
+			index[id] = i
+			institution := InstitutionNetWorth{InstitutionId: id, Name: id, Totals: make(map[string]NetWorthTotals)}
+			if a.Institution != nil {
+				institution.Name = a.Institution.InstitutionName
+			}
+			n.Institutions = append(n.Institutions, institution)
+		}
+
+		totals := n.Institutions[i].Totals[currency]
+		totals.add(a.CustomerAccount)
+		n.Institutions[i].Totals[currency] = totals
+
+		totals = n.Totals[currency]
+		totals.add(a.CustomerAccount)
+		n.Totals[currency] = totals
+	}
+
+	sort.SliceStable(n.Institutions, func(a, b int) bool {
+		return strings.ToLower(n.Institutions[a].Name) < strings.ToLower(n.Institutions[b].Name)
+	})
+
+	return n
+}
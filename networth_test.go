@@ -0,0 +1,41 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSummarizeNetWorth(t *testing.T) {
+	chase := &InstitutionDetails{InstitutionId: "1", InstitutionName: "Chase"}
+	accounts := []AccountWithInstitution{
+		{CustomerAccount{AccountId: "10", InstitutionId: "1", BankingAccountType: "CHECKING", BalanceAmount: 1000.10, CurrencyCode: "USD"}, chase},
+		{CustomerAccount{AccountId: "11", InstitutionId: "1", CreditAccountType: "CREDITCARD", BalanceAmount: -250.05}, chase},
+		{CustomerAccount{AccountId: "12", InstitutionId: "1", BankingAccountType: "SAVINGS", BalanceAmount: 99999, Status: "CLOSED"}, chase},
+		{CustomerAccount{AccountId: "20", InstitutionId: "2", InvestmentAccountType: "BROKERAGE", BalanceAmount: 5000, CurrencyCode: "cad"}, nil},
+		{CustomerAccount{AccountId: "21", InstitutionId: "2", LoanType: "MORTGAGE", BalanceAmount: 3000, CurrencyCode: "CAD"}, nil},
+		{CustomerAccount{AccountId: "22", InstitutionId: "2"}, nil},
+	}
+
+	n := SummarizeNetWorth(accounts)
+	assert.Equal(t, map[string]NetWorthTotals{
+		"USD": {Assets: 1000.10, Liabilities: 250.05, NetWorth: 750.05},
+		"CAD": {Assets: 5000, Liabilities: 3000, NetWorth: 2000},
+	}, n.Totals)
+
+	if assert.Len(t, n.Institutions, 2) {
+		assert.Equal(t, "2", n.Institutions[0].Name)
+		assert.Equal(t, "Chase", n.Institutions[1].Name)
+		assert.Equal(t, Amount(750.05), n.Institutions[1].Totals["USD"].NetWorth)
+	}
+
+	if assert.Len(t, n.Unclassified, 1) {
+		assert.Equal(t, "22", n.Unclassified[0].AccountId.String())
+	}
+}
+
+func TestAccountClass(t *testing.T) {
+	assert.Equal(t, AssetAccount, CustomerAccount{BankingAccountType: "checking"}.Class())
+	assert.Equal(t, LiabilityAccount, CustomerAccount{BankingAccountType: "OVERDRAFT"}.Class())
+	assert.Equal(t, LiabilityAccount, CustomerAccount{LoanType: "AUTO"}.Class())
+	assert.Equal(t, UnclassifiedAccount, CustomerAccount{}.Class())
+}
@@ -0,0 +1,251 @@
+package intuit
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+/*
+Values of the enumerated string types, listed in the generated spec.
+*/
+var specEnums = map[reflect.Type][]string{
+	reflect.TypeOf(AccountStatus("")):     {string(AccountActive), string(AccountInactive), string(AccountClosed)},
+	reflect.TypeOf(CorrectionAction("")):  {string(CorrectionReplace), string(CorrectionDelete)},
+	reflect.TypeOf(AccountHolderRole("")): {string(PrimaryHolder), string(SecondaryHolder), string(JointHolder), string(Custodian), string(Trustee), string(AuthorizedUser)},
+	reflect.TypeOf(TransactionType("")):   transactionTypeNames(),
+	reflect.TypeOf(Capability("")): {
+		string(CapabilityTransactions), string(CapabilityBalance),
+		string(BankingType), string(CreditType), string(LoanType), string(InvestmentType), string(RewardsType), string(OtherType),
+	},
+}
+
+func transactionTypeNames() []string {
+	names := make([]string, len(transactionTypes))
+	for i, t := range transactionTypes {
+		names[i] = string(t)
+	}
+
+	return names
+}
+
+/*
+Return an OpenAPI 3 document, as JSON, describing the CAD endpoints this package calls and the typed models it decodes their responses into.
+
+The schemas are generated from the Go types themselves, so the document always matches this package's behavior: fields the package does not model are absent, and identifiers are described as numbers or strings since both are accepted.
+*/
+func Spec() ([]byte, error) {
+	s := &specBuilder{schemas: make(map[string]interface{})}
+
+	accountList := s.object(map[string]interface{}{"accounts": s.array(reflect.TypeOf(CustomerAccount{}))})
+	discoverResult := s.schema(reflect.TypeOf(DiscoverResult{}))
+	institution := s.schema(reflect.TypeOf(InstitutionDetails{}))
+	errorResponse := s.object(map[string]interface{}{"errorInfo": s.array(reflect.TypeOf(ErrorInfo{}))})
+
+	transactionList := map[string]interface{}{"notRefreshedReason": map[string]interface{}{"type": "string"}}
+	for _, kind := range []string{"banking", "creditCard", "loan", "investment", "rewards"} {
+		transactionList[kind+"Transactions"] = s.array(reflect.TypeOf(Transaction{}))
+	}
+
+	institutionSummary := s.object(map[string]interface{}{
+		"institutionId":   map[string]interface{}{"$ref": "#/components/schemas/Id"},
+		"institutionName": map[string]interface{}{"type": "string"},
+		"homeUrl":         map[string]interface{}{"type": "string"},
+	})
+	s.schemas["Id"] = map[string]interface{}{"oneOf": []interface{}{
+		map[string]interface{}{"type": "integer", "format": "int64"},
+		map[string]interface{}{"type": "string"},
+	}}
+
+	login := map[string]interface{}{
+		"description": "XML InstitutionLogin document in the " + InstitutionXMLNS + " namespace, carrying credentials or, with challengeSessionId and challengeNodeId headers, challenge responses.",
+		"required":    true,
+		"content":     map[string]interface{}{"application/xml": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}},
+	}
+	challenge := map[string]interface{}{
+		"description": "MFA required. The challenge session is identified by the challengeSessionId and challengeNodeId headers.",
+		"headers": map[string]interface{}{
+			"challengeSessionId": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+			"challengeNodeId":    map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		},
+		"content": jsonContent(map[string]interface{}{"type": "object"}),
+	}
+
+	paths := map[string]interface{}{
+		"/institutions": map[string]interface{}{
+			"get": operation("Institutions", nil, nil, response("All institutions", s.object(map[string]interface{}{"institution": map[string]interface{}{"type": "array", "items": institutionSummary}}))),
+		},
+		"/institutions/{institutionId}": map[string]interface{}{
+			"get": operation("Institution", []string{"institutionId"}, nil, response("Institution details", institution)),
+		},
+		"/institutions/{institutionId}/logins": map[string]interface{}{
+			"post": operation("DiscoverAndAddAccounts", []string{"institutionId"}, login, response("Discovered accounts", discoverResult), challenge),
+		},
+		"/logins/{loginId}": map[string]interface{}{
+			"put": operation("UpdateLoginAccount", []string{"loginId"}, login, response("Accounts of the login", accountList), challenge),
+		},
+		"/logins/{loginId}/accounts": map[string]interface{}{
+			"get": operation("LoginAccounts", []string{"loginId"}, nil, response("Accounts of the login", accountList)),
+		},
+		"/accounts": map[string]interface{}{
+			"get": operation("Accounts", nil, nil, response("Accounts of the customer", accountList)),
+		},
+		"/accounts/{accountId}": map[string]interface{}{
+			"get":    operation("Account", []string{"accountId"}, nil, response("Account detail", accountList)),
+			"delete": operation("DeleteAccount", []string{"accountId"}, nil, map[string]interface{}{"description": "Deleted"}),
+		},
+		"/accounts/{accountId}/transactions": map[string]interface{}{
+			"get": operation("Transactions", []string{"accountId"}, nil, response("Transactions by account type", s.object(transactionList))),
+		},
+		"/customers": map[string]interface{}{
+			"delete": operation("DeleteCustomer", nil, nil, map[string]interface{}{"description": "Deleted"}),
+		},
+	}
+
+	transactions := paths["/accounts/{accountId}/transactions"].(map[string]interface{})["get"].(map[string]interface{})
+	transactions["parameters"] = append(transactions["parameters"].([]interface{}),
+		queryParameter("txnStartDate", "date"),
+		queryParameter("txnEndDate", "date"),
+	)
+
+	for _, p := range paths {
+		for _, op := range p.(map[string]interface{}) {
+			op.(map[string]interface{})["responses"].(map[string]interface{})["default"] = response("Error", errorResponse)
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Intuit Customer Account Data",
+			"version": "v1",
+		},
+		"servers":    []interface{}{map[string]interface{}{"url": strings.TrimSuffix(BaseURL, "/")}},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": s.schemas},
+	}, "", "  ")
+}
+
+func operation(id string, pathParams []string, body interface{}, ok interface{}, challenge ...interface{}) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId": id,
+		"responses":   map[string]interface{}{"200": ok},
+	}
+
+	params := make([]interface{}, len(pathParams))
+	for i, p := range pathParams {
+		params[i] = map[string]interface{}{"name": p, "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}}
+	}
+	op["parameters"] = params
+
+	if body != nil {
+		op["requestBody"] = body
+	}
+	if len(challenge) > 0 {
+		op["responses"].(map[string]interface{})["401"] = challenge[0]
+	}
+
+	return op
+}
+
+func response(description string, schema interface{}) map[string]interface{} {
+	return map[string]interface{}{"description": description, "content": jsonContent(schema)}
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+func queryParameter(name string, format string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "in": "query", "schema": map[string]interface{}{"type": "string", "format": format}}
+}
+
+type specBuilder struct {
+	schemas map[string]interface{}
+}
+
+func (s *specBuilder) object(properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func (s *specBuilder) array(t reflect.Type) map[string]interface{} {
+	return map[string]interface{}{"type": "array", "items": s.schema(t)}
+}
+
+/*
+Return the schema of a Go type, registering named structs as components.
+*/
+func (s *specBuilder) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case reflect.TypeOf(json.Number("")):
+		return map[string]interface{}{"$ref": "#/components/schemas/Id"}
+	case reflect.TypeOf(Date{}):
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(Amount(0)):
+		return map[string]interface{}{"type": "number", "format": "double"}
+	}
+
+	if values, ok := specEnums[t]; ok {
+		return map[string]interface{}{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return s.array(t.Elem())
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		if _, ok := s.schemas[t.Name()]; !ok {
+			s.schemas[t.Name()] = nil
+			s.schemas[t.Name()] = s.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	return map[string]interface{}{}
+}
+
+func (s *specBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded := s.structSchema(f.Type)
+			for k, v := range embedded["properties"].(map[string]interface{}) {
+				if _, exists := properties[k]; !exists {
+					properties[k] = v
+				}
+			}
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = s.schema(f.Type)
+	}
+
+	return s.object(properties)
+}
@@ -0,0 +1,50 @@
+package intuit
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpec(t *testing.T) {
+	b, err := Spec()
+	assert.NoError(t, err)
+
+	var spec struct {
+		OpenAPI    string                            `json:"openapi"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	assert.NoError(t, json.Unmarshal(b, &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+
+	assert.NotNil(t, spec.Paths["/accounts/{accountId}"]["delete"])
+	assert.NotNil(t, spec.Paths["/institutions/{institutionId}/logins"]["post"])
+
+	// Every modeled field is described.
+	for _, typ := range []interface{}{CustomerAccount{}, Transaction{}, InstitutionDetails{}, InstitutionKey{}, AccountHolder{}} {
+		rt := reflect.TypeOf(typ)
+		properties := spec.Components.Schemas[rt.Name()].Properties
+		for i := 0; i < rt.NumField(); i++ {
+			f := rt.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if f.PkgPath != "" || name == "-" || name == "" {
+				continue
+			}
+			_, ok := properties[name]
+			assert.True(t, ok, "%s.%s", rt.Name(), name)
+		}
+	}
+
+	account := spec.Components.Schemas["CustomerAccount"].Properties
+	assert.Equal(t, "#/components/schemas/Id", account["accountId"]["$ref"])
+	assert.Equal(t, "date-time", account["balanceDate"]["format"])
+	assert.Equal(t, []interface{}{"ACTIVE", "INACTIVE", "CLOSED"}, account["status"]["enum"])
+	assert.Equal(t, "array", account["holders"]["type"])
+}
@@ -0,0 +1,118 @@
+package intuit
+
+import (
+	"time"
+)
+
+/*
+Chunk is a single date window requested by a Pager.
+*/
+type Chunk struct {
+	Start time.Time
+	End   time.Time
+}
+
+/*
+Chunker splits a date range into the windows fetched by a Pager.
+*/
+type Chunker func(start time.Time, end time.Time) []Chunk
+
+/*
+Pager fetches a date range in chunks, handing each page to an accumulator. CAD endpoints filter by date rather than offset, so paging is expressed as a sequence of date windows.
+
+	var all []interface{}
+	p := &intuit.Pager{
+		Chunker: intuit.DayChunker(30),
+		Fetch: func(c intuit.Chunk) (interface{}, error) {
+			return intuit.Do(intuit.GET, "accounts/1/transactions", nil, map[string]string{
+				"txnStartDate": intuit.FormatQueryDate(c.Start),
+				"txnEndDate":   intuit.FormatQueryDate(c.End),
+			}, nil)
+		},
+		Accumulate: func(c intuit.Chunk, page interface{}) error {
+			all = append(all, page)
+			return nil
+		},
+	}
+	err := p.Run(start, end)
+*/
+type Pager struct {
+	// Split the range into chunks. Defaults to a single chunk covering the range.
+	Chunker Chunker
+
+	// Fetch a single chunk.
+	Fetch func(chunk Chunk) (interface{}, error)
+
+	// Merge a fetched page into the caller's result.
+	Accumulate func(chunk Chunk, page interface{}) error
+}
+
+/*
+Fetch every chunk of the range in order, stopping at the first error.
+*/
+func (p *Pager) Run(start time.Time, end time.Time) error {
+	chunker := p.Chunker
+	if chunker == nil {
+		chunker = func(start time.Time, end time.Time) []Chunk {
+			return []Chunk{{Start: start, End: end}}
+		}
+	}
+
+	for _, chunk := range chunker(start, end) {
+		page, err := p.Fetch(chunk)
+		if err != nil {
+			return err
+		}
+
+		if p.Accumulate != nil {
+			if err = p.Accumulate(chunk, page); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+Return a Chunker splitting a range into consecutive, non-overlapping windows of at most the given number of days. Both ends of each window are inclusive.
+*/
+func DayChunker(days int) Chunker {
+	return func(start time.Time, end time.Time) []Chunk {
+		return splitRange(start, end, func(t time.Time) time.Time {
+			return t.AddDate(0, 0, days)
+		})
+	}
+}
+
+/*
+Return a Chunker splitting a range into calendar months.
+*/
+func MonthChunker() Chunker {
+	return func(start time.Time, end time.Time) []Chunk {
+		return splitRange(start, end, func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		})
+	}
+}
+
+func splitRange(start time.Time, end time.Time, next func(time.Time) time.Time) []Chunk {
+	chunks := make([]Chunk, 0)
+
+	for !start.After(end) {
+		n := next(start)
+		if !n.After(start) {
+			n = start.AddDate(0, 0, 1)
+		}
+
+		chunkEnd := n.AddDate(0, 0, -1)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		chunks = append(chunks, Chunk{Start: start, End: chunkEnd})
+		start = n
+	}
+
+	return chunks
+}
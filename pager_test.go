@@ -0,0 +1,48 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMonthChunker(t *testing.T) {
+	chunks := MonthChunker()(date(2014, 1, 15), date(2014, 3, 10))
+
+	assert.Equal(t, []Chunk{
+		{Start: date(2014, 1, 15), End: date(2014, 1, 31)},
+		{Start: date(2014, 2, 1), End: date(2014, 2, 28)},
+		{Start: date(2014, 3, 1), End: date(2014, 3, 10)},
+	}, chunks)
+}
+
+func TestDayChunker(t *testing.T) {
+	chunks := DayChunker(10)(date(2014, 1, 1), date(2014, 1, 25))
+
+	assert.Equal(t, []Chunk{
+		{Start: date(2014, 1, 1), End: date(2014, 1, 10)},
+		{Start: date(2014, 1, 11), End: date(2014, 1, 20)},
+		{Start: date(2014, 1, 21), End: date(2014, 1, 25)},
+	}, chunks)
+}
+
+func TestPagerRun(t *testing.T) {
+	pages := make([]interface{}, 0)
+	p := &Pager{
+		Chunker: DayChunker(10),
+		Fetch: func(c Chunk) (interface{}, error) {
+			return c.Start.Day(), nil
+		},
+		Accumulate: func(c Chunk, page interface{}) error {
+			pages = append(pages, page)
+			return nil
+		},
+	}
+
+	assert.NoError(t, p.Run(date(2014, 1, 1), date(2014, 1, 25)))
+	assert.Equal(t, []interface{}{1, 11, 21}, pages)
+}
@@ -0,0 +1,131 @@
+package intuit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+PaymentAccountType is the kind of bank account an ACH payment is drawn from.
+*/
+type PaymentAccountType string
+
+const (
+	PaymentChecking PaymentAccountType = "CHECKING"
+	PaymentSavings  PaymentAccountType = "SAVINGS"
+)
+
+/*
+PaymentDetails are the full account and routing numbers needed to initiate an ACH payment from an account.
+*/
+type PaymentDetails struct {
+	AccountNumber string             `json:"accountNumber"`
+	RoutingNumber string             `json:"routingNumber"`
+	Type          PaymentAccountType `json:"type"`
+}
+
+var (
+	ErrPaymentDetailsDisabled = errors.New("intuit: payment details are disabled, set EnablePaymentDetails to expose full account numbers")
+	ErrNoPaymentDetails       = errors.New("intuit: the institution did not return full account and routing numbers")
+)
+
+/*
+Field names under which institutions have been seen returning the routing number.
+*/
+var routingNumberFields = []string{"routingNumber", "bankRoutingNumber", "routingTransitNumber", "bankId"}
+
+/*
+Fetch the payment details of an account. Requires EnablePaymentDetails; see NewPaymentDetails.
+*/
+func AccountPaymentDetails(accountId string) (*PaymentDetails, error) {
+	return defaultClient().AccountPaymentDetails(accountId)
+}
+
+/*
+Fetch the payment details of an account. Requires the client's EnablePaymentDetails; see NewPaymentDetails.
+*/
+func (c *Client) AccountPaymentDetails(accountId string) (*PaymentDetails, error) {
+	if configuration := c.Configuration(); configuration == nil || !configuration.EnablePaymentDetails {
+		return nil, ErrPaymentDetailsDisabled
+	}
+
+	var list struct {
+		Accounts []map[string]interface{} `json:"accounts"`
+	}
+	if err := fetch(c.background(), GET, fmt.Sprintf("accounts/%s", accountId), nil, nil, nil, &list); err != nil {
+		return nil, notFound("account", accountId, err)
+	}
+	if len(list.Accounts) == 0 {
+		return nil, &NotFoundError{Resource: "account", Id: accountId}
+	}
+
+	return newPaymentDetails(list.Accounts[0])
+}
+
+/*
+Extract payment details from a raw account, such as an element of the data returned by Accounts.
+
+Since full account numbers are sensitive, this fails with ErrPaymentDetailsDisabled unless EnablePaymentDetails is set. Only checking, savings and money market accounts with unmasked numbers qualify; any other account fails with ErrNoPaymentDetails.
+*/
+func NewPaymentDetails(data interface{}) (*PaymentDetails, error) {
+	return defaultClient().NewPaymentDetails(data)
+}
+
+/*
+Extract payment details from a raw account. Requires the client's EnablePaymentDetails; see NewPaymentDetails.
+*/
+func (c *Client) NewPaymentDetails(data interface{}) (*PaymentDetails, error) {
+	if configuration := c.Configuration(); configuration == nil || !configuration.EnablePaymentDetails {
+		return nil, ErrPaymentDetailsDisabled
+	}
+
+	return newPaymentDetails(data)
+}
+
+func newPaymentDetails(data interface{}) (*PaymentDetails, error) {
+	account, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, ErrNoPaymentDetails
+	}
+
+	details := &PaymentDetails{AccountNumber: digits(account["accountNumber"])}
+	for _, field := range routingNumberFields {
+		if details.RoutingNumber = digits(account[field]); details.RoutingNumber != "" {
+			break
+		}
+	}
+
+	subtype, _ := account["bankingAccountType"].(string)
+	switch AccountSubtype(strings.ToUpper(subtype)) {
+	case Checking:
+		details.Type = PaymentChecking
+	case Savings, MoneyMarket:
+		details.Type = PaymentSavings
+	}
+
+	if details.AccountNumber == "" || len(details.RoutingNumber) != 9 || details.Type == "" {
+		return nil, ErrNoPaymentDetails
+	}
+
+	return details, nil
+}
+
+/*
+Return a number with spaces and dashes removed, or "" if it is missing or masked.
+*/
+func digits(v interface{}) string {
+	s, ok := v.(string)
+	if !ok {
+		return ""
+	}
+
+	s = strings.NewReplacer(" ", "", "-", "").Replace(s)
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return ""
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,55 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestAccountPaymentDetails(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/1":
+			w.Write([]byte(`{"accounts": [{"accountId": 1, "accountNumber": "1234-5678-90", "routingNumber": "021000021", "bankingAccountType": "checking"}]}`))
+		case "/accounts/2":
+			w.Write([]byte(`{"accounts": [{"accountId": 2, "accountNumber": "xxxxxx7890", "routingNumber": "021000021", "bankingAccountType": "SAVINGS"}]}`))
+		}
+	})
+	defer done()
+
+	_, err := AccountPaymentDetails("1")
+	assert.Equal(t, ErrPaymentDetailsDisabled, err)
+
+	SessionConfiguration.EnablePaymentDetails = true
+	details, err := AccountPaymentDetails("1")
+	assert.NoError(t, err)
+	assert.Equal(t, &PaymentDetails{AccountNumber: "1234567890", RoutingNumber: "021000021", Type: PaymentChecking}, details)
+
+	_, err = AccountPaymentDetails("2")
+	assert.Equal(t, ErrNoPaymentDetails, err)
+
+	details, err = NewPaymentDetails(map[string]interface{}{"accountNumber": "55", "bankId": "011000015", "bankingAccountType": "MONEYMRKT"})
+	assert.NoError(t, err)
+	assert.Equal(t, PaymentSavings, details.Type)
+
+	_, err = NewPaymentDetails(map[string]interface{}{"accountNumber": "55", "routingNumber": "011000015", "creditAccountType": "CREDITCARD"})
+	assert.Equal(t, ErrNoPaymentDetails, err)
+}
+
+func TestClientPaymentDetails(t *testing.T) {
+	previous := SessionConfiguration
+	SessionConfiguration = nil
+	defer func() { SessionConfiguration = previous }()
+
+	account := map[string]interface{}{"accountNumber": "55", "routingNumber": "011000015", "bankingAccountType": "CHECKING"}
+
+	_, err := NewPaymentDetails(account)
+	assert.Equal(t, ErrPaymentDetailsDisabled, err)
+
+	_, err = NewClient(Configuration{}).NewPaymentDetails(account)
+	assert.Equal(t, ErrPaymentDetailsDisabled, err)
+
+	details, err := NewClient(Configuration{EnablePaymentDetails: true}).NewPaymentDetails(account)
+	assert.NoError(t, err)
+	assert.Equal(t, PaymentChecking, details.Type)
+}
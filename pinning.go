@@ -0,0 +1,138 @@
+package intuit
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// Host serving the CAD API.
+	APIHost = "financialdatafeed.platform.intuit.com"
+
+	// Host serving the SAML token exchange.
+	OAuthHost = "oauth.intuit.com"
+)
+
+/*
+PinError is returned when a pinned host presents a certificate chain containing none of its pinned keys. This usually means the connection is being intercepted, or that Intuit has rotated to a key which is not yet pinned.
+*/
+type PinError struct {
+	Host string
+
+	// Pins of the keys in the chain which was presented, leaf first.
+	Presented []string
+}
+
+func (e *PinError) Error() string {
+	return fmt.Sprintf("intuit: certificate for %s matches none of its pinned keys (presented %s)", e.Host, strings.Join(e.Presented, ", "))
+}
+
+/*
+Return the pin of a certificate's public key, the base64 SHA-256 hash of its SubjectPublicKeyInfo in the "sha256/..." form used by Configuration.Pins.
+
+The pin for a host's current key can be computed with:
+
+	openssl s_client -connect financialdatafeed.platform.intuit.com:443 </dev/null | openssl x509 -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64
+*/
+func SPKIPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return "sha256/" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+/*
+Check the chains presented by pinned hosts, matched by server name. Hosts without pins, and connections by IP address, which carry no server name, are only subject to the usual verification.
+*/
+func verifyPins(pins map[string][]string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		host := strings.ToLower(cs.ServerName)
+		want := pins[host]
+		if len(want) == 0 {
+			return nil
+		}
+
+		chains := cs.VerifiedChains
+		if len(chains) == 0 {
+			chains = [][]*x509.Certificate{cs.PeerCertificates}
+		}
+
+		presented := make([]string, 0)
+		for _, chain := range chains {
+			for _, cert := range chain {
+				pin := SPKIPin(cert)
+				for _, w := range want {
+					if pin == "sha256/"+strings.TrimPrefix(w, "sha256/") {
+						return nil
+					}
+				}
+				presented = append(presented, pin)
+			}
+		}
+
+		return &PinError{Host: host, Presented: presented}
+	}
+}
+
+/*
+A RoundTripper failing every request, for when pinning cannot be applied.
+*/
+type errorTransport struct {
+	err error
+}
+
+func (t errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+var pinMutex sync.Mutex
+
+/*
+Return a copy of the configured client whose transport enforces Pins, built once per configuration.
+*/
+func (c *Configuration) pinnedClient(client *http.Client) *http.Client {
+	pinMutex.Lock()
+	defer pinMutex.Unlock()
+
+	if c.pinned != nil {
+		return c.pinned
+	}
+
+	pins := make(map[string][]string)
+	for host, p := range c.Pins {
+		pins[strings.ToLower(host)] = p
+	}
+
+	pinned := *client
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if t, ok := base.(*http.Transport); ok {
+		t = t.Clone()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		verify := t.TLSClientConfig.VerifyConnection
+		t.TLSClientConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			if verify != nil {
+				if err := verify(cs); err != nil {
+					return err
+				}
+			}
+			return verifyPins(pins)(cs)
+		}
+		pinned.Transport = t
+	} else {
+		// Pins can only be enforced on an *http.Transport; failing closed is safer than silently not pinning.
+		pinned.Transport = errorTransport{fmt.Errorf("intuit: certificate pinning requires an *http.Transport, not %T", base)}
+	}
+
+	c.pinned = &pinned
+	return c.pinned
+}
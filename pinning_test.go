@@ -0,0 +1,62 @@
+package intuit
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPins(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	previous := SessionConfiguration
+	defer func() { SessionConfiguration = previous }()
+
+	// Pins are matched by server name, which is not sent for IP addresses; the test certificate is also valid for example.com.
+	client := server.Client()
+	client.Transport.(*http.Transport).TLSClientConfig.ServerName = "example.com"
+	pin := SPKIPin(server.Certificate())
+	configure := func(pins ...string) {
+		Configure(&Configuration{
+			BaseURL:    server.URL + "/",
+			HTTPClient: client,
+			Pins:       map[string][]string{"Example.com": pins},
+			tokens:     map[string]*AccessToken{"": {Token: "token", Secret: "secret"}},
+		})
+	}
+
+	// The current key is accepted alongside the next one during rotation.
+	configure("sha256/bmV4dCBrZXk=", pin)
+	_, err := Do(GET, "accounts", nil, nil, nil)
+	assert.NoError(t, err)
+
+	configure("sha256/bmV4dCBrZXk=")
+	_, err = Do(GET, "accounts", nil, nil, nil)
+	var pinErr *PinError
+	assert.True(t, errors.As(err, &pinErr))
+	assert.Equal(t, "example.com", pinErr.Host)
+	assert.Equal(t, []string{pin}, pinErr.Presented)
+
+	// Unpinned hosts are unaffected.
+	SessionConfiguration.Pins = map[string][]string{APIHost: {"sha256/bmV4dCBrZXk="}}
+	SessionConfiguration.pinned = nil
+	_, err = Do(GET, "accounts", nil, nil, nil)
+	assert.NoError(t, err)
+
+	// A client copied from a configuration which has pinned connections enforces its own pins.
+	configure(pin)
+	_, err = Do(GET, "accounts", nil, nil, nil)
+	assert.NoError(t, err)
+
+	other := *SessionConfiguration
+	other.Pins = map[string][]string{"example.com": {"sha256/bmV4dCBrZXk="}}
+	c := NewClient(other)
+	c.configuration.setToken("", &AccessToken{Token: "token", Secret: "secret"})
+	_, err = c.Do(GET, "accounts", nil, nil, nil)
+	assert.True(t, errors.As(err, &pinErr))
+}
@@ -0,0 +1,56 @@
+package intuit
+
+import "sync"
+
+/*
+PopularInstitution is an entry in the curated shortlist of institutions, enough to render an institution picker and log in without fetching the full institution list.
+*/
+type PopularInstitution struct {
+	InstitutionId   string
+	InstitutionName string
+	HomeURL         string
+	Keys            CredentialKeys
+}
+
+var (
+	popularMutex sync.RWMutex
+
+	// Only the test institution ships, as the Ids of others are specific to each Intuit environment.
+	popularInstitutions = []PopularInstitution{
+		popular(TestInstitutionId, "DAG Site", "http://www.intuit.com"),
+	}
+)
+
+func popular(institutionId string, name string, homeURL string) PopularInstitution {
+	return PopularInstitution{InstitutionId: institutionId, InstitutionName: name, HomeURL: homeURL, Keys: credentialKeys[institutionId]}
+}
+
+/*
+Return the curated shortlist of institutions, most popular first. Until SetPopularInstitutions is called it holds only the test institution.
+*/
+func PopularInstitutions() []PopularInstitution {
+	popularMutex.RLock()
+	defer popularMutex.RUnlock()
+
+	list := make([]PopularInstitution, len(popularInstitutions))
+	copy(list, popularInstitutions)
+	return list
+}
+
+/*
+Replace the shortlist returned by PopularInstitutions, most popular first, registering each institution's credential keys.
+
+Institution Ids are specific to each Intuit environment; applications using another environment, or wanting a different selection, install their own list at startup.
+*/
+func SetPopularInstitutions(list []PopularInstitution) {
+	popularMutex.Lock()
+	popularInstitutions = make([]PopularInstitution, len(list))
+	copy(popularInstitutions, list)
+	popularMutex.Unlock()
+
+	for _, p := range list {
+		if p.Keys.Username != "" && p.Keys.Password != "" {
+			RegisterCredentialKeys(p.InstitutionId, p.Keys)
+		}
+	}
+}
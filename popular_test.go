@@ -0,0 +1,49 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPopularInstitutions(t *testing.T) {
+	previous := PopularInstitutions()
+	credentialKeysMutex.RLock()
+	registered := make(map[string]CredentialKeys, len(credentialKeys))
+	for id, keys := range credentialKeys {
+		registered[id] = keys
+	}
+	credentialKeysMutex.RUnlock()
+	t.Cleanup(func() {
+		popularMutex.Lock()
+		popularInstitutions = previous
+		popularMutex.Unlock()
+
+		credentialKeysMutex.Lock()
+		credentialKeys = registered
+		credentialKeysMutex.Unlock()
+	})
+
+	assert.Equal(t, "DAG Site", previous[0].InstitutionName)
+	assert.Equal(t, "Banking Userid", previous[0].Keys.Username)
+	for _, p := range previous {
+		keys, ok := CredentialKeysFor(p.InstitutionId)
+		assert.True(t, ok, p.InstitutionName)
+		assert.Equal(t, keys, p.Keys)
+	}
+
+	SetPopularInstitutions([]PopularInstitution{
+		{InstitutionId: "1", InstitutionName: "First", Keys: CredentialKeys{Username: "User", Password: "Pass"}},
+		{InstitutionId: "2", InstitutionName: "Second"},
+	})
+
+	list := PopularInstitutions()
+	assert.Equal(t, "First", list[0].InstitutionName)
+	assert.Equal(t, 2, len(list))
+
+	list[0].InstitutionName = "Changed"
+	assert.Equal(t, "First", PopularInstitutions()[0].InstitutionName)
+
+	keys, ok := CredentialKeysFor("1")
+	assert.True(t, ok)
+	assert.Equal(t, "User", keys.Username)
+}
@@ -0,0 +1,71 @@
+package intuit
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/*
+Position is a holding of an investment account, such as a stock or fund.
+*/
+type Position struct {
+	Id           json.Number `json:"investmentPositionId"`
+	Symbol       string      `json:"symbol"`
+	Description  string      `json:"description"`
+	CurrencyCode string      `json:"currencyCode"`
+
+	// Kind of security, such as "STOCK", "MUTUALFUND" or "OPTION", and whether it is held long or short.
+	PositionType string `json:"positionType,omitempty"`
+	HoldType     string `json:"holdType,omitempty"`
+
+	Units       float64 `json:"units"`
+	Price       Amount  `json:"currentPrice"`
+	PriceDate   Date    `json:"currentPriceDate"`
+	MarketValue Amount  `json:"marketValue"`
+	CostBasis   Amount  `json:"costBasis"`
+
+	// Today's change in value, as an amount and a percentage.
+	DailyChange   Amount  `json:"dailyChange,omitempty"`
+	ChangePercent float64 `json:"changePercent,omitempty"`
+
+	IntuitTid string `json:"-"`
+}
+
+/*
+Return the position's gain or loss against its cost basis.
+*/
+func (p Position) Gain() Amount {
+	return roundCents(p.MarketValue - p.CostBasis)
+}
+
+type positionList struct {
+	Positions []Position `json:"positions"`
+}
+
+func (l *positionList) setIntuitTid(tid string) {
+	for i := range l.Positions {
+		l.Positions[i].IntuitTid = tid
+	}
+}
+
+/*
+Return the positions held in an investment account. A NotFoundError is returned for an account which does not exist or has been deleted.
+*/
+func Positions(accountId string) ([]Position, error) {
+	return defaultClient().Positions(accountId)
+}
+
+/*
+Return the positions held in an investment account. See Positions.
+*/
+func (c *Client) Positions(accountId string) ([]Position, error) {
+	list, err := Get[*positionList](c.background(), fmt.Sprintf("accounts/%s/positions", accountId), nil)
+	if err != nil {
+		return nil, notFound("account", accountId, err)
+	}
+	if list.Positions == nil {
+		return make([]Position, 0), nil
+	}
+
+	return list.Positions, nil
+}
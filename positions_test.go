@@ -0,0 +1,44 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestPositions(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/400/positions":
+			w.Write([]byte(`{"positions": [{"investmentPositionId": 1, "symbol": "ACME", "description": "Acme Corp", "currencyCode": "USD",
+				"positionType": "STOCK", "holdType": "LONG", "units": 10, "currentPrice": 12.5, "currentPriceDate": "2026-10-01",
+				"marketValue": 125, "costBasis": 100.4, "dailyChange": -1.25, "changePercent": -0.99}]}`))
+		case "/accounts/401/positions":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer done()
+
+	positions, err := Positions("400")
+	assert.NoError(t, err)
+	if assert.Len(t, positions, 1) {
+		p := positions[0]
+		assert.Equal(t, "ACME", p.Symbol)
+		assert.Equal(t, "STOCK", p.PositionType)
+		assert.Equal(t, 10.0, p.Units)
+		assert.Equal(t, Amount(12.5), p.Price)
+		assert.Equal(t, Amount(125), p.MarketValue)
+		assert.Equal(t, Amount(100.4), p.CostBasis)
+		assert.Equal(t, Amount(24.6), p.Gain())
+		assert.Equal(t, 2026, p.PriceDate.Year())
+	}
+
+	positions, err = Positions("401")
+	assert.NoError(t, err)
+	assert.Empty(t, positions)
+
+	_, err = Positions("402")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
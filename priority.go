@@ -0,0 +1,213 @@
+package intuit
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+Priority is the class of a request, deciding its share of the slots of PriorityMiddleware.
+*/
+type Priority int
+
+const (
+	// Calls made while a user waits, such as MFA responses and adding logins.
+	PriorityInteractive Priority = iota
+
+	// Calls with no priority set.
+	PriorityNormal
+
+	// Batch work such as backfills, which should yield to everything else.
+	PriorityBackground
+)
+
+/*
+Default share of slots for each class. Of every 13 slots freed while all classes are waiting, interactive requests get 8, normal 4 and background 1.
+*/
+var DefaultPriorityWeights = map[Priority]int{
+	PriorityInteractive: 8,
+	PriorityNormal:      4,
+	PriorityBackground:  1,
+}
+
+type priorityKey struct{}
+
+/*
+Return a context whose requests are dispatched with the given priority by PriorityMiddleware.
+*/
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+/*
+Return the priority set with WithPriority, if any.
+*/
+func PriorityFromContext(ctx context.Context) (Priority, bool) {
+	p, ok := ctx.Value(priorityKey{}).(Priority)
+	return p, ok
+}
+
+/*
+Return the priority of a request: the one set on its context, otherwise interactive for MFA responses and new logins, which a user is waiting on, and normal for everything else.
+*/
+func requestPriority(req *Request) Priority {
+	if p, ok := PriorityFromContext(req.Context); ok {
+		return p
+	}
+
+	if len(req.Header["challengeSessionId"]) > 0 {
+		return PriorityInteractive
+	}
+	if req.Method == POST && strings.HasPrefix(req.Endpoint, "institutions/") && strings.HasSuffix(req.Endpoint, "/logins") {
+		return PriorityInteractive
+	}
+
+	return PriorityNormal
+}
+
+/*
+A request waiting for a slot.
+*/
+type priorityWaiter struct {
+	ready chan struct{}
+}
+
+/*
+A dispatch queue admitting at most a fixed number of requests at once and handing freed slots to waiting classes by smooth weighted round robin.
+*/
+type priorityQueue struct {
+	mutex   sync.Mutex
+	free    int
+	weights map[Priority]int
+	current map[Priority]int
+	waiting map[Priority][]*priorityWaiter
+}
+
+func (q *priorityQueue) acquire(ctx context.Context, p Priority) error {
+	q.mutex.Lock()
+	if q.free > 0 && q.queued() == 0 {
+		q.free--
+		q.mutex.Unlock()
+		return nil
+	}
+
+	w := &priorityWaiter{ready: make(chan struct{})}
+	q.waiting[p] = append(q.waiting[p], w)
+	q.mutex.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		q.mutex.Lock()
+		defer q.mutex.Unlock()
+
+		for i, other := range q.waiting[p] {
+			if other == w {
+				q.waiting[p] = append(q.waiting[p][:i], q.waiting[p][i+1:]...)
+				return ctx.Err()
+			}
+		}
+
+		// The slot was handed over as the context ended; pass it on.
+		q.dispatch()
+		return ctx.Err()
+	}
+}
+
+func (q *priorityQueue) release() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.dispatch()
+}
+
+func (q *priorityQueue) queued() int {
+	n := 0
+	for _, w := range q.waiting {
+		n += len(w)
+	}
+
+	return n
+}
+
+/*
+Hand a freed slot to the next waiter, or return it to the pool. Must be called with the mutex held.
+*/
+func (q *priorityQueue) dispatch() {
+	var (
+		next  Priority
+		found bool
+		total int
+	)
+	classes := make([]Priority, 0, len(q.waiting))
+	for p, w := range q.waiting {
+		if len(w) > 0 {
+			classes = append(classes, p)
+		} else {
+			// Classes only accumulate credit while they wait.
+			q.current[p] = 0
+		}
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i] < classes[j] })
+
+	for _, p := range classes {
+		weight := q.weights[p]
+		if weight < 1 {
+			weight = 1
+		}
+		q.current[p] += weight
+		total += weight
+		if !found || q.current[p] > q.current[next] {
+			next, found = p, true
+		}
+	}
+
+	if !found {
+		q.free++
+		return
+	}
+
+	q.current[next] -= total
+	w := q.waiting[next][0]
+	q.waiting[next] = q.waiting[next][1:]
+	close(w.ready)
+}
+
+/*
+Return middleware admitting at most concurrency requests at a time, so that when the slots are busy, waiting interactive requests are dispatched ahead of background work such as backfills rather than queueing behind it.
+
+Freed slots are shared between the waiting classes in proportion to weights, which defaults to DefaultPriorityWeights; classes missing from weights get a weight of one. A request's class is set with WithPriority; otherwise MFA responses and new logins are interactive and everything else is normal. Place it after RetryMiddleware so that each attempt waits its turn.
+*/
+func PriorityMiddleware(concurrency int, weights map[Priority]int) Middleware {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if weights == nil {
+		weights = DefaultPriorityWeights
+	}
+
+	q := &priorityQueue{
+		free:    concurrency,
+		weights: make(map[Priority]int),
+		current: make(map[Priority]int),
+		waiting: make(map[Priority][]*priorityWaiter),
+	}
+	for p, w := range weights {
+		q.weights[p] = w
+	}
+
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			if err := q.acquire(req.Context, requestPriority(req)); err != nil {
+				return nil, &TransportError{Method: req.Method, Endpoint: req.Endpoint, Err: err, RequestId: req.RequestId}
+			}
+			defer q.release()
+
+			return next(req)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package intuit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPriorityMiddleware(t *testing.T) {
+	var (
+		mutex sync.Mutex
+		order []string
+	)
+	release := make(chan struct{})
+	h := PriorityMiddleware(1, nil)(func(req *Request) (*http.Response, error) {
+		if req.Endpoint == "first" {
+			<-release
+		}
+		mutex.Lock()
+		order = append(order, req.Endpoint)
+		mutex.Unlock()
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	var wg sync.WaitGroup
+	run := func(ctx context.Context, endpoint string, headers map[string][]string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h(&Request{Context: ctx, Method: GET, Endpoint: endpoint, Header: headers})
+		}()
+		// Let the request reach the queue before the next is made.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	background := WithPriority(context.Background(), PriorityBackground)
+	run(background, "first", nil)
+	run(background, "backfill 1", nil)
+	run(background, "backfill 2", nil)
+	run(context.Background(), "accounts", nil)
+	run(context.Background(), "mfa", map[string][]string{"challengeSessionId": {"1"}})
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []string{"first", "mfa", "accounts", "backfill 1", "backfill 2"}, order)
+}
+
+func TestPriorityMiddlewareCancel(t *testing.T) {
+	release := make(chan struct{})
+	h := PriorityMiddleware(1, nil)(func(req *Request) (*http.Response, error) {
+		<-release
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	go h(&Request{Context: context.Background(), Method: GET, Endpoint: "first"})
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := h(&Request{Context: ctx, Method: GET, Endpoint: "second"})
+	_, ok := err.(*TransportError)
+	assert.True(t, ok)
+
+	// The abandoned request does not hold up those behind it.
+	close(release)
+	_, err = h(&Request{Context: context.Background(), Method: GET, Endpoint: "third"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,91 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"strconv"
+	"strings"
+)
+
+/*
+Prompt on the terminal for an answer to each challenge in the session.
+*/
+func Challenges(session *intuit.ChallengeSession) error {
+	return New().Challenges(session)
+}
+
+/*
+Prompt for an answer to each challenge in the session, storing them in session.Answers ready for Respond.
+*/
+func (p *Prompter) Challenges(session *intuit.ChallengeSession) error {
+	return intuit.AnswerChallenges(context.Background(), session, p)
+}
+
+/*
+The Prompter is an intuit.ChallengeRenderer, prompting for every kind of challenge with Challenge.
+*/
+func (p *Prompter) RenderText(ctx context.Context, c intuit.Challenge) (intuit.Answer, error) {
+	return p.Challenge(c)
+}
+
+func (p *Prompter) RenderChoices(ctx context.Context, c intuit.Challenge) (intuit.Answer, error) {
+	return p.Challenge(c)
+}
+
+func (p *Prompter) RenderImage(ctx context.Context, c intuit.Challenge) (intuit.Answer, error) {
+	return p.Challenge(c)
+}
+
+/*
+Prompt for the answer to a single challenge. Image prompts are shown with ShowImage, and choices are listed and selected by number.
+*/
+func (p *Prompter) Challenge(c intuit.Challenge) (intuit.Answer, error) {
+	if len(c.Image) > 0 {
+		show := p.ShowImage
+		if show == nil {
+			show = p.showImage
+		}
+		if err := show(c.Image); err != nil {
+			return intuit.Answer{}, err
+		}
+	}
+
+	if c.Question != "" {
+		fmt.Fprintln(p.Out, c.Question)
+	}
+
+	if c.Kind() != intuit.ChoiceChallenge {
+		for {
+			fmt.Fprint(p.Out, "Answer: ")
+			value, err := p.readLine()
+			if err != nil {
+				return intuit.Answer{}, err
+			}
+
+			answer := intuit.TextAnswer(value)
+			if c.Validate(answer) == nil {
+				return answer, nil
+			}
+			fmt.Fprintln(p.Out, "An answer is required.")
+		}
+	}
+
+	for i, choice := range c.Choices {
+		fmt.Fprintf(p.Out, "  %d) %s\n", i+1, choice.Text)
+	}
+
+	for {
+		fmt.Fprintf(p.Out, "Choice [1-%d]: ", len(c.Choices))
+		value, err := p.readLine()
+		if err != nil {
+			return intuit.Answer{}, err
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err == nil && n >= 1 && n <= len(c.Choices) {
+			return intuit.ChoiceAnswer(c.Choices[n-1]), nil
+		}
+		fmt.Fprintf(p.Out, "Enter a number from 1 to %d.\n", len(c.Choices))
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package prompt
+
+import (
+	"os"
+)
+
+/*
+Terminal echo cannot be disabled on this platform, so the line is read normally.
+*/
+func readMasked(tty *os.File, readLine func() (string, error)) (string, error) {
+	return readLine()
+}
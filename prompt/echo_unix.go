@@ -0,0 +1,27 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package prompt
+
+import (
+	"os"
+	"os/exec"
+)
+
+/*
+Read a line with terminal echo disabled. If echo cannot be disabled, such as when input is not a terminal, the line is read normally.
+*/
+func readMasked(tty *os.File, readLine func() (string, error)) (string, error) {
+	if err := stty(tty, "-echo"); err != nil {
+		return readLine()
+	}
+	defer stty(tty, "echo")
+
+	return readLine()
+}
+
+func stty(tty *os.File, arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = tty
+	return cmd.Run()
+}
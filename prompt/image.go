@@ -0,0 +1,113 @@
+package prompt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+/*
+Show an image challenge on Out. Terminals speaking the iTerm2 or kitty inline image protocols display it in place; otherwise it is saved to a temporary file whose path is printed.
+*/
+func (p *Prompter) showImage(b []byte) error {
+	switch imageProtocol() {
+	case "iterm":
+		return writeITermImage(p.Out, b)
+	case "kitty":
+		return writeKittyImage(p.Out, b)
+	}
+
+	f, err := ioutil.TempFile("", "intuit-challenge-*"+imageExtension(b))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err = f.Write(b); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.Out, "The challenge image has been saved to %s\n", f.Name())
+	return nil
+}
+
+func imageProtocol() string {
+	if os.Getenv("TERM_PROGRAM") == "iTerm.app" || os.Getenv("LC_TERMINAL") == "iTerm2" {
+		return "iterm"
+	}
+	if os.Getenv("KITTY_WINDOW_ID") != "" || strings.Contains(os.Getenv("TERM"), "kitty") {
+		return "kitty"
+	}
+
+	return ""
+}
+
+func writeITermImage(w io.Writer, b []byte) error {
+	_, err := fmt.Fprintf(w, "\x1b]1337;File=inline=1;size=%d:%s\a\n", len(b), base64.StdEncoding.EncodeToString(b))
+	return err
+}
+
+/*
+Write an image with the kitty graphics protocol, which only accepts PNG, in the chunks of at most 4096 base64 bytes it requires.
+*/
+func writeKittyImage(w io.Writer, b []byte) error {
+	if http.DetectContentType(b) != "image/png" {
+		img, _, err := image.Decode(bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err = png.Encode(&buf, img); err != nil {
+			return err
+		}
+		b = buf.Bytes()
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(b)
+	for first := true; first || encoded != ""; first = false {
+		chunk := encoded
+		if len(chunk) > 4096 {
+			chunk = chunk[:4096]
+		}
+		encoded = encoded[len(chunk):]
+
+		more := 0
+		if encoded != "" {
+			more = 1
+		}
+
+		control := fmt.Sprintf("m=%d", more)
+		if first {
+			control = "a=T,f=100," + control
+		}
+		if _, err := fmt.Fprintf(w, "\x1b_G%s;%s\x1b\\", control, chunk); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func imageExtension(b []byte) string {
+	switch http.DetectContentType(b) {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	}
+
+	return ""
+}
@@ -0,0 +1,132 @@
+/*
+Interactive credential prompts for terminal applications.
+*/
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Prompter asks for credential values and challenge answers on Out and reads them from In. Masked fields are read with ReadSecret, which defaults to reading from the terminal with echo disabled.
+*/
+type Prompter struct {
+	In         io.Reader
+	Out        io.Writer
+	ReadSecret func() (string, error)
+
+	// Shows an image challenge. Defaults to displaying it inline where the terminal supports it.
+	ShowImage func(image []byte) error
+
+	reader *bufio.Reader
+}
+
+/*
+Return a Prompter reading from standard input and writing to standard output.
+*/
+func New() *Prompter {
+	p := &Prompter{In: os.Stdin, Out: os.Stdout}
+	p.ReadSecret = func() (string, error) {
+		return readMasked(os.Stdin, p.readLine)
+	}
+
+	return p
+}
+
+/*
+Prompt on the terminal for each of the institution's credential fields.
+*/
+func Credentials(institution *intuit.InstitutionDetails) ([]intuit.Credential, error) {
+	return New().Credentials(institution)
+}
+
+/*
+Prompt for each displayed credential field of the institution in display order, masking input for masked fields and re-prompting until each value satisfies the field's length limits.
+*/
+func (p *Prompter) Credentials(institution *intuit.InstitutionDetails) ([]intuit.Credential, error) {
+	credentials := make([]intuit.Credential, 0, len(institution.Keys))
+
+	for _, key := range institution.Keys {
+		if !key.DisplayFlag {
+			continue
+		}
+
+		value, err := p.Field(key)
+		if err != nil {
+			return nil, err
+		}
+
+		credentials = append(credentials, intuit.Credential{Name: key.Name, Value: value})
+	}
+
+	return credentials, nil
+}
+
+/*
+Prompt for a single credential field.
+*/
+func (p *Prompter) Field(key intuit.InstitutionKey) (string, error) {
+	label := key.Description
+	if label == "" {
+		label = key.Name
+	}
+
+	if key.Instructions != "" {
+		fmt.Fprintln(p.Out, key.Instructions)
+	}
+
+	for {
+		fmt.Fprintf(p.Out, "%s: ", label)
+
+		var value string
+		var err error
+		if key.Mask && p.ReadSecret != nil {
+			value, err = p.ReadSecret()
+			fmt.Fprintln(p.Out)
+		} else {
+			value, err = p.readLine()
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if problem := checkLength(key, value); problem != "" {
+			fmt.Fprintln(p.Out, problem)
+			continue
+		}
+
+		return value, nil
+	}
+}
+
+func (p *Prompter) readLine() (string, error) {
+	if p.reader == nil {
+		p.reader = bufio.NewReader(p.In)
+	}
+
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func checkLength(key intuit.InstitutionKey, value string) string {
+	n := utf8.RuneCountInString(value)
+
+	if n == 0 || n < key.ValueLengthMin {
+		return fmt.Sprintf("Must be at least %d characters.", max(key.ValueLengthMin, 1))
+	}
+	if key.ValueLengthMax > 0 && n > key.ValueLengthMax {
+		return fmt.Sprintf("Must be at most %d characters.", key.ValueLengthMax)
+	}
+
+	return ""
+}
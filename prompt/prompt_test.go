@@ -0,0 +1,56 @@
+package prompt
+
+import (
+	"bytes"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCredentials(t *testing.T) {
+	institution := &intuit.InstitutionDetails{Keys: intuit.InstitutionKeys{
+		{Name: "Banking Userid", Description: "User ID", DisplayFlag: true, DisplayOrder: 1, ValueLengthMin: 3},
+		{Name: "Banking Password", Description: "Password", DisplayFlag: true, DisplayOrder: 2, Mask: true},
+		{Name: "Hidden", DisplayFlag: false, DisplayOrder: 3},
+	}}
+
+	var out bytes.Buffer
+	secrets := 0
+	p := &Prompter{In: strings.NewReader("ab\nalice\n"), Out: &out}
+	p.ReadSecret = func() (string, error) {
+		secrets++
+		return "hunter2", nil
+	}
+
+	credentials, err := p.Credentials(institution)
+	assert.NoError(t, err)
+	assert.Equal(t, []intuit.Credential{
+		{Name: "Banking Userid", Value: "alice"},
+		{Name: "Banking Password", Value: "hunter2"},
+	}, credentials)
+	assert.Equal(t, 1, secrets)
+	assert.Contains(t, out.String(), "Must be at least 3 characters.")
+}
+
+func TestChallenges(t *testing.T) {
+	session := &intuit.ChallengeSession{Challenges: []intuit.Challenge{
+		{Question: "Favorite color?"},
+		{Question: "Pick a city", Choices: []intuit.Choice{{Value: "1", Text: "Paris"}, {Value: "2", Text: "Rome"}}},
+		{Image: []byte("\x89PNG\r\n\x1a\n")},
+	}}
+
+	var out bytes.Buffer
+	var shown []byte
+	p := &Prompter{In: strings.NewReader("\nblue\n3\n2\nA7X\n"), Out: &out}
+	p.ShowImage = func(image []byte) error {
+		shown = image
+		return nil
+	}
+
+	assert.NoError(t, p.Challenges(session))
+	assert.Equal(t, []intuit.Answer{intuit.TextAnswer("blue"), {Value: "2"}, intuit.TextAnswer("A7X")}, session.Answers)
+	assert.Equal(t, session.Challenges[2].Image, shown)
+	assert.Contains(t, out.String(), "  2) Rome")
+	assert.Contains(t, out.String(), "Enter a number from 1 to 2.")
+}
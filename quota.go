@@ -0,0 +1,92 @@
+package intuit
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*
+Returned by QuotaMiddleware when the customer has used up the day's calls.
+*/
+var ErrQuotaExceeded = errors.New("intuit: daily call quota exceeded")
+
+/*
+Counts are kept a little longer than the day they cover, so usage can still be read shortly after midnight.
+*/
+const quotaTTL = 48 * time.Hour
+
+// Serializes updates to the counts of all configurations within the process.
+var quotaMutex sync.Mutex
+
+func (c *Configuration) quotaKey(customerId string, day time.Time) string {
+	return "quota/" + c.consumerKey() + "/" + customerId + "/" + day.UTC().Format("2006-01-02")
+}
+
+/*
+Return the number of calls made for a customer on the UTC day of t, as counted by QuotaMiddleware in the configured KV.
+*/
+func QuotaUsage(customerId string, t time.Time) (int, error) {
+	return defaultClient().QuotaUsage(customerId, t)
+}
+
+/*
+Return the number of calls made for a customer on the UTC day of t. See QuotaUsage.
+*/
+func (c *Client) QuotaUsage(customerId string, t time.Time) (int, error) {
+	configuration := c.Configuration()
+	if configuration == nil || configuration.kv() == nil {
+		return 0, errors.New("intuit: reading quota usage: no KV configured")
+	}
+
+	return quotaCount(configuration.kv(), configuration.quotaKey(customerId, t))
+}
+
+func quotaCount(kv KV, key string) (int, error) {
+	b, err := kv.Get(key)
+	if err == ErrKeyNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(string(b))
+}
+
+/*
+Return middleware counting each customer's calls per UTC day in the configured KV, so that usage against Intuit's quotas is tracked across restarts and, with a shared store, across instances. Once a customer has made limit calls in a day, further calls fail with ErrQuotaExceeded without being sent; a limit of zero only counts.
+
+Counts are updated with a read followed by a write, so instances sharing a store may undercount calls made at the same moment. Calls are passed through uncounted when no KV is configured.
+*/
+func QuotaMiddleware(limit int) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			configuration := configurationFor(req.Context)
+			kv := configuration.kv()
+			if kv == nil {
+				return next(req)
+			}
+
+			key := configuration.quotaKey(customerFor(req.Context), time.Now())
+
+			quotaMutex.Lock()
+			count, err := quotaCount(kv, key)
+			if err == nil && limit > 0 && count >= limit {
+				quotaMutex.Unlock()
+				return nil, ErrQuotaExceeded
+			}
+			if err == nil {
+				err = kv.Set(key, []byte(strconv.Itoa(count+1)), quotaTTL)
+			}
+			quotaMutex.Unlock()
+
+			if err != nil {
+				configuration.logf("intuit: warning: counting call for quota: %v", err)
+			}
+
+			return next(req)
+		}
+	}
+}
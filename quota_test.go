@@ -0,0 +1,49 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQuotaMiddleware(t *testing.T) {
+	var requests int32
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer done()
+
+	kv := NewMemoryKV()
+	SessionConfiguration.KV = kv
+	SessionConfiguration.Middleware = []Middleware{QuotaMiddleware(2)}
+
+	assert.NoError(t, DeleteAccount("1"))
+	assert.NoError(t, DeleteAccount("2"))
+	assert.Equal(t, ErrQuotaExceeded, DeleteAccount("3"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	usage, err := QuotaUsage("", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, usage)
+
+	// Counts are kept in the KV, so another configuration for the application sharing it sees them.
+	other := NewClient(Configuration{OAuthConsumerKey: SessionConfiguration.OAuthConsumerKey, KV: kv})
+	usage, err = other.QuotaUsage("", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, usage)
+
+	// Another application's customers are counted separately.
+	usage, err = NewClient(Configuration{OAuthConsumerKey: "other", KV: kv}).QuotaUsage("", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, usage)
+
+	usage, err = QuotaUsage("", time.Now().AddDate(0, 0, -1))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, usage)
+
+	_, err = NewClient(Configuration{}).QuotaUsage("", time.Now())
+	assert.Error(t, err)
+}
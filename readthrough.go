@@ -0,0 +1,80 @@
+package intuit
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Guards the lazy creation of each configuration's read-through cache.
+var readsMutex sync.Mutex
+
+/*
+Return the configuration's read-through account cache, or nil when ReadThroughAccounts is off.
+*/
+func (c *Configuration) readCache() *cache {
+	if c == nil || !c.ReadThroughAccounts {
+		return nil
+	}
+
+	readsMutex.Lock()
+	defer readsMutex.Unlock()
+
+	if c.reads == nil {
+		c.reads = newCache()
+	}
+	return c.reads
+}
+
+/*
+Drop a customer's cached accounts.
+*/
+func (c *Configuration) invalidateAccounts(customerId string) {
+	reads := c.readCache()
+	if reads == nil {
+		return
+	}
+
+	prefix := reads.scope(c, customerId+"/")
+
+	reads.mutex.Lock()
+	defer reads.mutex.Unlock()
+
+	for k := range reads.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(reads.entries, k)
+		}
+	}
+}
+
+/*
+Return the value for the scoped customer under key from the read-through cache, fetching it when missing or expired, or fetch it directly when the cache is off. Failures are not cached.
+*/
+func (c *Client) readThrough(key string, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	ctx := c.background()
+	configuration := c.Configuration()
+
+	reads := configuration.readCache()
+	if reads == nil {
+		return fetch(ctx)
+	}
+
+	v, _, err := reads.get(configuration, customerFor(ctx)+"/"+key, func() (interface{}, error) {
+		return fetch(ctx)
+	})
+	return v, err
+}
+
+/*
+Drop the scoped customer's accounts from the read-through cache, so the next Accounts or Account call fetches them. Use it after changes made outside the client, such as by another instance. See ReadThroughAccounts.
+*/
+func InvalidateAccounts() {
+	defaultClient().InvalidateAccounts()
+}
+
+/*
+Drop the scoped customer's accounts from the client's read-through cache. See InvalidateAccounts.
+*/
+func (c *Client) InvalidateAccounts() {
+	c.Configuration().invalidateAccounts(customerFor(c.background()))
+}
@@ -0,0 +1,59 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReadThroughAccounts(t *testing.T) {
+	var fetches int32
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == GET {
+			atomic.AddInt32(&fetches, 1)
+			w.Write([]byte(`{"accounts": [{"accountId": 1, "accountNickname": "Checking"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer done()
+
+	// Off by default.
+	Accounts()
+	Accounts()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	SessionConfiguration.ReadThroughAccounts = true
+	atomic.StoreInt32(&fetches, 0)
+
+	accounts, err := Accounts()
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	Accounts()
+	account, err := Account("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Checking", account.Common().AccountNickname)
+	Account("1")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	// Changing the result does not change the cache.
+	account.Common().AccountNickname = "Changed"
+	account, _ = Account("1")
+	assert.Equal(t, "Checking", account.Common().AccountNickname)
+
+	assert.NoError(t, DeleteAccount("2"))
+	Accounts()
+	Account("1")
+	assert.Equal(t, int32(4), atomic.LoadInt32(&fetches))
+
+	InvalidateAccounts()
+	Accounts()
+	assert.Equal(t, int32(5), atomic.LoadInt32(&fetches))
+
+	// Each customer has its own entries.
+	SessionConfiguration.setToken("other", &AccessToken{Token: "token", Secret: "secret"})
+	Scope("other")
+	Accounts()
+	assert.Equal(t, int32(6), atomic.LoadInt32(&fetches))
+}
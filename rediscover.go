@@ -0,0 +1,65 @@
+package intuit
+
+import "fmt"
+
+/*
+RediscoverResult is the outcome of updating a login's credentials followed by a discover pass on the same login.
+*/
+type RediscoverResult struct {
+	// Every account on the login, including those newly found.
+	Accounts []CustomerAccount
+
+	// Accounts found by the discover pass which were not on the login before, such as accounts opened since it was added.
+	New []CustomerAccount
+}
+
+/*
+Update login information like UpdateLoginAccount, then discover accounts again with the same credentials so any opened since the login was added are picked up, reporting which are new.
+
+If either step requires MFA, its challenge session is returned without a result. A challenge raised by the discover pass is answered with RespondToChallenge as for DiscoverAndAddAccounts, which adds the new accounts.
+*/
+func UpdateLoginAccountAndRediscover(loginId string, username string, password string, usernameKey string, passwordKey string) (result *RediscoverResult, challengeSession *ChallengeSession, err error) {
+	return defaultClient().UpdateLoginAccountAndRediscover(loginId, username, password, usernameKey, passwordKey)
+}
+
+/*
+Update login information, then discover accounts again with the same credentials. See UpdateLoginAccountAndRediscover.
+*/
+func (c *Client) UpdateLoginAccountAndRediscover(loginId string, username string, password string, usernameKey string, passwordKey string) (result *RediscoverResult, challengeSession *ChallengeSession, err error) {
+	accounts, challengeSession, err := c.UpdateLoginAccount(loginId, username, password, usernameKey, passwordKey)
+	if err != nil || challengeSession != nil {
+		return
+	}
+
+	if len(accounts) == 0 {
+		return nil, nil, fmt.Errorf("intuit: login %s has no accounts to rediscover from", loginId)
+	}
+
+	institutionId := accounts[0].Common().InstitutionId.String()
+	body, header, challengeSession, err := c.discoverAndAddAccounts(institutionId, username, password, usernameKey, passwordKey)
+	if err != nil || challengeSession != nil {
+		return
+	}
+
+	discovered, err := decodeDiscoverResult(c.Configuration(), institutionId, body, header)
+	if err != nil {
+		return
+	}
+
+	result = &RediscoverResult{}
+	known := make(map[string]bool)
+	for _, a := range accounts {
+		known[a.Common().AccountId.String()] = true
+		result.Accounts = append(result.Accounts, *a.Common())
+	}
+
+	for _, a := range discovered.Added() {
+		if !known[a.AccountId.String()] {
+			known[a.AccountId.String()] = true
+			result.Accounts = append(result.Accounts, a.CustomerAccount)
+			result.New = append(result.New, a.CustomerAccount)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,34 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateLoginAccountAndRediscover(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/logins/7":
+			assert.Equal(t, PUT, r.Method)
+			w.Write([]byte(`{"accounts": [{"accountId": 1, "institutionId": 100000, "institutionLoginId": 7}]}`))
+		case "/institutions/100000/logins":
+			assert.Equal(t, POST, r.Method)
+			w.Write([]byte(`{"accounts": [
+				{"accountId": 1, "institutionId": 100000, "institutionLoginId": 7},
+				{"accountId": 2, "institutionId": 100000, "institutionLoginId": 7},
+				{"accountId": 3, "institutionId": 100000, "aggrStatusCode": "103"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer done()
+
+	result, session, err := UpdateLoginAccountAndRediscover("7", "user", "pass", "Banking Userid", "Banking Password")
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	assert.Equal(t, 2, len(result.Accounts))
+	assert.Equal(t, 1, len(result.New))
+	assert.Equal(t, "2", result.New[0].AccountId.String())
+}
@@ -0,0 +1,247 @@
+/*
+Share MFA challenge sessions between instances of a web backend through Redis, so a challenge can be answered on a different instance than the one which received it.
+
+	manager := &intuit.ChallengeManager{Store: redisstore.New("localhost:6379")}
+
+Sessions are stored as JSON with a Redis expiry of the manager's TTL, so abandoned challenges clean themselves up. The store speaks the Redis protocol directly over a small pool of connections and needs no client library.
+*/
+package redisstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const DefaultPrefix = "intuit:challenge:"
+
+/*
+Store is an intuit.ChallengeStore keeping sessions in Redis.
+*/
+type Store struct {
+	// Address of the Redis server, as host:port.
+	Addr string
+
+	// Prepended to every key. Defaults to DefaultPrefix.
+	Prefix string
+
+	// Sent with AUTH on each new connection when set.
+	Password string
+
+	// Selected on each new connection.
+	DB int
+
+	// Bounds connecting and each command. No timeout when zero.
+	Timeout time.Duration
+
+	mutex sync.Mutex
+	idle  []*conn
+}
+
+/*
+Return a store for the Redis server at addr.
+*/
+func New(addr string) *Store {
+	return &Store{Addr: addr, Timeout: 5 * time.Second}
+}
+
+func (s *Store) key(key string) string {
+	if s.Prefix == "" {
+		return DefaultPrefix + key
+	}
+
+	return s.Prefix + key
+}
+
+func (s *Store) Save(key string, session *intuit.ChallengeSession, ttl time.Duration) error {
+	b, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	ms := ttl.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+
+	_, err = s.do("SET", s.key(key), string(b), "PX", strconv.FormatInt(ms, 10))
+	return err
+}
+
+func (s *Store) Load(key string) (*intuit.ChallengeSession, error) {
+	reply, err := s.do("GET", s.key(key))
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := reply.([]byte)
+	if !ok {
+		return nil, intuit.ErrChallengeExpired
+	}
+
+	session := &intuit.ChallengeSession{}
+	return session, json.Unmarshal(b, session)
+}
+
+func (s *Store) Delete(key string) error {
+	_, err := s.do("DEL", s.key(key))
+	return err
+}
+
+/*
+Close the idle connections.
+*/
+func (s *Store) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, c := range s.idle {
+		c.Close()
+	}
+	s.idle = nil
+
+	return nil
+}
+
+/*
+Maximum number of idle connections kept for reuse.
+*/
+const maxIdle = 8
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+/*
+Run a command on a pooled connection. Connections which fail are discarded rather than returned to the pool.
+*/
+func (s *Store) do(args ...string) (interface{}, error) {
+	c, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := s.command(c, args...)
+	if err != nil {
+		if _, ok := err.(redisError); !ok {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	s.put(c)
+	return reply, err
+}
+
+func (s *Store) get() (*conn, error) {
+	s.mutex.Lock()
+	if n := len(s.idle); n > 0 {
+		c := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mutex.Unlock()
+		return c, nil
+	}
+	s.mutex.Unlock()
+
+	nc, err := net.DialTimeout("tcp", s.Addr, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	c := &conn{Conn: nc, r: bufio.NewReader(nc)}
+
+	if s.Password != "" {
+		if _, err := s.command(c, "AUTH", s.Password); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if s.DB != 0 {
+		if _, err := s.command(c, "SELECT", strconv.Itoa(s.DB)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (s *Store) put(c *conn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.idle) >= maxIdle {
+		c.Close()
+		return
+	}
+	s.idle = append(s.idle, c)
+}
+
+func (s *Store) command(c *conn, args ...string) (interface{}, error) {
+	if s.Timeout > 0 {
+		c.SetDeadline(time.Now().Add(s.Timeout))
+	}
+
+	buf := fmt.Sprintf("*%d\r\n", len(args))
+	for _, a := range args {
+		buf += fmt.Sprintf("$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := io.WriteString(c, buf); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.r)
+}
+
+/*
+redisError is an error reply from the server. The connection remains usable.
+*/
+type redisError string
+
+func (e redisError) Error() string {
+	return "redisstore: " + string(e)
+}
+
+/*
+Read a single RESP reply: simple strings and integers as strings, bulk strings as []byte, and null as nil.
+*/
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New("redisstore: malformed reply")
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redisstore: malformed reply: %v", err)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		b := make([]byte, n+2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+
+	return nil, fmt.Errorf("redisstore: unexpected reply %q", line)
+}
@@ -0,0 +1,122 @@
+package redisstore
+
+import (
+	"bufio"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+Serve SET with PX, GET and DEL from memory, speaking just enough of the Redis protocol for the store.
+*/
+func fakeRedis(t *testing.T) (string, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	var mutex sync.Mutex
+	values := make(map[string]string)
+	expires := make(map[string]time.Time)
+
+	serve := func(c net.Conn) {
+		defer c.Close()
+		r := bufio.NewReader(c)
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+
+			args := make([]string, n)
+			for i := range args {
+				r.ReadString('\n')
+				arg, _ := r.ReadString('\n')
+				args[i] = strings.TrimSuffix(arg, "\r\n")
+			}
+
+			mutex.Lock()
+			if len(args) > 1 {
+				if e, ok := expires[args[1]]; ok && time.Now().After(e) {
+					delete(values, args[1])
+				}
+			}
+
+			switch strings.ToUpper(args[0]) {
+			case "SET":
+				values[args[1]] = args[2]
+				ms, _ := strconv.Atoi(args[4])
+				expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+				io.WriteString(c, "+OK\r\n")
+			case "GET":
+				if v, ok := values[args[1]]; ok {
+					io.WriteString(c, "$"+strconv.Itoa(len(v))+"\r\n"+v+"\r\n")
+				} else {
+					io.WriteString(c, "$-1\r\n")
+				}
+			case "DEL":
+				delete(values, args[1])
+				io.WriteString(c, ":1\r\n")
+			default:
+				io.WriteString(c, "-ERR unknown command\r\n")
+			}
+			mutex.Unlock()
+		}
+	}
+
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serve(c)
+		}
+	}()
+
+	return l.Addr().String(), func() { l.Close() }
+}
+
+func TestStore(t *testing.T) {
+	addr, done := fakeRedis(t)
+	defer done()
+
+	store := New(addr)
+	defer store.Close()
+
+	session := &intuit.ChallengeSession{InstitutionId: "100000", SessionId: "session", NodeId: "node"}
+	session.Challenges = []intuit.Challenge{{Question: "Favorite color?"}}
+	assert.NoError(t, store.Save("key", session, time.Minute))
+
+	loaded, err := store.Load("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "node", loaded.NodeId)
+	assert.Equal(t, "Favorite color?", loaded.Challenges[0].Question)
+
+	// Other instances see the same sessions.
+	other := New(addr)
+	defer other.Close()
+	_, err = other.Load("key")
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Delete("key"))
+	_, err = other.Load("key")
+	assert.Equal(t, intuit.ErrChallengeExpired, err)
+
+	assert.NoError(t, store.Save("short", session, time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, err = store.Load("short")
+	assert.Equal(t, intuit.ErrChallengeExpired, err)
+
+	_, err = store.do("FLUSHALL")
+	assert.EqualError(t, err, "redisstore: ERR unknown command")
+	_, err = store.Load("short")
+	assert.Equal(t, intuit.ErrChallengeExpired, err)
+}
@@ -0,0 +1,314 @@
+/*
+Format accounts and transactions for display, as aligned text tables, CSV or indented JSON.
+
+	err := render.Transactions(os.Stdout, transactions, render.Options{
+		Format:  render.CSV,
+		Columns: []string{"date", "payee", "amount"},
+	})
+
+Tables format amounts for display in the options' locale; CSV and JSON keep them as plain numbers so they can be read back.
+*/
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+type Format string
+
+const (
+	Table Format = "table"
+	CSV   Format = "csv"
+	JSON  Format = "json"
+)
+
+/*
+Parse a format name, as given to a command line flag.
+*/
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case Table, CSV, JSON:
+		return f, nil
+	}
+
+	return "", fmt.Errorf("render: unknown format %q", s)
+}
+
+type Options struct {
+	// Defaults to Table.
+	Format Format
+
+	// Names of the columns to include, in order. Defaults to the kind's default columns; see AccountColumns and TransactionColumns. When empty, JSON output includes every field.
+	Columns []string
+
+	// Locale for amounts in tables. Defaults to intuit.DefaultLocale.
+	Locale string
+
+	// Omit the header row of tables and CSV.
+	NoHeader bool
+}
+
+func (o Options) locale() string {
+	if o.Locale == "" {
+		return intuit.DefaultLocale
+	}
+
+	return o.Locale
+}
+
+/*
+Columns available for accounts, in their default order. The first six are included by default.
+*/
+var AccountColumns = []string{"id", "name", "number", "type", "balance", "status", "currency", "institution", "login", "balanceDate", "updated"}
+
+var defaultAccountColumns = AccountColumns[:6]
+
+var accountValues = map[string]func(a intuit.CustomerAccount) interface{}{
+	"id": func(a intuit.CustomerAccount) interface{} { return a.AccountId },
+	"name": func(a intuit.CustomerAccount) interface{} {
+		if a.AccountNickname != "" {
+			return a.AccountNickname
+		}
+		return a.Description
+	},
+	"number":      func(a intuit.CustomerAccount) interface{} { return a.AccountNumber },
+	"type":        func(a intuit.CustomerAccount) interface{} { return a.Subtype() },
+	"balance":     func(a intuit.CustomerAccount) interface{} { return a.BalanceAmount },
+	"status":      func(a intuit.CustomerAccount) interface{} { return a.Status },
+	"currency":    func(a intuit.CustomerAccount) interface{} { return accountCurrency(a) },
+	"institution": func(a intuit.CustomerAccount) interface{} { return a.InstitutionId },
+	"login":       func(a intuit.CustomerAccount) interface{} { return a.InstitutionLoginId },
+	"balanceDate": func(a intuit.CustomerAccount) interface{} { return a.BalanceDate },
+	"updated":     func(a intuit.CustomerAccount) interface{} { return a.AggrSuccessDate },
+}
+
+/*
+Columns available for transactions, in their default order. The first four are included by default.
+*/
+var TransactionColumns = []string{"date", "payee", "amount", "status", "id", "account", "type", "memo", "currency"}
+
+var defaultTransactionColumns = TransactionColumns[:4]
+
+var transactionValues = map[string]func(t intuit.Transaction) interface{}{
+	"date":   func(t intuit.Transaction) interface{} { return t.PostedDate },
+	"payee":  func(t intuit.Transaction) interface{} { return t.PayeeName },
+	"amount": func(t intuit.Transaction) interface{} { return t.Amount },
+	"status": func(t intuit.Transaction) interface{} {
+		if t.Pending {
+			return "pending"
+		}
+		return "posted"
+	},
+	"id":       func(t intuit.Transaction) interface{} { return t.Id },
+	"account":  func(t intuit.Transaction) interface{} { return t.AccountId },
+	"type":     func(t intuit.Transaction) interface{} { return t.Type },
+	"memo":     func(t intuit.Transaction) interface{} { return t.Memo },
+	"currency": func(t intuit.Transaction) interface{} { return transactionCurrency(t) },
+}
+
+func accountCurrency(a intuit.CustomerAccount) string {
+	if a.CurrencyCode == "" {
+		return "USD"
+	}
+
+	return a.CurrencyCode
+}
+
+func transactionCurrency(t intuit.Transaction) string {
+	if t.CurrencyType == "" {
+		return "USD"
+	}
+
+	return t.CurrencyType
+}
+
+/*
+Write accounts in the given format.
+*/
+func Accounts(w io.Writer, accounts []intuit.CustomerAccount, o Options) error {
+	if o.Format == JSON && len(o.Columns) == 0 {
+		return writeJSON(w, accounts)
+	}
+
+	columns := o.Columns
+	if len(columns) == 0 {
+		columns = defaultAccountColumns
+	}
+	for _, c := range columns {
+		if accountValues[c] == nil {
+			return fmt.Errorf("render: unknown account column %q", c)
+		}
+	}
+
+	rows := make([]row, len(accounts))
+	for i, a := range accounts {
+		rows[i].currency = accountCurrency(a)
+		for _, c := range columns {
+			rows[i].values = append(rows[i].values, accountValues[c](a))
+		}
+	}
+
+	return write(w, columns, rows, o)
+}
+
+/*
+Write transactions in the given format.
+*/
+func Transactions(w io.Writer, transactions []intuit.Transaction, o Options) error {
+	if o.Format == JSON && len(o.Columns) == 0 {
+		return writeJSON(w, transactions)
+	}
+
+	columns := o.Columns
+	if len(columns) == 0 {
+		columns = defaultTransactionColumns
+	}
+	for _, c := range columns {
+		if transactionValues[c] == nil {
+			return fmt.Errorf("render: unknown transaction column %q", c)
+		}
+	}
+
+	rows := make([]row, len(transactions))
+	for i, t := range transactions {
+		rows[i].currency = transactionCurrency(t)
+		for _, c := range columns {
+			rows[i].values = append(rows[i].values, transactionValues[c](t))
+		}
+	}
+
+	return write(w, columns, rows, o)
+}
+
+/*
+The selected values of an account or transaction, and the currency of its amounts.
+*/
+type row struct {
+	currency string
+	values   []interface{}
+}
+
+func write(w io.Writer, columns []string, rows []row, o Options) error {
+	switch o.Format {
+	case "", Table:
+		return writeTable(w, columns, rows, o)
+	case CSV:
+		return writeCSV(w, columns, rows, o)
+	case JSON:
+		return writeObjects(w, columns, rows)
+	}
+
+	return fmt.Errorf("render: unknown format %q", o.Format)
+}
+
+func writeTable(w io.Writer, columns []string, rows []row, o Options) error {
+	t := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if !o.NoHeader {
+		fmt.Fprintln(t, strings.ToUpper(strings.Join(columns, "\t")))
+	}
+
+	for _, r := range rows {
+		cells := make([]string, len(r.values))
+		for i, v := range r.values {
+			if amount, ok := v.(intuit.Amount); ok {
+				cells[i] = amount.Format(r.currency, o.locale())
+			} else if amount, ok := v.(intuit.Decimal); ok {
+				cells[i] = amount.Format(r.currency, o.locale())
+			} else {
+				cells[i] = text(v)
+			}
+		}
+		fmt.Fprintln(t, strings.Join(cells, "\t"))
+	}
+
+	return t.Flush()
+}
+
+func writeCSV(w io.Writer, columns []string, rows []row, o Options) error {
+	c := csv.NewWriter(w)
+	if !o.NoHeader {
+		c.Write(columns)
+	}
+
+	for _, r := range rows {
+		cells := make([]string, len(r.values))
+		for i, v := range r.values {
+			cells[i] = text(v)
+		}
+		c.Write(cells)
+	}
+
+	c.Flush()
+	return c.Error()
+}
+
+/*
+Write each row as an object holding its columns in order.
+*/
+func writeObjects(w io.Writer, columns []string, rows []row) error {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i, r := range rows {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('{')
+		for j, v := range r.values {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(columns[j])
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			b.Write(key)
+			b.WriteByte(':')
+			b.Write(value)
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(']')
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, b.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+
+	_, err := indented.WriteTo(w)
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(v)
+}
+
+/*
+Format a value for a table or CSV cell: amounts as plain numbers and dates as days, or empty when unset.
+*/
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case intuit.Amount:
+		return strconv.FormatFloat(float64(v), 'f', -1, 64)
+	case intuit.Decimal:
+		return v.String()
+	case intuit.Date:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02")
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,59 @@
+package render
+
+import (
+	"bytes"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func date(s string) intuit.Date {
+	d, _ := intuit.ParseDate(s)
+	return d
+}
+
+var transactions = []intuit.Transaction{
+	{Id: "1", PayeeName: "Rent", Amount: intuit.MustParseDecimal("-1200.5"), PostedDate: date("2014-06-01")},
+	{Id: "2", PayeeName: "Coffee, large", Amount: intuit.MustParseDecimal("-4"), Pending: true},
+}
+
+func TestTable(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, Transactions(&b, transactions, Options{}))
+	assert.Equal(t, ""+
+		"DATE        PAYEE          AMOUNT      STATUS\n"+
+		"2014-06-01  Rent           -$1,200.50  posted\n"+
+		"            Coffee, large  -$4.00      pending\n", b.String())
+
+	b.Reset()
+	accounts := []intuit.CustomerAccount{{AccountId: "7", Description: "Checking", AccountNumber: "1234", BankingAccountType: "CHECKING", BalanceAmount: 10, Status: intuit.AccountActive}}
+	assert.NoError(t, Accounts(&b, accounts, Options{Columns: []string{"name", "balance"}, NoHeader: true}))
+	assert.Equal(t, "Checking  $10.00\n", b.String())
+}
+
+func TestCSV(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, Transactions(&b, transactions, Options{Format: CSV, Columns: []string{"id", "payee", "amount"}}))
+	assert.Equal(t, "id,payee,amount\n1,Rent,-1200.5\n2,\"Coffee, large\",-4\n", b.String())
+}
+
+func TestJSON(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, Transactions(&b, transactions[:1], Options{Format: JSON, Columns: []string{"payee", "amount", "date"}}))
+	assert.Equal(t, "[\n  {\n    \"payee\": \"Rent\",\n    \"amount\": -1200.5,\n    \"date\": \"2014-06-01T00:00:00Z\"\n  }\n]\n", b.String())
+
+	// Without columns, every field is included.
+	b.Reset()
+	assert.NoError(t, Transactions(&b, transactions[:1], Options{Format: JSON}))
+	assert.Contains(t, b.String(), `"institutionTransactionId": ""`)
+}
+
+func TestUnknownColumn(t *testing.T) {
+	var b bytes.Buffer
+	assert.EqualError(t, Accounts(&b, nil, Options{Columns: []string{"payee"}}), `render: unknown account column "payee"`)
+
+	_, err := ParseFormat("xml")
+	assert.EqualError(t, err, `render: unknown format "xml"`)
+	f, _ := ParseFormat("CSV")
+	assert.Equal(t, CSV, f)
+}
@@ -0,0 +1,68 @@
+package intuit
+
+import (
+	"context"
+)
+
+/*
+ChallengeRenderer presents MFA challenges to a user and returns their answers, so the same challenge flow can drive a terminal, a web form or a mobile client. Each method is given one challenge and should return an answer it accepts; see Challenge.Validate.
+*/
+type ChallengeRenderer interface {
+	// Present a question to be answered with free text.
+	RenderText(ctx context.Context, c Challenge) (Answer, error)
+
+	// Present a question, text or image, to be answered with one of its Choices.
+	RenderChoices(ctx context.Context, c Challenge) (Answer, error)
+
+	// Present an image, such as a captcha, to be answered with free text.
+	RenderImage(ctx context.Context, c Challenge) (Answer, error)
+}
+
+/*
+Answer a session's challenges with a renderer and respond, repeating for each further round of challenges the institution asks, and return the data of the final response.
+
+Each challenge is passed to the renderer method for its Kind. A renderer error or an invalid answer stops the flow before anything is sent for that round.
+*/
+func RenderChallenges(ctx context.Context, session *ChallengeSession, r ChallengeRenderer) (data interface{}, err error) {
+	for session != nil {
+		if err = AnswerChallenges(ctx, session, r); err != nil {
+			return nil, err
+		}
+
+		data, session, err = session.Respond()
+	}
+
+	return data, err
+}
+
+/*
+Collect an answer to each of the session's challenges with a renderer, storing them in session.Answers ready for Respond.
+*/
+func AnswerChallenges(ctx context.Context, session *ChallengeSession, r ChallengeRenderer) error {
+	answers := make([]Answer, len(session.Challenges))
+	for i, c := range session.Challenges {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		render := r.RenderText
+		switch c.Kind() {
+		case ChoiceChallenge:
+			render = r.RenderChoices
+		case ImageChallenge:
+			render = r.RenderImage
+		}
+
+		answer, err := render(ctx, c)
+		if err != nil {
+			return err
+		}
+		if err := c.Validate(answer); err != nil {
+			return err
+		}
+		answers[i] = answer
+	}
+
+	session.Answers = answers
+	return nil
+}
@@ -0,0 +1,78 @@
+package intuit
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+type stubRenderer struct {
+	rendered []string
+	err      error
+}
+
+func (r *stubRenderer) RenderText(ctx context.Context, c Challenge) (Answer, error) {
+	r.rendered = append(r.rendered, "text:"+c.Question)
+	return TextAnswer("smith"), r.err
+}
+
+func (r *stubRenderer) RenderChoices(ctx context.Context, c Challenge) (Answer, error) {
+	r.rendered = append(r.rendered, "choices:"+c.Question)
+	return ChoiceAnswer(c.Choices[len(c.Choices)-1]), r.err
+}
+
+func (r *stubRenderer) RenderImage(ctx context.Context, c Challenge) (Answer, error) {
+	r.rendered = append(r.rendered, "image")
+	return TextAnswer("x7k2"), r.err
+}
+
+func TestRenderChallenges(t *testing.T) {
+	rounds := 0
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		rounds++
+		body, _ := ioutil.ReadAll(r.Body)
+
+		if rounds == 1 {
+			assert.Contains(t, string(body), "x7k2")
+			assert.Contains(t, string(body), ">2<")
+			w.Header().Set("challengeSessionId", "session")
+			w.Header().Set("challengeNodeId", "node-2")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"challenge": [{"textOrImageAndChoice": ["Mother's maiden name?"]}]}`))
+			return
+		}
+
+		assert.Contains(t, string(body), "smith")
+		w.Write([]byte(`{"accounts": []}`))
+	})
+	defer done()
+
+	session := &ChallengeSession{InstitutionId: "100000", SessionId: "session", NodeId: "node-1", contextType: discoverAndAddType}
+	session.Challenges = []Challenge{
+		{Image: []byte("GIF89a")},
+		{Question: "Pick a city", Choices: []Choice{{Value: "1", Text: "Paris"}, {Value: "2", Text: "Rome"}}},
+	}
+
+	r := &stubRenderer{}
+	data, err := RenderChallenges(context.Background(), session, r)
+	assert.NoError(t, err)
+	assert.NotNil(t, data)
+	assert.Equal(t, []string{"image", "choices:Pick a city", "text:Mother's maiden name?"}, r.rendered)
+	assert.Equal(t, 2, rounds)
+}
+
+func TestAnswerChallengesStops(t *testing.T) {
+	session := &ChallengeSession{Challenges: []Challenge{{Question: "Favorite color?"}, {Question: "First pet?"}}}
+
+	failing := &stubRenderer{err: errors.New("cancelled by user")}
+	assert.EqualError(t, AnswerChallenges(context.Background(), session, failing), "cancelled by user")
+	assert.Len(t, failing.rendered, 1)
+	assert.Nil(t, session.Answers)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, AnswerChallenges(ctx, session, &stubRenderer{}))
+}
@@ -0,0 +1,122 @@
+package intuit
+
+import (
+	"time"
+)
+
+/*
+RepairAction is what a user or caller should do to get a failing login aggregating again.
+*/
+type RepairAction string
+
+const (
+	// The login is aggregating; nothing needs doing.
+	RepairNone RepairAction = "NONE"
+
+	// The user must enter their credentials again, with UpdateLoginAccount.
+	RepairReenterCredentials RepairAction = "REENTER_CREDENTIALS"
+
+	// The institution requires MFA. Refresh the login with RefreshAccount and answer the returned challenge.
+	RepairAnswerChallenge RepairAction = "ANSWER_CHALLENGE"
+
+	// The user must resolve something on the institution's website, such as a notice or password change, then update the login.
+	RepairVisitInstitution RepairAction = "VISIT_INSTITUTION"
+
+	// The institution is unavailable; retry later.
+	RepairWait RepairAction = "WAIT"
+
+	// The failure is not one the user can fix; report it to Intuit support with the code.
+	RepairContactSupport RepairAction = "CONTACT_SUPPORT"
+)
+
+/*
+Actions for well-known aggregation status codes. Codes not listed call for RepairContactSupport. Callers may add or override entries.
+*/
+var RepairActions = map[string]RepairAction{
+	"102": RepairWait,
+	"103": RepairReenterCredentials,
+	"106": RepairWait,
+	"108": RepairVisitInstitution,
+	"109": RepairVisitInstitution,
+	"185": RepairAnswerChallenge,
+	"186": RepairAnswerChallenge,
+	"187": RepairAnswerChallenge,
+}
+
+/*
+How long a login may fail with a RepairWait code before support should be contacted instead, since an institution outage rarely lasts this long.
+*/
+var RepairWaitLimit = 72 * time.Hour
+
+/*
+Repair is the recommended action for a login.
+*/
+type Repair struct {
+	LoginId string
+	Action  RepairAction
+
+	// The aggregation status code behind the action, and its remediation hint. Empty for RepairNone.
+	Code string
+	Hint string
+
+	// When the login last aggregated successfully, zero if never.
+	LastSuccess time.Time
+}
+
+/*
+Recommend how to repair a login of the scoped customer, from the aggregation status of its accounts.
+*/
+func SuggestRepair(loginId string) (*Repair, error) {
+	return defaultClient().SuggestRepair(loginId)
+}
+
+/*
+Recommend how to repair a login of the scoped customer. See SuggestRepair.
+*/
+func (c *Client) SuggestRepair(loginId string) (*Repair, error) {
+	accounts, err := customerAccounts(c.background())
+	if err != nil {
+		return nil, err
+	}
+
+	accounts = FilterAccounts(accounts, func(a CustomerAccount) bool {
+		return a.InstitutionLoginId.String() == loginId
+	})
+	if len(accounts) == 0 {
+		return nil, &NotFoundError{Resource: "login", Id: loginId}
+	}
+
+	return RepairFor(loginId, accounts, time.Now()), nil
+}
+
+/*
+Recommend how to repair a login from its accounts, as of now, without contacting Intuit. The first failing account decides the action. A login which has failed with a RepairWait code for longer than RepairWaitLimit calls for RepairContactSupport.
+*/
+func RepairFor(loginId string, accounts []CustomerAccount, now time.Time) *Repair {
+	r := &Repair{LoginId: loginId, Action: RepairNone}
+
+	for _, a := range accounts {
+		if a.AggrSuccessDate.After(r.LastSuccess) {
+			r.LastSuccess = a.AggrSuccessDate.Time
+		}
+		if r.Code == "" && !aggregating(a) {
+			r.Code = a.AggrStatusCode
+		}
+	}
+
+	if r.Code == "" {
+		return r
+	}
+
+	r.Hint = ErrorHints[r.Code]
+	action, ok := RepairActions[r.Code]
+	if !ok {
+		action = RepairContactSupport
+	}
+	if action == RepairWait && !r.LastSuccess.IsZero() && now.Sub(r.LastSuccess) > RepairWaitLimit {
+		action = RepairContactSupport
+	}
+	r.Action = action
+
+	return r
+}
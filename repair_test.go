@@ -0,0 +1,47 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRepairFor(t *testing.T) {
+	now := time.Date(2014, 6, 30, 0, 0, 0, 0, time.UTC)
+	success := Date{Time: now.Add(-24 * time.Hour)}
+
+	r := RepairFor("1", []CustomerAccount{{AggrStatusCode: "0", AggrSuccessDate: success}}, now)
+	assert.Equal(t, RepairNone, r.Action)
+	assert.Equal(t, success.Time, r.LastSuccess)
+
+	r = RepairFor("1", []CustomerAccount{{AggrStatusCode: "0"}, {AggrStatusCode: "103"}}, now)
+	assert.Equal(t, RepairReenterCredentials, r.Action)
+	assert.Equal(t, "103", r.Code)
+	assert.Equal(t, ErrorHints["103"], r.Hint)
+
+	assert.Equal(t, RepairAnswerChallenge, RepairFor("1", []CustomerAccount{{AggrStatusCode: "187"}}, now).Action)
+	assert.Equal(t, RepairContactSupport, RepairFor("1", []CustomerAccount{{AggrStatusCode: "999"}}, now).Action)
+
+	// An outage is waited out, unless it has gone on too long.
+	assert.Equal(t, RepairWait, RepairFor("1", []CustomerAccount{{AggrStatusCode: "102", AggrSuccessDate: success}}, now).Action)
+	old := Date{Time: now.Add(-RepairWaitLimit - time.Hour)}
+	assert.Equal(t, RepairContactSupport, RepairFor("1", []CustomerAccount{{AggrStatusCode: "102", AggrSuccessDate: old}}, now).Action)
+}
+
+func TestSuggestRepair(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accounts": [
+			{"accountId": 1, "institutionLoginId": 10, "aggrStatusCode": "0"},
+			{"accountId": 2, "institutionLoginId": 20, "aggrStatusCode": "108"}
+		]}`))
+	})
+	defer done()
+
+	r, err := SuggestRepair("20")
+	assert.NoError(t, err)
+	assert.Equal(t, &Repair{LoginId: "20", Action: RepairVisitInstitution, Code: "108", Hint: ErrorHints["108"]}, r)
+
+	_, err = SuggestRepair("30")
+	assert.EqualError(t, err, "intuit: login 30 not found")
+}
@@ -2,17 +2,18 @@ package intuit
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
 	"crypto/x509"
+	"embed"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"github.com/MattNewberry/oauth"
-	"github.com/nu7hatch/gouuid"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -23,6 +24,8 @@ import (
 
 type Assertion struct {
 	IssuerId   string
+	Audience   string
+	Recipient  string
 	UserId     string
 	RefId      string
 	TimeNow    string
@@ -41,13 +44,149 @@ type Signature struct {
 	SignedInfo     string
 }
 
-func MakeSamlAssertion() (*oauth.AccessToken, error) {
+/*
+Default timeout for exchanging a SAML assertion for an OAuth token.
+*/
+const DefaultTokenExchangeTimeout = 30 * time.Second
+
+/*
+Intuit's endpoint for exchanging SAML assertions for OAuth tokens.
+*/
+const SamlTokenURL = "https://oauth.intuit.com/oauth/v1/get_access_token_by_saml"
+
+/*
+Returned by API calls made before Authenticate when RequireAuthenticate is set.
+*/
+var ErrNotAuthenticated = errors.New("intuit: not authenticated, call Authenticate first")
+
+/*
+Returned when a SAML assertion signed with the key at CertificatePath cannot be verified with the certificate at PublicCertificatePath. Intuit would reject the assertion with a generic 401.
+*/
+var ErrCertificateMismatch = errors.New("intuit: certificate does not match signing key")
+
+/*
+Perform the SAML token exchange for the scoped customer, or the customer selected by WithCustomer, replacing any existing token for that customer.
+
+Calling this at startup makes certificate and configuration problems fail immediately, rather than surfacing as errors from the first API call.
+*/
+func Authenticate(ctx context.Context) error {
+	token, err := MakeSamlAssertionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	configurationFor(ctx).setToken(customerFor(ctx), token)
+	return nil
+}
+
+/*
+Exchange a signed SAML assertion for an OAuth access token.
+
+Deprecated: use Authenticate, or MakeSamlAssertionContext to obtain the token.
+*/
+func MakeSamlAssertion() (*AccessToken, error) {
+	defaultClient().Configuration().deprecated(0, "MakeSamlAssertion", "Authenticate or MakeSamlAssertionContext")
+	return MakeSamlAssertionContext(context.Background())
+}
+
+/*
+Exchange a signed SAML assertion for an OAuth access token, bounded by the context and the configured TokenExchangeTimeout.
+*/
+func MakeSamlAssertionContext(ctx context.Context) (*AccessToken, error) {
+	configuration := configurationFor(ctx)
+	a, err := newSignedAssertion(configuration, customerFor(ctx), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	payload := base64.URLEncoding.EncodeToString([]byte(a.String()))
+
+	values := make(url.Values)
+	values.Set("saml_assertion", payload)
+	values.Set("oauth_consumer_key", configuration.OAuthConsumerKey)
+
+	timeout := configuration.TokenExchangeTimeout
+	if timeout == 0 {
+		timeout = DefaultTokenExchangeTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tokenURL := configuration.TokenURL
+	if tokenURL == "" {
+		tokenURL = SamlTokenURL
+	}
+
+	req, err := http.NewRequest(POST, tokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, &TransportError{Method: POST, Endpoint: tokenURL, Err: err}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := configuration.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, &TransportError{Method: POST, Endpoint: tokenURL, Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &TransportError{Method: POST, Endpoint: tokenURL, Err: err}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		authenticate, _ := url.QueryUnescape(resp.Header.Get("Www-Authenticate"))
+		return nil, &APIError{
+			Method:     POST,
+			Endpoint:   tokenURL,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       body,
+			Header:     resp.Header,
+			Message:    strings.TrimSpace(fmt.Sprintf("%s %s", authenticate, body)),
+
+			configuration: configuration,
+		}
+	}
+
+	bValues, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, &DecodeError{Method: POST, Endpoint: tokenURL, StatusCode: resp.StatusCode, Body: body, Err: err}
+	}
+
+	tokens := &AccessToken{}
+	tokens.Token = bValues.Get("oauth_token")
+	tokens.Secret = bValues.Get("oauth_token_secret")
+
+	// A token without its secret, or the reverse, would only fail later when signing requests.
+	for _, key := range []string{"oauth_token", "oauth_token_secret"} {
+		if bValues.Get(key) == "" {
+			return nil, &DecodeError{Method: POST, Endpoint: tokenURL, StatusCode: resp.StatusCode, Body: body, Err: fmt.Errorf("missing %s", key)}
+		}
+	}
+
+	return tokens, nil
+}
+
+/*
+Build and sign an assertion for the given customer, valid around t.
+*/
+func newSignedAssertion(configuration *Configuration, customerId string, t time.Time) (*Assertion, error) {
 	a := &Assertion{}
-	a.IssuerId = SessionConfiguration.SamlProviderId
-	a.UserId = SessionConfiguration.CustomerId
-	a.RefId = newUUId()
+	a.IssuerId = configuration.SamlProviderId
+	a.Audience = configuration.SamlAudience
+	if a.Audience == "" {
+		a.Audience = configuration.SamlProviderId
+	}
+	a.Recipient = configuration.SamlRecipient
+	a.UserId = customerId
+
+	id, err := configuration.newAssertionId()
+	if err != nil {
+		return nil, err
+	}
+	a.RefId = id
 
-	t := time.Now()
 	a.TimeNow = a.formatTimeFromDuration(t, 0)
 	a.TimeBefore = a.formatTimeFromDuration(t, -5*time.Minute)
 	a.TimeAfter = a.formatTimeFromDuration(t, 10*time.Minute)
@@ -55,32 +194,21 @@ func MakeSamlAssertion() (*oauth.AccessToken, error) {
 	si := signedInfoFromAssertion(a)
 
 	s := &Signature{}
-	s.SignatureValue = si.SignatureValue(SessionConfiguration.CertificatePath)
+	if s.SignatureValue, err = si.SignatureValue(configuration.CertificatePath); err != nil {
+		return nil, err
+	}
 	s.SignedInfo = si.String()
 
+	if configuration.PublicCertificatePath != "" {
+		if err = verifySignature(configuration.PublicCertificatePath, s); err != nil {
+			return nil, err
+		}
+	}
+
 	signature := s.String()
 	a.Signature = signature
 
-	payload := base64.URLEncoding.EncodeToString([]byte(a.String()))
-
-	values := make(url.Values)
-	values.Set("saml_assertion", payload)
-	values.Set("oauth_consumer_key", SessionConfiguration.OAuthConsumerKey)
-	resp, err := http.PostForm("https://oauth.intuit.com/oauth/v1/get_access_token_by_saml", values)
-
-	tokens := &oauth.AccessToken{}
-	if err != nil || resp.StatusCode != 200 {
-		db, _ := url.QueryUnescape(resp.Header.Get("Www-Authenticate"))
-		msg := fmt.Sprintf("%s %s", resp.Status, db)
-		err = errors.New(msg)
-	} else {
-		body, _ := ioutil.ReadAll(resp.Body)
-		bValues, _ := url.ParseQuery(string(body))
-		tokens.Token = bValues.Get("oauth_token")
-		tokens.Secret = bValues.Get("oauth_token_secret")
-	}
-
-	return tokens, err
+	return a, nil
 }
 
 func (a *Assertion) String() string {
@@ -95,8 +223,14 @@ func (s *SignedInfo) String() string {
 	return parseTemplate("saml_signed", s)
 }
 
+/*
+The assertion templates are compiled into the package, so it works regardless of the working directory.
+*/
+//go:embed templates/*.xml
+var templates embed.FS
+
 func parseTemplate(file string, data interface{}) string {
-	t, _ := template.ParseFiles("templates/" + file + ".xml")
+	t, _ := template.ParseFS(templates, "templates/"+file+".xml")
 
 	var buf bytes.Buffer
 	t.Execute(&buf, data)
@@ -114,9 +248,37 @@ func (a *Assertion) formatTimeFromDuration(t time.Time, d time.Duration) string
 	return fmt.Sprintf("%s.000Z", t.Add(d).UTC().Format(layout))
 }
 
-func newUUId() string {
-	uuid, _ := uuid.NewV4()
-	return fmt.Sprintf("_%s", strings.Replace(uuid.String(), "-", "", -1))
+/*
+Return a random (version 4) UUID as 32 hex digits, prefixed with an underscore since XML Ids may not start with a digit.
+*/
+func newUUId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("intuit: generating assertion id: %v", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return "_" + hex.EncodeToString(b), nil
+}
+
+func (c *Configuration) newAssertionId() (string, error) {
+	generate := c.AssertionIdGenerator
+	if generate == nil {
+		generate = newUUId
+	}
+
+	id, err := generate()
+	if err != nil {
+		return "", err
+	}
+
+	// The Id is referenced by the signature, so an empty one produces an assertion Intuit rejects with an opaque 401
+	if strings.Trim(id, "_0") == "" {
+		return "", fmt.Errorf("intuit: invalid assertion id %q", id)
+	}
+
+	return id, nil
 }
 
 func signedInfoFromAssertion(a *Assertion) *SignedInfo {
@@ -129,29 +291,86 @@ func signedInfoFromAssertion(a *Assertion) *SignedInfo {
 	return s
 }
 
-func (s *SignedInfo) SignatureValue(keyPath string) string {
-	pkey, err := ioutil.ReadFile(keyPath)
+/*
+Sign with the private key at keyPath, returning the base64-encoded signature. An error is returned if the key cannot be read or parsed.
+*/
+func (s *SignedInfo) SignatureValue(keyPath string) (string, error) {
+	privateKey, err := loadPrivateKey(keyPath)
 	if err != nil {
-		panic(err)
+		return "", fmt.Errorf("intuit: loading signing key: %w", err)
 	}
 
-	block, _ := pem.Decode(pkey)
+	signedString := s.String()
+	digest := []byte(sha1Encode(signedString))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA1, digest)
+	if err != nil {
+		return "", fmt.Errorf("intuit: signing assertion: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(signature)), nil
+}
+
+/*
+Verify a signature with the public key of the certificate at certPath, returning ErrCertificateMismatch if it was made with another key.
+*/
+func verifySignature(certPath string, s *Signature) error {
+	publicKey, err := loadCertificateKey(certPath)
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(s.SignatureValue)
+	if err != nil {
+		return err
+	}
+
+	if rsa.VerifyPKCS1v15(publicKey, crypto.SHA1, []byte(sha1Encode(s.SignedInfo)), signature) != nil {
+		return ErrCertificateMismatch
+	}
+
+	return nil
+}
+
+func loadCertificateKey(certPath string) (*rsa.PublicKey, error) {
+	b, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(b)
 	if block == nil {
-		panic(fmt.Sprintf("bad key data: %s", "not PEM-encoded"))
+		return nil, fmt.Errorf("bad certificate data: %s", "not PEM-encoded")
 	}
 
-	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	cert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
-		panic(fmt.Sprintf("bad private key: %s", err))
+		return nil, fmt.Errorf("bad certificate: %s", err)
 	}
 
-	signedString := s.String()
-	digest := []byte(sha1Encode(signedString))
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("bad certificate: %s", "not an RSA key")
+	}
 
-	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA1, digest)
+	return publicKey, nil
+}
+
+func loadPrivateKey(keyPath string) (*rsa.PrivateKey, error) {
+	pkey, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(pkey)
+	if block == nil {
+		return nil, fmt.Errorf("bad key data: %s", "not PEM-encoded")
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
 	if err != nil {
-		panic(fmt.Sprintf("rsa.SignPKCS1v15 error: %v\n", err))
+		return nil, fmt.Errorf("bad private key: %s", err)
 	}
 
-	return base64.StdEncoding.EncodeToString([]byte(signature))
+	return privateKey, nil
 }
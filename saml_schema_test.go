@@ -0,0 +1,709 @@
+package intuit
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+The SAML 2.0 assertion schema (saml-schema-assertion-2.0.xsd) and the schemas it imports, the W3C XML Signature (xmldsig-core-schema.xsd) and XML Encryption (xenc-schema.xsd) schemas, are vendored in testdata with their imports pointed at the local copies. Generated documents are validated against them by the small XSD interpreter below, which covers the constructs those schemas use, and also with xmllint when it is installed.
+*/
+const (
+	xsdNS  = "http://www.w3.org/2001/XMLSchema"
+	samlNS = "urn:oasis:names:tc:SAML:2.0:assertion"
+	dsNS   = "http://www.w3.org/2000/09/xmldsig#"
+
+	unbounded = -1
+)
+
+var schemaFiles = map[string]string{
+	samlNS: "testdata/saml-schema-assertion-2.0.xsd",
+	dsNS:   "testdata/xmldsig-core-schema.xsd",
+}
+
+/*
+xsdNode is an element of a schema document, with the namespace prefixes in scope to resolve the QNames in its attributes.
+*/
+type xsdNode struct {
+	name     string
+	attrs    map[string]string
+	prefixes map[string]string
+	children []*xsdNode
+
+	// Of the schema document the node belongs to.
+	target    string
+	qualified bool
+}
+
+func (n *xsdNode) qname(attr string) (xml.Name, bool) {
+	v, ok := n.attrs[attr]
+	if !ok {
+		return xml.Name{}, false
+	}
+
+	prefix, local := "", v
+	if i := strings.Index(v, ":"); i >= 0 {
+		prefix, local = v[:i], v[i+1:]
+	}
+
+	return xml.Name{Space: n.prefixes[prefix], Local: local}, true
+}
+
+func (n *xsdNode) occurs() (min, max int) {
+	min, max = 1, 1
+	if v, ok := n.attrs["minOccurs"]; ok {
+		min, _ = strconv.Atoi(v)
+	}
+	if v, ok := n.attrs["maxOccurs"]; ok {
+		if v == "unbounded" {
+			max = unbounded
+		} else {
+			max, _ = strconv.Atoi(v)
+		}
+	}
+
+	return
+}
+
+func (n *xsdNode) child(names ...string) *xsdNode {
+	for _, c := range n.children {
+		for _, name := range names {
+			if c.name == name {
+				return c
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+xsdSchema holds the global components of the loaded schema documents.
+*/
+type xsdSchema struct {
+	elements map[xml.Name]*xsdNode
+	types    map[xml.Name]*xsdNode
+	groups   map[xml.Name]*xsdNode
+	loaded   map[string]bool
+}
+
+func loadSchema(t *testing.T, path string) *xsdSchema {
+	s := &xsdSchema{
+		elements: make(map[xml.Name]*xsdNode),
+		types:    make(map[xml.Name]*xsdNode),
+		groups:   make(map[xml.Name]*xsdNode),
+		loaded:   make(map[string]bool),
+	}
+	if err := s.load(path); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func (s *xsdSchema) load(path string) error {
+	if s.loaded[path] {
+		return nil
+	}
+	s.loaded[path] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	root, err := parseXSD(f)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	for _, c := range root.children {
+		name := xml.Name{Space: root.target, Local: c.attrs["name"]}
+		switch c.name {
+		case "import":
+			if err := s.load(filepath.Join(filepath.Dir(path), c.attrs["schemaLocation"])); err != nil {
+				return err
+			}
+		case "element":
+			s.elements[name] = c
+		case "complexType", "simpleType":
+			s.types[name] = c
+		case "attributeGroup":
+			s.groups[name] = c
+		}
+	}
+
+	return nil
+}
+
+func parseXSD(r io.Reader) (*xsdNode, error) {
+	d := xml.NewDecoder(r)
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// The assertion schema is declared US-ASCII, a subset of UTF-8.
+		if strings.EqualFold(charset, "US-ASCII") {
+			return input, nil
+		}
+		return nil, fmt.Errorf("unsupported charset %s", charset)
+	}
+	var stack []*xsdNode
+	var root *xsdNode
+
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &xsdNode{name: tok.Name.Local, attrs: make(map[string]string), prefixes: make(map[string]string)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				for k, v := range parent.prefixes {
+					n.prefixes[k] = v
+				}
+				n.target, n.qualified = parent.target, parent.qualified
+				parent.children = append(parent.children, n)
+			} else {
+				root = n
+				n.target = xmlAttr(tok, "targetNamespace")
+				n.qualified = xmlAttr(tok, "elementFormDefault") == "qualified"
+			}
+			for _, a := range tok.Attr {
+				switch {
+				case a.Name.Space == "xmlns":
+					n.prefixes[a.Name.Local] = a.Value
+				case a.Name.Space == "" && a.Name.Local == "xmlns":
+					n.prefixes[""] = a.Value
+				case a.Name.Space == "":
+					n.attrs[a.Name.Local] = a.Value
+				}
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if root == nil || root.name != "schema" {
+		return nil, errors.New("not a schema document")
+	}
+	return root, nil
+}
+
+func xmlAttr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+/*
+contentModel is what a complex type allows: its attributes, its children as a particle, and the type of its text for simple content.
+*/
+type contentModel struct {
+	attributes   map[string]*xsdNode
+	anyAttribute bool
+	particles    []*xsdNode
+	mixed        bool
+	text         string
+}
+
+/*
+Return the builtin type a type name resolves to, following simple type restrictions, or "" for complex types.
+*/
+func (s *xsdSchema) simpleType(name xml.Name) string {
+	if name.Space == xsdNS {
+		if name.Local == "anyType" {
+			return ""
+		}
+		return name.Local
+	}
+
+	def, ok := s.types[name]
+	if !ok || def.name != "simpleType" {
+		return ""
+	}
+	if r := def.child("restriction"); r != nil {
+		if base, ok := r.qname("base"); ok {
+			return s.simpleType(base)
+		}
+	}
+
+	return "string"
+}
+
+func (s *xsdSchema) model(def *xsdNode) *contentModel {
+	m := &contentModel{attributes: make(map[string]*xsdNode), mixed: def.attrs["mixed"] == "true"}
+	s.addAttributes(m, def)
+
+	for _, c := range def.children {
+		switch c.name {
+		case "sequence", "choice", "all":
+			m.particles = append(m.particles, c)
+		case "simpleContent", "complexContent":
+			if c.attrs["mixed"] == "true" {
+				m.mixed = true
+			}
+
+			derivation := c.child("extension", "restriction")
+			base, _ := derivation.qname("base")
+			if c.name == "simpleContent" {
+				m.text = s.simpleType(base)
+				if m.text == "" {
+					m.text = s.model(s.types[base]).text
+				}
+			} else if baseDef, ok := s.types[base]; ok {
+				inherited := s.model(baseDef)
+				for k, v := range inherited.attributes {
+					m.attributes[k] = v
+				}
+				m.anyAttribute = m.anyAttribute || inherited.anyAttribute
+				if derivation.name == "extension" {
+					m.particles = append(m.particles, inherited.particles...)
+				}
+			}
+
+			s.addAttributes(m, derivation)
+			for _, p := range derivation.children {
+				if p.name == "sequence" || p.name == "choice" || p.name == "all" {
+					m.particles = append(m.particles, p)
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+func (s *xsdSchema) addAttributes(m *contentModel, def *xsdNode) {
+	for _, c := range def.children {
+		switch c.name {
+		case "attribute":
+			m.attributes[c.attrs["name"]] = c
+		case "attributeGroup":
+			if ref, ok := c.qname("ref"); ok {
+				s.addAttributes(m, s.groups[ref])
+			}
+		case "anyAttribute":
+			m.anyAttribute = true
+		}
+	}
+}
+
+/*
+Return the name an element particle matches and its declaration, resolving references to global elements.
+*/
+func (s *xsdSchema) declaration(p *xsdNode) (xml.Name, *xsdNode) {
+	if ref, ok := p.qname("ref"); ok {
+		return ref, s.elements[ref]
+	}
+
+	name := xml.Name{Local: p.attrs["name"]}
+	if p.qualified {
+		name.Space = p.target
+	}
+	return name, p
+}
+
+func (n *xsdNode) allows(space string) bool {
+	switch ns := n.attrs["namespace"]; ns {
+	case "", "##any":
+		return true
+	case "##other":
+		return space != "" && space != n.target
+	default:
+		for _, allowed := range strings.Fields(ns) {
+			if allowed == space || (allowed == "##targetNamespace" && space == n.target) || (allowed == "##local" && space == "") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+/*
+Return the positions in children at which a match of the particle, repeated as its occurrences allow, starting at i can end.
+*/
+func (s *xsdSchema) match(p *xsdNode, children []*node, i int) []int {
+	min, max := p.occurs()
+
+	ends := make(map[int]bool)
+	current := map[int]bool{i: true}
+	seen := map[int]bool{i: true}
+	if min == 0 {
+		ends[i] = true
+	}
+
+	for count := 1; len(current) > 0 && (max == unbounded || count <= max); count++ {
+		next := make(map[int]bool)
+		for pos := range current {
+			for _, end := range s.matchOnce(p, children, pos) {
+				if count >= min {
+					ends[end] = true
+				}
+				// Stop repeating once no further children are consumed, unless the minimum is still to be reached.
+				if !seen[end] || count < min {
+					next[end] = true
+					seen[end] = true
+				}
+			}
+		}
+		current = next
+	}
+
+	list := make([]int, 0, len(ends))
+	for end := range ends {
+		list = append(list, end)
+	}
+	return list
+}
+
+func (s *xsdSchema) matchOnce(p *xsdNode, children []*node, i int) []int {
+	switch p.name {
+	case "element":
+		name, _ := s.declaration(p)
+		if i < len(children) && children[i].name == name {
+			return []int{i + 1}
+		}
+	case "any":
+		if i < len(children) && p.allows(children[i].name.Space) {
+			return []int{i + 1}
+		}
+	case "sequence", "all":
+		positions := []int{i}
+		for _, c := range p.children {
+			next := make(map[int]bool)
+			for _, pos := range positions {
+				for _, end := range s.match(c, children, pos) {
+					next[end] = true
+				}
+			}
+			positions = positions[:0]
+			for pos := range next {
+				positions = append(positions, pos)
+			}
+		}
+		return positions
+	case "choice":
+		ends := make([]int, 0)
+		for _, c := range p.children {
+			ends = append(ends, s.match(c, children, i)...)
+		}
+		return ends
+	}
+
+	return nil
+}
+
+/*
+Collect the element declarations of a content model by the name they match.
+*/
+func (s *xsdSchema) declarations(particles []*xsdNode, into map[xml.Name]*xsdNode) {
+	for _, p := range particles {
+		if p.name == "element" {
+			name, decl := s.declaration(p)
+			into[name] = decl
+		} else {
+			s.declarations(p.children, into)
+		}
+	}
+}
+
+/*
+Validate an element against its declaration.
+*/
+func (s *xsdSchema) validate(n *node, decl *xsdNode) []error {
+	path := n.name.Local
+	errs := make([]error, 0)
+
+	var def *xsdNode
+	typeName, hasType := decl.qname("type")
+	if hasType {
+		if text := s.simpleType(typeName); text != "" {
+			return s.validateSimple(n, text)
+		}
+		def = s.types[typeName]
+	} else if def = decl.child("complexType"); def == nil {
+		if st := decl.child("simpleType"); st != nil {
+			base, _ := st.child("restriction").qname("base")
+			return s.validateSimple(n, s.simpleType(base))
+		}
+	}
+	if def == nil {
+		// xs:anyType, or a declaration without a type, accepts anything.
+		return errs
+	}
+	if def.attrs["abstract"] == "true" {
+		return append(errs, fmt.Errorf("%s: type %s is abstract", path, typeName.Local))
+	}
+
+	m := s.model(def)
+
+	for name, a := range m.attributes {
+		if a.attrs["use"] == "required" {
+			if _, ok := n.attrs[name]; !ok {
+				errs = append(errs, fmt.Errorf("%s: missing required attribute %s", path, name))
+			}
+		}
+	}
+	for name, v := range n.attrs {
+		a, ok := m.attributes[name]
+		if !ok {
+			if !m.anyAttribute {
+				errs = append(errs, fmt.Errorf("%s: unexpected attribute %s", path, name))
+			}
+			continue
+		}
+
+		kind := "string"
+		if t, ok := a.qname("type"); ok {
+			kind = s.simpleType(t)
+		}
+		if err := checkValue(kind, v); err != nil {
+			errs = append(errs, fmt.Errorf("%s@%s: %v", path, name, err))
+		}
+	}
+
+	if m.text != "" {
+		if len(n.children) > 0 {
+			errs = append(errs, fmt.Errorf("%s: unexpected child %s in simple content", path, n.children[0].name.Local))
+		}
+		if err := checkValue(m.text, n.text); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", path, err))
+		}
+		return errs
+	}
+	if !m.mixed && strings.TrimSpace(n.text) != "" {
+		errs = append(errs, fmt.Errorf("%s: unexpected text %q", path, strings.TrimSpace(n.text)))
+	}
+
+	content := &xsdNode{name: "sequence", children: m.particles}
+	valid := false
+	for _, end := range s.match(content, n.children, 0) {
+		valid = valid || end == len(n.children)
+	}
+	if !valid {
+		names := make([]string, len(n.children))
+		for i, c := range n.children {
+			names[i] = c.name.Local
+		}
+		errs = append(errs, fmt.Errorf("%s: children %v do not match the content model of %s", path, names, typeName.Local))
+	}
+
+	decls := make(map[xml.Name]*xsdNode)
+	s.declarations(m.particles, decls)
+	for _, c := range n.children {
+		if d, ok := decls[c.name]; ok && d != nil {
+			errs = append(errs, s.validate(c, d)...)
+		} else if d, ok := s.elements[c.name]; ok {
+			errs = append(errs, s.validate(c, d)...)
+		}
+	}
+
+	return errs
+}
+
+func (s *xsdSchema) validateSimple(n *node, kind string) []error {
+	errs := make([]error, 0)
+	if len(n.children) > 0 || len(n.attrs) > 0 {
+		errs = append(errs, fmt.Errorf("%s: simple content may not have children or attributes", n.name.Local))
+	}
+	if err := checkValue(kind, n.text); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %v", n.name.Local, err))
+	}
+
+	return errs
+}
+
+/*
+Validate a document against the vendored schema of its root element's namespace.
+*/
+func validateDocument(t *testing.T, doc string) []error {
+	root := parseNode(t, doc)
+	path, ok := schemaFiles[root.name.Space]
+	if !ok {
+		return []error{fmt.Errorf("no schema for namespace %s", root.name.Space)}
+	}
+
+	s := loadSchema(t, path)
+	decl, ok := s.elements[root.name]
+	if !ok {
+		return []error{fmt.Errorf("unexpected element {%s}%s", root.name.Space, root.name.Local)}
+	}
+
+	errs := s.validate(root, decl)
+	errs = append(errs, xmllint(t, path, doc)...)
+	return errs
+}
+
+/*
+Validate a document with xmllint, when it is installed, as a check on the interpreter above.
+*/
+func xmllint(t *testing.T, schema string, doc string) []error {
+	path, err := exec.LookPath("xmllint")
+	if err != nil {
+		return nil
+	}
+
+	cmd := exec.Command(path, "--noout", "--nonet", "--schema", schema, "-")
+	cmd.Stdin = strings.NewReader(doc)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return []error{fmt.Errorf("xmllint: %v: %s", err, out)}
+	}
+
+	return nil
+}
+
+var (
+	ncName   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	dateTime = regexp.MustCompile(`^-?\d{4,}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?$`)
+)
+
+type node struct {
+	name     xml.Name
+	attrs    map[string]string
+	children []*node
+	text     string
+}
+
+func parseNode(t *testing.T, doc string) *node {
+	d := xml.NewDecoder(strings.NewReader(doc))
+	var stack []*node
+	var root *node
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			break
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: tok.Name, attrs: make(map[string]string)}
+			for _, a := range tok.Attr {
+				if a.Name.Space == "" {
+					n.attrs[a.Name.Local] = a.Value
+				}
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(tok)
+			}
+		}
+	}
+
+	assert.NotNil(t, root, "document has no root element")
+	return root
+}
+
+func checkValue(kind string, value string) error {
+	switch kind {
+	case "ID", "NCName":
+		if !ncName.MatchString(value) {
+			return fmt.Errorf("%q is not a valid xs:ID", value)
+		}
+	case "dateTime":
+		if !dateTime.MatchString(value) {
+			return fmt.Errorf("%q is not a valid xs:dateTime", value)
+		}
+	case "anyURI":
+		if strings.ContainsAny(value, " \t\n") {
+			return fmt.Errorf("%q is not a valid xs:anyURI", value)
+		}
+	case "base64Binary":
+		if _, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("%q is not valid xs:base64Binary", value)
+		}
+	case "integer", "nonNegativeInteger":
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || (kind == "nonNegativeInteger" && n < 0) {
+			return fmt.Errorf("%q is not a valid xs:%s", value, kind)
+		}
+	}
+
+	return nil
+}
+
+func TestAssertionSchema(t *testing.T) {
+	_, done := configureStubTokenServer(t, nil)
+	defer done()
+
+	a, err := newSignedAssertion(SessionConfiguration, SessionConfiguration.CustomerId, time.Now())
+	assert.NoError(t, err)
+
+	// The schema types Version as a string; SAML 2.0 requires it to be "2.0".
+	assert.Contains(t, a.String(), `Version="2.0"`)
+
+	for _, e := range validateDocument(t, a.String()) {
+		t.Error(e)
+	}
+}
+
+func TestSignedInfoSchema(t *testing.T) {
+	si := &SignedInfo{RefId: "_abc", Digest: base64.StdEncoding.EncodeToString([]byte("digest"))}
+
+	for _, e := range validateDocument(t, si.String()) {
+		t.Error(e)
+	}
+}
+
+func TestSchemaValidatorRejectsInvalidAssertion(t *testing.T) {
+	doc := `<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion" ID="1abc" Version="2.0"><saml2:Subject></saml2:Subject><saml2:Issuer>x</saml2:Issuer></saml2:Assertion>`
+
+	s := loadSchema(t, schemaFiles[samlNS])
+	errs := s.validate(parseNode(t, doc), s.elements[xml.Name{Space: samlNS, Local: "Assertion"}])
+	assert.Equal(t, 4, len(errs), fmt.Sprint(errs))
+
+	// xmllint, when installed, rejects it too.
+	if _, err := exec.LookPath("xmllint"); err == nil {
+		assert.NotEmpty(t, xmllint(t, schemaFiles[samlNS], doc))
+	}
+}
+
+func TestAssertionAudienceAndRecipient(t *testing.T) {
+	_, done := configureStubTokenServer(t, nil)
+	defer done()
+
+	a, err := newSignedAssertion(SessionConfiguration, SessionConfiguration.CustomerId, time.Now())
+	assert.NoError(t, err)
+	assert.Contains(t, a.String(), "<saml2:Audience>provider</saml2:Audience>")
+	assert.NotContains(t, a.String(), "SubjectConfirmationData")
+
+	SessionConfiguration.SamlAudience = "https://saml.example.com/audience"
+	SessionConfiguration.SamlRecipient = "https://saml.example.com/acs?app=1&env=test"
+	a, err = newSignedAssertion(SessionConfiguration, SessionConfiguration.CustomerId, time.Now())
+	assert.NoError(t, err)
+	assert.Contains(t, a.String(), "<saml2:Audience>https://saml.example.com/audience</saml2:Audience>")
+	assert.Contains(t, a.String(), `Recipient="https://saml.example.com/acs?app=1&amp;env=test"`)
+
+	for _, e := range validateDocument(t, a.String()) {
+		t.Error(e)
+	}
+}
@@ -1,12 +1,225 @@
 package intuit
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
 )
 
-func TestSaml(t *testing.T) {
+func configureStubTokenServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, func()) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	f, err := ioutil.TempFile("", "intuit-key")
+	assert.NoError(t, err)
+	pem.Encode(f, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	f.Close()
+
+	server := httptest.NewServer(handler)
+	previous := SessionConfiguration
+	Configure(&Configuration{
+		CustomerId:       "customer",
+		OAuthConsumerKey: "consumer",
+		SamlProviderId:   "provider",
+		CertificatePath:  f.Name(),
+		TokenURL:         server.URL,
+	})
+
+	return server, func() {
+		server.Close()
+		os.Remove(f.Name())
+		SessionConfiguration = previous
+	}
+}
+
+func TestSamlTokenExchange(t *testing.T) {
+	_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "consumer", r.FormValue("oauth_consumer_key"))
+		assert.NotEmpty(t, r.FormValue("saml_assertion"))
+		w.Write([]byte("oauth_token=token&oauth_token_secret=secret"))
+	})
+	defer done()
+
 	token, err := MakeSamlAssertion()
 	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
+	assert.Equal(t, "token", token.Token)
+	assert.Equal(t, "secret", token.Secret)
+}
+
+func TestSamlTokenExchangeRejected(t *testing.T) {
+	_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Www-Authenticate", "OAuth%20oauth_problem%3D%22signature_invalid%22")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("assertion signature invalid"))
+	})
+	defer done()
+
+	token, err := MakeSamlAssertion()
+	assert.Nil(t, token)
+
+	apiError, ok := err.(*APIError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, apiError.StatusCode)
+	assert.Contains(t, apiError.Error(), `oauth_problem="signature_invalid"`)
+	assert.Contains(t, apiError.Error(), "assertion signature invalid")
+}
+
+func TestSamlTokenExchangeIncomplete(t *testing.T) {
+	for _, body := range []string{"oauth_token=token", "oauth_token_secret=secret", "oauth_token=&oauth_token_secret=secret", ""} {
+		_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		})
+
+		token, err := MakeSamlAssertionContext(context.Background())
+		assert.Nil(t, token, body)
+		decodeError, ok := err.(*DecodeError)
+		if assert.True(t, ok, body) {
+			assert.Contains(t, decodeError.Error(), "missing oauth_token", body)
+		}
+
+		done()
+	}
+}
+
+func TestSamlTokenExchangeUnreachable(t *testing.T) {
+	server, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	defer done()
+	server.Close()
+
+	token, err := MakeSamlAssertion()
+	assert.Nil(t, token)
+
+	_, ok := err.(*TransportError)
+	assert.True(t, ok)
+}
+
+func TestRequireAuthenticate(t *testing.T) {
+	_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("oauth_token=token&oauth_token_secret=secret"))
+	})
+	defer done()
+	SessionConfiguration.RequireAuthenticate = true
+
+	_, err := Do(GET, "accounts", nil, nil, nil)
+	assert.Equal(t, ErrNotAuthenticated, err)
+
+	assert.NoError(t, Authenticate(context.Background()))
+	assert.Equal(t, "token", SessionConfiguration.token("customer").Token)
+}
+
+func TestAssertionIdGenerator(t *testing.T) {
+	_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assertion, _ := base64.URLEncoding.DecodeString(r.FormValue("saml_assertion"))
+		assert.Contains(t, string(assertion), `ID='_fixed'`)
+		assert.Contains(t, string(assertion), `URI="#_fixed"`)
+		w.Write([]byte("oauth_token=token&oauth_token_secret=secret"))
+	})
+	defer done()
+
+	SessionConfiguration.AssertionIdGenerator = func() (string, error) {
+		return "_fixed", nil
+	}
+	_, err := MakeSamlAssertion()
+	assert.NoError(t, err)
+
+	SessionConfiguration.AssertionIdGenerator = func() (string, error) {
+		return "_00000000000000000000000000000000", nil
+	}
+	_, err = MakeSamlAssertion()
+	assert.Error(t, err)
+
+	SessionConfiguration.AssertionIdGenerator = func() (string, error) {
+		return "", errors.New("entropy exhausted")
+	}
+	_, err = MakeSamlAssertion()
+	assert.EqualError(t, err, "entropy exhausted")
+}
+
+func writeCertificate(t *testing.T, key *rsa.PrivateKey) string {
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	f, err := ioutil.TempFile("", "intuit-cert")
+	assert.NoError(t, err)
+	pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	f.Close()
+
+	return f.Name()
+}
+
+func TestAssertionCertificateMismatch(t *testing.T) {
+	requests := 0
+	_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("oauth_token=token&oauth_token_secret=secret"))
+	})
+	defer done()
+
+	key, err := loadPrivateKey(SessionConfiguration.CertificatePath)
+	assert.NoError(t, err)
+	SessionConfiguration.PublicCertificatePath = writeCertificate(t, key)
+	defer os.Remove(SessionConfiguration.PublicCertificatePath)
+
+	_, err = MakeSamlAssertion()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	other, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+	mismatched := writeCertificate(t, other)
+	defer os.Remove(mismatched)
+	SessionConfiguration.PublicCertificatePath = mismatched
+
+	_, err = MakeSamlAssertion()
+	assert.Equal(t, ErrCertificateMismatch, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestAuthenticateBadKey(t *testing.T) {
+	requests := 0
+	_, done := configureStubTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+	defer done()
+
+	SessionConfiguration.CertificatePath = "/nonexistent.key"
+	err := Authenticate(context.Background())
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	f, err := ioutil.TempFile("", "intuit-bad-key")
+	assert.NoError(t, err)
+	f.WriteString("not a key")
+	f.Close()
+	defer os.Remove(f.Name())
+
+	SessionConfiguration.CertificatePath = f.Name()
+	err = Authenticate(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not PEM-encoded")
+	assert.Equal(t, 0, requests)
+}
+
+func TestNewUUId(t *testing.T) {
+	id, err := newUUId()
+	assert.NoError(t, err)
+	assert.Equal(t, 33, len(id))
+	assert.Equal(t, "_", id[:1])
+	assert.Equal(t, "4", id[13:14])
+
+	other, _ := newUUId()
+	assert.NotEqual(t, id, other)
 }
@@ -0,0 +1,208 @@
+/*
+Build datasets of institution details, such as credential keys, by fetching the details of every institution in the institution list.
+
+	f, _ := os.OpenFile("institutions.jsonl", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	done, _ := scrape.Completed(f)
+
+	s := &scrape.Scraper{Concurrency: 4, Interval: 250 * time.Millisecond, Skip: done}
+	err := s.Run(context.Background(), f)
+
+Each institution is written as a line of JSON as soon as it is fetched, so an interrupted run resumes by skipping the institutions already in the output.
+*/
+package scrape
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/MattNewberry/intuit"
+	"io"
+	"sync"
+	"time"
+)
+
+const DefaultConcurrency = 2
+
+/*
+Scraper fetches institution details politely: with bounded concurrency, a minimum interval between requests and retries for failures.
+*/
+type Scraper struct {
+	// Number of institutions fetched concurrently. Defaults to DefaultConcurrency.
+	Concurrency int
+
+	// Minimum time between the start of consecutive requests, across all workers.
+	Interval time.Duration
+
+	// Additional attempts for an institution whose details could not be fetched, waiting Interval between them.
+	Retries int
+
+	// Institution Ids to skip, typically those already written by an earlier run. See Completed.
+	Skip map[string]bool
+
+	// Return the Ids of every institution. Defaults to the Ids in intuit.Institutions.
+	List func() ([]string, error)
+
+	// Fetch an institution's details. Defaults to intuit.Institution.
+	Fetch func(institutionId string) (*intuit.InstitutionDetails, error)
+}
+
+/*
+Return the Ids of the institutions already written to a JSONL dataset. Truncated trailing lines, as left by an interrupted run, are ignored.
+*/
+func Completed(r io.Reader) (map[string]bool, error) {
+	done := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var i intuit.InstitutionDetails
+		if err := json.Unmarshal(scanner.Bytes(), &i); err == nil && i.InstitutionId != "" {
+			done[i.InstitutionId.String()] = true
+		}
+	}
+
+	return done, scanner.Err()
+}
+
+/*
+Fetch every institution not in Skip, writing each as a line of JSON to w. Individual failures do not stop the run and are reported together as an *intuit.BatchError; failed institutions are not written, so a later run retries them. The run stops early only when the context is cancelled, the list cannot be fetched or w fails.
+*/
+func (s *Scraper) Run(ctx context.Context, w io.Writer) error {
+	ids, err := s.list()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var tick <-chan time.Time
+	if s.Interval > 0 {
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	wait := func() bool {
+		if tick == nil {
+			return ctx.Err() == nil
+		}
+		select {
+		case <-tick:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var (
+		mutex    sync.Mutex
+		wg       sync.WaitGroup
+		failed   = intuit.NewBatchError("scrape institutions", 0)
+		writeErr error
+	)
+
+	type item struct {
+		index int
+		id    string
+	}
+	queue := make(chan item)
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for next := range queue {
+				id := next.id
+				var details *intuit.InstitutionDetails
+				var err error
+				for attempt := 0; attempt <= s.Retries && wait(); attempt++ {
+					if details, err = s.fetch(id); err == nil {
+						break
+					}
+				}
+				if ctx.Err() != nil {
+					continue
+				}
+
+				mutex.Lock()
+				if err != nil {
+					failed.Add(next.index, id, err)
+				} else if details != nil && writeErr == nil {
+					if writeErr = writeLine(w, details); writeErr != nil {
+						cancel()
+					}
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for i, id := range ids {
+		if s.Skip[id] {
+			continue
+		}
+		failed.Total++
+
+		select {
+		case queue <- item{i, id}:
+			continue
+		case <-ctx.Done():
+		}
+		break
+	}
+	close(queue)
+	wg.Wait()
+
+	switch {
+	case writeErr != nil:
+		return writeErr
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case failed.Err() != nil:
+		return failed
+	}
+
+	return nil
+}
+
+func writeLine(w io.Writer, details *intuit.InstitutionDetails) error {
+	b, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func (s *Scraper) list() ([]string, error) {
+	if s.List != nil {
+		return s.List()
+	}
+
+	all, err := intuit.Institutions()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(all))
+	for _, i := range all {
+		ids = append(ids, i.InstitutionId.String())
+	}
+
+	return ids, nil
+}
+
+func (s *Scraper) fetch(institutionId string) (*intuit.InstitutionDetails, error) {
+	if s.Fetch != nil {
+		return s.Fetch(institutionId)
+	}
+
+	return intuit.Institution(institutionId)
+}
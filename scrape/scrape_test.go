@@ -0,0 +1,80 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testScraper(fail map[string]int) (*Scraper, *sync.Map) {
+	var mutex sync.Mutex
+	calls := &sync.Map{}
+
+	return &Scraper{
+		Concurrency: 3,
+		Retries:     1,
+		List: func() ([]string, error) {
+			return []string{"1", "2", "3", "4", "5"}, nil
+		},
+		Fetch: func(id string) (*intuit.InstitutionDetails, error) {
+			n, _ := calls.LoadOrStore(id, 0)
+			calls.Store(id, n.(int)+1)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if fail[id] > 0 {
+				fail[id]--
+				return nil, errors.New("service unavailable")
+			}
+			return &intuit.InstitutionDetails{InstitutionId: json.Number(id), InstitutionName: "Bank " + id}, nil
+		},
+	}, calls
+}
+
+func TestScrape(t *testing.T) {
+	s, calls := testScraper(map[string]int{"2": 1, "4": 5})
+
+	var out bytes.Buffer
+	err := s.Run(context.Background(), &out)
+
+	failed, ok := err.(*intuit.BatchError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"4"}, failed.Ids())
+	assert.Equal(t, 3, failed.Errors[0].Index)
+
+	n, _ := calls.Load("2")
+	assert.Equal(t, 2, n)
+	n, _ = calls.Load("4")
+	assert.Equal(t, 2, n)
+
+	done, err := Completed(strings.NewReader(out.String() + `{"institutionId": "9`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"1": true, "2": true, "3": true, "5": true}, done)
+
+	// Resume, fetching only the institution which failed.
+	s, calls = testScraper(nil)
+	s.Skip = done
+	assert.NoError(t, s.Run(context.Background(), &out))
+
+	_, fetched := calls.Load("1")
+	assert.False(t, fetched)
+	n, _ = calls.Load("4")
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 5, strings.Count(out.String(), "\n"))
+}
+
+func TestScrapeInterval(t *testing.T) {
+	s, _ := testScraper(nil)
+	s.Interval = 20 * time.Millisecond
+
+	start := time.Now()
+	assert.NoError(t, s.Run(context.Background(), &bytes.Buffer{}))
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+}
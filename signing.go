@@ -0,0 +1,259 @@
+package intuit
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+SignatureMethod selects how OAuth requests to the API are signed.
+*/
+type SignatureMethod string
+
+const (
+	HMACSHA1  SignatureMethod = "HMAC-SHA1"
+	RSASHA1   SignatureMethod = "RSA-SHA1"
+	PLAINTEXT SignatureMethod = "PLAINTEXT"
+)
+
+/*
+AccessToken is an OAuth 1.0 token and its secret, as obtained through the SAML exchange. Its fields match those of the previously used github.com/MattNewberry/oauth package.
+*/
+type AccessToken struct {
+	Token          string
+	Secret         string
+	AdditionalData map[string]string
+}
+
+/*
+Signs API requests with OAuth 1.0 using the configured signature method.
+*/
+type signer struct {
+	method         SignatureMethod
+	consumerKey    string
+	consumerSecret string
+	token          *AccessToken
+	privateKey     *rsa.PrivateKey
+	now            func() time.Time
+	nonce          func() (string, error)
+	bodyHash       bool
+}
+
+func newSigner(configuration *Configuration, token *AccessToken) (*signer, error) {
+	s := &signer{
+		method:         configuration.SignatureMethod,
+		consumerKey:    configuration.OAuthConsumerKey,
+		consumerSecret: configuration.OAuthConsumerSecret,
+		token:          token,
+		now:            configuration.Clock,
+		nonce:          configuration.NonceGenerator,
+		bodyHash:       configuration.OAuthBodyHash,
+	}
+
+	if s.token == nil {
+		s.token = &AccessToken{}
+	}
+
+	switch s.method {
+	case "":
+		s.method = HMACSHA1
+	case HMACSHA1, PLAINTEXT:
+	case RSASHA1:
+		key, err := loadPrivateKey(configuration.CertificatePath)
+		if err != nil {
+			return nil, err
+		}
+		s.privateKey = key
+	default:
+		return nil, fmt.Errorf("intuit: unsupported signature method %q", s.method)
+	}
+
+	return s, nil
+}
+
+/*
+Add an OAuth Authorization header to the request.
+*/
+func (s *signer) sign(req *http.Request) error {
+	now, generate := s.now, s.nonce
+	if now == nil {
+		now = time.Now
+	}
+	if generate == nil {
+		generate = RandomNonce
+	}
+
+	nonce, err := generate()
+	if err != nil {
+		return err
+	}
+
+	params := map[string]string{
+		"oauth_consumer_key":     oauthEscape(s.consumerKey),
+		"oauth_nonce":            oauthEscape(nonce),
+		"oauth_signature_method": string(s.method),
+		"oauth_timestamp":        strconv.FormatInt(now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+
+	if s.token.Token != "" {
+		params["oauth_token"] = oauthEscape(s.token.Token)
+	}
+
+	if s.bodyHash {
+		hash, err := bodyHash(req)
+		if err != nil {
+			return err
+		}
+		params["oauth_body_hash"] = oauthEscape(hash)
+	}
+
+	signature, err := s.signature(signatureBaseString(req, params))
+	if err != nil {
+		return err
+	}
+	params["oauth_signature"] = oauthEscape(signature)
+
+	req.Header.Set("Authorization", oauthHeader(params))
+	return nil
+}
+
+func (s *signer) signature(base string) (string, error) {
+	key := oauthEscape(s.consumerSecret) + "&" + oauthEscape(s.token.Secret)
+
+	switch s.method {
+	case RSASHA1:
+		digest := sha1.Sum([]byte(base))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA1, digest[:])
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(signature), nil
+	case PLAINTEXT:
+		return key, nil
+	}
+
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+/*
+Return the oauth_body_hash of a request: the base64 SHA-1 digest of its body, which is empty for requests without one. The body is restored so it can still be sent.
+*/
+func bodyHash(req *http.Request) (string, error) {
+	var b []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if b, err = ioutil.ReadAll(req.Body); err != nil {
+			return "", err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	digest := sha1.Sum(b)
+	return base64.StdEncoding.EncodeToString(digest[:]), nil
+}
+
+/*
+Return a nonce of 128 random bits from crypto/rand, hex encoded. Nonces are unique with overwhelming probability, so a replayed request can always be recognized.
+*/
+func RandomNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+/*
+Return a nonce generator producing strictly increasing nonces, for servers which require them: the current time in nanoseconds, bumped past the previous nonce when the clock has not advanced. Nonces are decimal and of equal length, so they also sort as strings.
+*/
+func MonotonicNonce() func() (string, error) {
+	var (
+		mutex sync.Mutex
+		last  int64
+	)
+
+	return func() (string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		n := time.Now().UnixNano()
+		if n <= last {
+			n = last + 1
+		}
+		last = n
+
+		return fmt.Sprintf("%020d", n), nil
+	}
+}
+
+func oauthHeader(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, k, params[k])
+	}
+
+	return "OAuth " + strings.Join(pairs, ",")
+}
+
+/*
+Build the OAuth 1.0 signature base string from the request and its (percent-encoded) OAuth parameters.
+*/
+func signatureBaseString(req *http.Request, oauthParams map[string]string) string {
+	pairs := make([]string, 0)
+	for k, v := range oauthParams {
+		if k == "oauth_signature" {
+			continue
+		}
+		pairs = append(pairs, k+"="+v)
+	}
+
+	for k, vs := range req.URL.Query() {
+		for _, v := range vs {
+			pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+	sort.Strings(pairs)
+
+	u := url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host, Path: req.URL.Path}
+	return req.Method + "&" + oauthEscape(u.String()) + "&" + oauthEscape(strings.Join(pairs, "&"))
+}
+
+func oauthEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
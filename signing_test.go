@@ -0,0 +1,135 @@
+package intuit
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signedRequest(t *testing.T, s *signer) (*http.Request, map[string]string) {
+	req, _ := http.NewRequest(DELETE, BaseURL+"accounts?b=2&a=1", strings.NewReader("<body/>"))
+	assert.NoError(t, s.sign(req))
+
+	return req, parseOAuthHeader(req.Header.Get("Authorization"))
+}
+
+func parseOAuthHeader(header string) map[string]string {
+	params := make(map[string]string)
+	for _, pair := range strings.Split(strings.TrimPrefix(header, "OAuth "), ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		params[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+
+	return params
+}
+
+func unescape(t *testing.T, s string) string {
+	u, err := url.QueryUnescape(s)
+	assert.NoError(t, err)
+	return u
+}
+
+func TestHMACSignature(t *testing.T) {
+	s := &signer{method: HMACSHA1, consumerKey: "key", consumerSecret: "consumer secret", token: &AccessToken{Token: "token", Secret: "token secret"}}
+	req, params := signedRequest(t, s)
+
+	assert.Equal(t, "HMAC-SHA1", params["oauth_signature_method"])
+	assert.Equal(t, "key", params["oauth_consumer_key"])
+	assert.Equal(t, "token", params["oauth_token"])
+
+	mac := hmac.New(sha1.New, []byte("consumer%20secret&token%20secret"))
+	mac.Write([]byte(signatureBaseString(req, params)))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), unescape(t, params["oauth_signature"]))
+}
+
+func TestPlaintextSignature(t *testing.T) {
+	_, params := signedRequest(t, &signer{method: PLAINTEXT, consumerSecret: "consumer secret", token: &AccessToken{Secret: "token"}})
+
+	assert.Equal(t, "PLAINTEXT", params["oauth_signature_method"])
+	assert.Equal(t, "consumer%2520secret%26token", params["oauth_signature"])
+}
+
+func TestRSASignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	req, params := signedRequest(t, &signer{method: RSASHA1, consumerKey: "key", privateKey: key, token: &AccessToken{Token: "token"}})
+	assert.Equal(t, "RSA-SHA1", params["oauth_signature_method"])
+
+	base := signatureBaseString(req, params)
+	assert.True(t, strings.HasPrefix(base, "DELETE&https%3A%2F%2Ffinancialdatafeed.platform.intuit.com%2Fv1%2Faccounts&a%3D1%26b%3D2%26oauth_consumer_key%3Dkey%26oauth_nonce%3D"), base)
+
+	signature, _ := base64.StdEncoding.DecodeString(unescape(t, params["oauth_signature"]))
+	digest := sha1.Sum([]byte(base))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, digest[:], signature))
+}
+
+func TestSigningClockAndNonce(t *testing.T) {
+	s := &signer{
+		method: HMACSHA1,
+		token:  &AccessToken{},
+		now:    func() time.Time { return time.Unix(1400000000, 0) },
+		nonce:  func() (string, error) { return "fixed nonce", nil },
+	}
+	_, params := signedRequest(t, s)
+
+	assert.Equal(t, "1400000000", params["oauth_timestamp"])
+	assert.Equal(t, "fixed%20nonce", params["oauth_nonce"])
+
+	_, again := signedRequest(t, s)
+	assert.Equal(t, params["oauth_signature"], again["oauth_signature"])
+
+	s.nonce = func() (string, error) { return "", errors.New("exhausted") }
+	req, _ := http.NewRequest(GET, BaseURL+"accounts", nil)
+	assert.Error(t, s.sign(req))
+}
+
+func TestNonceGenerators(t *testing.T) {
+	a, err := RandomNonce()
+	assert.NoError(t, err)
+	b, _ := RandomNonce()
+	assert.Equal(t, 32, len(a))
+	assert.NotEqual(t, a, b)
+
+	next := MonotonicNonce()
+	previous, _ := next()
+	for i := 0; i < 1000; i++ {
+		n, _ := next()
+		assert.True(t, n > previous, "%s <= %s", n, previous)
+		previous = n
+	}
+}
+
+func TestBodyHash(t *testing.T) {
+	s := &signer{method: HMACSHA1, consumerSecret: "secret", token: &AccessToken{}, bodyHash: true}
+	req, params := signedRequest(t, s)
+
+	digest := sha1.Sum([]byte("<body/>"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(digest[:]), unescape(t, params["oauth_body_hash"]))
+	assert.Contains(t, signatureBaseString(req, params), "oauth_body_hash%3D")
+
+	// The body is still sent.
+	body, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, "<body/>", string(body))
+
+	// Requests without a body hash the empty string.
+	req, _ = http.NewRequest(GET, BaseURL+"accounts", nil)
+	assert.NoError(t, s.sign(req))
+	assert.Equal(t, "2jmj7l5rSw0yVb/vlWAYkK/YBwk=", unescape(t, parseOAuthHeader(req.Header.Get("Authorization"))["oauth_body_hash"]))
+
+	s.bodyHash = false
+	_, params = signedRequest(t, s)
+	_, ok := params["oauth_body_hash"]
+	assert.False(t, ok)
+}
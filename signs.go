@@ -0,0 +1,66 @@
+package intuit
+
+/*
+SignPolicy is the sign convention applied to transaction amounts as they are decoded. In raw CAD data, banking and investment transactions report money leaving the account as negative, while credit card and loan transactions report charges as positive and payments as negative, so the same purchase has opposite signs depending on how it was paid.
+*/
+type SignPolicy string
+
+const (
+	// Leave amounts as Intuit sent them. The default.
+	SignAsIs SignPolicy = "AS_IS"
+
+	// Debits, such as purchases, withdrawals and fees, are negative and credits positive, on every account type.
+	SignDebitNegative SignPolicy = "DEBIT_NEGATIVE"
+
+	// Debits are positive and credits negative, on every account type.
+	SignDebitPositive SignPolicy = "DEBIT_POSITIVE"
+)
+
+/*
+Account types whose raw transactions report debits as positive amounts, named as in their transaction list keys (creditCardTransactions, etc.).
+*/
+var debitPositiveAccountTypes = map[string]bool{
+	"creditCard": true,
+	"loan":       true,
+}
+
+/*
+Return whether the transaction takes money from the customer: a purchase, withdrawal or fee on a deposit account, or a charge on a credit card or loan. A DEBIT or CREDIT type decides it; otherwise it is inferred from the sign of the amount as sent for the transaction's AccountType, so it must be called on an amount as Intuit sent it.
+*/
+func (t Transaction) IsDebit() bool {
+	switch t.Type {
+	case TransactionDebit:
+		return true
+	case TransactionCredit:
+		return false
+	}
+
+	if debitPositiveAccountTypes[t.AccountType] {
+		return t.Amount.Sign() > 0
+	}
+	return t.Amount.Sign() < 0
+}
+
+/*
+Return the transaction with its amount signed according to the policy. The transaction's amount must be as Intuit sent it; see IsDebit. Unknown policies leave the amount as is.
+*/
+func (p SignPolicy) Apply(t Transaction) Transaction {
+	magnitude := t.Amount.Abs()
+
+	switch p {
+	case SignDebitNegative:
+		if t.IsDebit() {
+			t.Amount = magnitude.Neg()
+		} else {
+			t.Amount = magnitude
+		}
+	case SignDebitPositive:
+		if t.IsDebit() {
+			t.Amount = magnitude
+		} else {
+			t.Amount = magnitude.Neg()
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,90 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSignPolicy(t *testing.T) {
+	cases := []struct {
+		name     string
+		txn      Transaction
+		debit    bool
+		negative string
+		positive string
+	}{
+		{"banking purchase", Transaction{AccountType: "banking", Amount: MustParseDecimal("-12.5")}, true, "-12.5", "12.5"},
+		{"banking deposit", Transaction{AccountType: "banking", Amount: MustParseDecimal("100")}, false, "100", "-100"},
+		{"credit card purchase", Transaction{AccountType: "creditCard", Amount: MustParseDecimal("40")}, true, "-40", "40"},
+		{"credit card payment", Transaction{AccountType: "creditCard", Amount: MustParseDecimal("-250")}, false, "250", "-250"},
+		{"credit card refund", Transaction{AccountType: "creditCard", Amount: MustParseDecimal("-15.99"), Type: TransactionCredit}, false, "15.99", "-15.99"},
+		{"loan interest", Transaction{AccountType: "loan", Amount: MustParseDecimal("31.2"), Type: TransactionInterest}, true, "-31.2", "31.2"},
+		{"loan payment", Transaction{AccountType: "loan", Amount: MustParseDecimal("-500")}, false, "500", "-500"},
+		{"investment dividend", Transaction{AccountType: "investment", Amount: MustParseDecimal("8.75"), Type: TransactionDividend}, false, "8.75", "-8.75"},
+		{"investment banking fee", Transaction{AccountType: "investmentBanking", Amount: MustParseDecimal("-2")}, true, "-2", "2"},
+		{"unknown account withdrawal", Transaction{Amount: MustParseDecimal("-60")}, true, "-60", "60"},
+
+		// A DEBIT or CREDIT type wins over an amount sent with an unexpected sign.
+		{"banking debit sent positive", Transaction{AccountType: "banking", Amount: MustParseDecimal("20"), Type: TransactionDebit}, true, "-20", "20"},
+		{"credit card credit sent positive", Transaction{AccountType: "creditCard", Amount: MustParseDecimal("20"), Type: TransactionCredit}, false, "20", "-20"},
+
+		{"zero amount", Transaction{AccountType: "creditCard", Amount: MustParseDecimal("0")}, false, "0", "0"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.debit, c.txn.IsDebit(), c.name)
+		assert.Equal(t, c.txn.Amount, SignAsIs.Apply(c.txn).Amount, c.name)
+		assert.Equal(t, c.txn.Amount, SignPolicy("").Apply(c.txn).Amount, c.name)
+		assert.Equal(t, c.negative, SignDebitNegative.Apply(c.txn).Amount.String(), c.name)
+		assert.Equal(t, c.positive, SignDebitPositive.Apply(c.txn).Amount.String(), c.name)
+
+		// Only the amount changes.
+		signed := SignDebitPositive.Apply(c.txn)
+		signed.Amount = c.txn.Amount
+		assert.Equal(t, c.txn, signed, c.name)
+	}
+}
+
+func TestTransactionsAmountSigns(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/1/transactions":
+			w.Write([]byte(`{"bankingTransactions": [
+				{"id": 1, "amount": -12.5, "payeeName": "Coffee"},
+				{"id": 2, "amount": 100, "payeeName": "Payroll"}
+			]}`))
+		case "/accounts/2/transactions":
+			w.Write([]byte(`{"creditCardTransactions": [
+				{"id": 3, "amount": 40, "payeeName": "Books"},
+				{"id": 4, "amount": -27.5, "payeeName": "Payment"}
+			]}`))
+		}
+	})
+	defer done()
+
+	start := time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC)
+	amounts := func() []string {
+		list := make([]string, 0)
+		for _, id := range []string{"1", "2"} {
+			transactions, err := Transactions(id, start, start.AddDate(0, 0, 29))
+			assert.NoError(t, err)
+			for _, txn := range transactions {
+				list = append(list, txn.Amount.String())
+			}
+		}
+		return list
+	}
+
+	assert.Equal(t, []string{"-12.5", "100", "40", "-27.5"}, amounts())
+
+	SessionConfiguration.AmountSigns = SignDebitNegative
+	assert.Equal(t, []string{"-12.5", "100", "-40", "27.5"}, amounts())
+
+	SessionConfiguration.AmountSigns = SignDebitPositive
+	assert.Equal(t, []string{"12.5", "-100", "40", "-27.5"}, amounts())
+
+	SessionConfiguration.AmountSigns = "SIDEWAYS"
+	assert.Contains(t, SessionConfiguration.Validate().Error(), `AmountSigns "SIDEWAYS" is not supported`)
+}
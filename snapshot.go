@@ -0,0 +1,162 @@
+package intuit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"time"
+)
+
+/*
+Snapshot is a point-in-time copy of everything stored for a customer.
+*/
+type Snapshot struct {
+	CustomerId string          `json:"customerId"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Logins     []LoginSnapshot `json:"logins"`
+}
+
+type LoginSnapshot struct {
+	LoginId       string            `json:"loginId"`
+	InstitutionId string            `json:"institutionId"`
+	Accounts      []AccountSnapshot `json:"accounts"`
+}
+
+type AccountSnapshot struct {
+	CustomerAccount
+	Transactions []Transaction `json:"transactions,omitempty"`
+}
+
+/*
+Take a snapshot of the scoped customer's logins and accounts. When q is non-nil, each account's transactions matching q are included.
+*/
+func CustomerSnapshot(q *TransactionQuery) (*Snapshot, error) {
+	return defaultClient().CustomerSnapshot(q)
+}
+
+/*
+Take a snapshot of the scoped customer's logins and accounts. See CustomerSnapshot.
+*/
+func (c *Client) CustomerSnapshot(q *TransactionQuery) (*Snapshot, error) {
+	logins, err := c.AccountsByLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := c.background()
+	snapshot := &Snapshot{CustomerId: customerFor(ctx), CreatedAt: time.Now().UTC()}
+
+	loginIds := make([]string, 0, len(logins))
+	for id := range logins {
+		loginIds = append(loginIds, id)
+	}
+	sort.Strings(loginIds)
+
+	for _, id := range loginIds {
+		accounts := logins[id]
+		login := LoginSnapshot{LoginId: id, InstitutionId: accounts[0].InstitutionId.String()}
+
+		for _, a := range accounts {
+			account := AccountSnapshot{CustomerAccount: a}
+
+			if q != nil {
+				if account.Transactions, err = collectTransactions(ctx, a.AccountId.String(), *q); err != nil {
+					return nil, err
+				}
+			}
+
+			login.Accounts = append(login.Accounts, account)
+		}
+
+		snapshot.Logins = append(snapshot.Logins, login)
+	}
+
+	return snapshot, nil
+}
+
+/*
+Write a snapshot of the scoped customer as a single JSON document, for backups before DeleteCustomer or migrating to another provider. When q is non-nil, transactions matching q are included.
+*/
+func ExportCustomerSnapshot(w io.Writer, q *TransactionQuery) error {
+	return defaultClient().ExportCustomerSnapshot(w, q)
+}
+
+/*
+Write a snapshot of the scoped customer as a single JSON document. See ExportCustomerSnapshot.
+*/
+func (c *Client) ExportCustomerSnapshot(w io.Writer, q *TransactionQuery) error {
+	snapshot, err := c.CustomerSnapshot(q)
+	if err != nil {
+		return err
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(snapshot)
+}
+
+/*
+Save a snapshot to the configured KV under its customer, replacing the customer's previous snapshot, for diffing against later with DiffSnapshots.
+*/
+func SaveSnapshot(s *Snapshot) error {
+	return defaultClient().SaveSnapshot(s)
+}
+
+/*
+Save a snapshot to the client's KV. See SaveSnapshot.
+*/
+func (c *Client) SaveSnapshot(s *Snapshot) error {
+	kv := c.Configuration().kv()
+	if kv == nil {
+		return errors.New("intuit: saving snapshot: no KV configured")
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return kv.Set(c.Configuration().snapshotKey(s.CustomerId), b, 0)
+}
+
+/*
+Return the snapshot last saved for a customer with SaveSnapshot, or ErrKeyNotFound.
+*/
+func LoadSnapshot(customerId string) (*Snapshot, error) {
+	return defaultClient().LoadSnapshot(customerId)
+}
+
+/*
+Return the snapshot last saved for a customer in the client's KV. See LoadSnapshot.
+*/
+func (c *Client) LoadSnapshot(customerId string) (*Snapshot, error) {
+	kv := c.Configuration().kv()
+	if kv == nil {
+		return nil, errors.New("intuit: loading snapshot: no KV configured")
+	}
+
+	b, err := kv.Get(c.Configuration().snapshotKey(customerId))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	return snapshot, json.Unmarshal(b, snapshot)
+}
+
+func (c *Configuration) snapshotKey(customerId string) string {
+	return "snapshot/" + c.consumerKey() + "/" + customerId
+}
+
+func collectTransactions(ctx context.Context, accountId string, q TransactionQuery) ([]Transaction, error) {
+	transactions, errs := TransactionsChan(ctx, accountId, q)
+
+	all := make([]Transaction, 0)
+	for t := range transactions {
+		all = append(all, t)
+	}
+
+	return all, <-errs
+}
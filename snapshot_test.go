@@ -0,0 +1,72 @@
+package intuit
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExportCustomerSnapshot(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts":
+			w.Write([]byte(`{"accounts": [
+				{"accountId": 2, "institutionId": 100000, "institutionLoginId": 20, "accountNickname": "Savings", "balanceAmount": 2500, "bankingAccountType": "SAVINGS"},
+				{"accountId": 1, "institutionId": 100000, "institutionLoginId": 10, "accountNickname": "Checking", "balanceAmount": 120.5, "bankingAccountType": "CHECKING"}
+			]}`))
+		case "/accounts/1/transactions":
+			w.Write([]byte(`{"bankingTransactions": [{"id": 9001, "amount": -12.5, "payeeName": "Coffee", "postedDate": "2024-03-01"}]}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+	defer done()
+	SessionConfiguration.setToken("customer", &AccessToken{Token: "token", Secret: "secret"})
+	Scope("customer")
+
+	var buf bytes.Buffer
+	q := &TransactionQuery{Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)}
+	assert.NoError(t, ExportCustomerSnapshot(&buf, q))
+
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "customer", doc["customerId"])
+	_, err := time.Parse(time.RFC3339, doc["createdAt"].(string))
+	assert.NoError(t, err)
+
+	// Logins are ordered by Id, each holding its accounts.
+	logins := doc["logins"].([]interface{})
+	if !assert.Len(t, logins, 2) {
+		return
+	}
+	first := logins[0].(map[string]interface{})
+	assert.Equal(t, "10", first["loginId"])
+	assert.Equal(t, "100000", first["institutionId"])
+	assert.Equal(t, "20", logins[1].(map[string]interface{})["loginId"])
+
+	account := first["accounts"].([]interface{})[0].(map[string]interface{})
+	assert.EqualValues(t, 1, account["accountId"])
+	assert.Equal(t, "Checking", account["accountNickname"])
+	assert.Equal(t, "CHECKING", account["bankingAccountType"])
+
+	transactions := account["transactions"].([]interface{})
+	if assert.Len(t, transactions, 1) {
+		transaction := transactions[0].(map[string]interface{})
+		assert.EqualValues(t, 9001, transaction["id"])
+		assert.Equal(t, "Coffee", transaction["payeeName"])
+		assert.EqualValues(t, -12.5, transaction["amount"])
+	}
+
+	// The document reads back as a Snapshot.
+	var snapshot Snapshot
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &snapshot))
+	assert.Equal(t, MustParseDecimal("-12.5"), snapshot.Logins[0].Accounts[0].Transactions[0].Amount)
+
+	// Without a query, transactions are left out.
+	buf.Reset()
+	assert.NoError(t, ExportCustomerSnapshot(&buf, nil))
+	assert.NotContains(t, buf.String(), `"transactions"`)
+}
@@ -0,0 +1,77 @@
+package intuit
+
+import (
+	"context"
+	"strings"
+)
+
+/*
+AccountStatus is the state of an account at its institution.
+*/
+type AccountStatus string
+
+const (
+	AccountActive   AccountStatus = "ACTIVE"
+	AccountInactive AccountStatus = "INACTIVE"
+	AccountClosed   AccountStatus = "CLOSED"
+)
+
+/*
+Report whether the account has been closed at its institution.
+*/
+func (a CustomerAccount) IsClosed() bool {
+	return AccountStatus(strings.ToUpper(string(a.Status))) == AccountClosed
+}
+
+/*
+Return the accounts for which keep returns true.
+*/
+func FilterAccounts(accounts []CustomerAccount, keep func(CustomerAccount) bool) []CustomerAccount {
+	filtered := make([]CustomerAccount, 0)
+	for _, a := range accounts {
+		if keep(a) {
+			filtered = append(filtered, a)
+		}
+	}
+
+	return filtered
+}
+
+/*
+Return the scoped customer's accounts which have been closed at their institution.
+*/
+func ClosedAccounts() ([]CustomerAccount, error) {
+	return defaultClient().ClosedAccounts()
+}
+
+/*
+Return the scoped customer's accounts which have been closed at their institution.
+*/
+func (c *Client) ClosedAccounts() ([]CustomerAccount, error) {
+	return filteredAccounts(c.background(), CustomerAccount.IsClosed)
+}
+
+/*
+Return the scoped customer's accounts which have not been closed.
+*/
+func OpenAccounts() ([]CustomerAccount, error) {
+	return defaultClient().OpenAccounts()
+}
+
+/*
+Return the scoped customer's accounts which have not been closed.
+*/
+func (c *Client) OpenAccounts() ([]CustomerAccount, error) {
+	return filteredAccounts(c.background(), func(a CustomerAccount) bool {
+		return !a.IsClosed()
+	})
+}
+
+func filteredAccounts(ctx context.Context, keep func(CustomerAccount) bool) ([]CustomerAccount, error) {
+	list, err := Get[accountList](ctx, "accounts", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterAccounts(list.Accounts, keep), nil
+}
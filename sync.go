@@ -0,0 +1,128 @@
+package intuit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+/*
+How far before the latest synced transaction each sync fetches again, to catch transactions which institutions post late or backdate. Transactions already returned within the window are remembered by the cursor and not returned again.
+*/
+var SyncWindow = 14 * 24 * time.Hour
+
+/*
+The state encoded in a sync cursor.
+*/
+type syncCursor struct {
+	Version int `json:"v"`
+
+	// Posted date of the latest transaction synced.
+	Date string `json:"date"`
+
+	// Posted dates of the transactions synced within SyncWindow of Date, by Id.
+	Seen map[string]string `json:"seen"`
+}
+
+/*
+Return the account's transactions posted since the previous sync, along with the cursor to pass to the next. An empty cursor starts a sync with CAD's default range; cursors are opaque strings, safe to store.
+
+CAD can only filter transactions by date, so each sync fetches from SyncWindow before the latest synced transaction and leaves out those already returned. Pending transactions are not returned until they post. Corrections are returned like other transactions; apply them with ApplyCorrections.
+*/
+func SyncTransactions(accountId string, cursor string) ([]Transaction, string, error) {
+	return defaultClient().SyncTransactions(accountId, cursor)
+}
+
+/*
+Return the account's transactions posted since the previous sync, along with the cursor to pass to the next. See SyncTransactions.
+*/
+func (client *Client) SyncTransactions(accountId string, cursor string) ([]Transaction, string, error) {
+	c, err := parseSyncCursor(cursor)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	var q TransactionQuery
+	if c.Date != "" {
+		date, _ := time.Parse(transactionDateFormat, c.Date)
+		q.Start = date.Add(-SyncWindow)
+	}
+
+	fetched, err := collectTransactions(client.background(), accountId, q)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	transactions := make([]Transaction, 0)
+	for _, t := range fetched {
+		id := syncId(t)
+		if t.Pending || t.PostedDate.IsZero() || id == "" {
+			continue
+		}
+		if _, seen := c.Seen[id]; seen {
+			continue
+		}
+
+		date := t.PostedDate.Format(transactionDateFormat)
+		c.Seen[id] = date
+		if date > c.Date {
+			c.Date = date
+		}
+		transactions = append(transactions, t)
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].PostedDate.Before(transactions[j].PostedDate.Time)
+	})
+
+	// Forget transactions which have left the window, since they will not be fetched again.
+	latest, _ := time.Parse(transactionDateFormat, c.Date)
+	oldest := latest.Add(-SyncWindow).Format(transactionDateFormat)
+	for id, date := range c.Seen {
+		if date < oldest {
+			delete(c.Seen, id)
+		}
+	}
+
+	next, err := c.encode()
+	return transactions, next, err
+}
+
+func syncId(t Transaction) string {
+	if id := t.Id.String(); id != "" {
+		return id
+	}
+
+	return t.InstitutionTransactionId
+}
+
+func parseSyncCursor(cursor string) (*syncCursor, error) {
+	c := &syncCursor{Version: 1, Seen: make(map[string]string)}
+	if cursor == "" {
+		return c, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(b, c)
+	}
+	if err != nil || c.Version != 1 {
+		return nil, fmt.Errorf("intuit: invalid sync cursor %q", cursor)
+	}
+	if c.Seen == nil {
+		c.Seen = make(map[string]string)
+	}
+
+	return c, nil
+}
+
+func (c *syncCursor) encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,51 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestSyncTransactions(t *testing.T) {
+	var starts []string
+	body := `{"bankingTransactions": [
+		{"id": 2, "postedDate": "2014-06-20"},
+		{"id": 1, "postedDate": "2014-06-01"},
+		{"id": 3, "pending": true}
+	]}`
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		starts = append(starts, r.URL.Query().Get("txnStartDate"))
+		w.Write([]byte(body))
+	})
+	defer done()
+
+	transactions, cursor, err := SyncTransactions("1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(transactions))
+	assert.Equal(t, "1", transactions[0].Id.String())
+	assert.Equal(t, "", starts[0])
+
+	// The overlapping fetch returns only the late-posted transaction.
+	body = `{"bankingTransactions": [
+		{"id": 2, "postedDate": "2014-06-20"},
+		{"id": 4, "postedDate": "2014-06-19"},
+		{"id": 3, "postedDate": "2014-06-21"}
+	]}`
+	starts = nil
+	transactions, cursor, err = SyncTransactions("1", cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4", "3"}, []string{transactions[0].Id.String(), transactions[1].Id.String()})
+	assert.Equal(t, "2014-06-06", starts[0])
+
+	starts = nil
+	transactions, cursor, err = SyncTransactions("1", cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(transactions))
+	assert.Equal(t, "2014-06-07", starts[0])
+
+	c, _ := parseSyncCursor(cursor)
+	assert.Equal(t, map[string]string{"2": "2014-06-20", "4": "2014-06-19", "3": "2014-06-21"}, c.Seen)
+
+	_, _, err = SyncTransactions("1", "garbage")
+	assert.EqualError(t, err, `intuit: invalid sync cursor "garbage"`)
+}
@@ -0,0 +1,70 @@
+package intuit
+
+/*
+Intuit's test institution (DAG Site), available to every development application.
+*/
+const TestInstitutionId = "100000"
+
+/*
+TestScenario is a DAG Site username which triggers a canned login flow.
+*/
+type TestScenario string
+
+const (
+	TestScenarioSuccess         TestScenario = "direct"
+	TestScenarioTextChallenge   TestScenario = "tfa_text"
+	TestScenarioChoiceChallenge TestScenario = "tfa_choice"
+	TestScenarioImageChallenge  TestScenario = "tfa_image"
+)
+
+/*
+TestMode drives the test institution's canned flows during development, without needing real bank credentials.
+*/
+type TestMode struct {
+	InstitutionId string
+	Password      string
+
+	// Answer given to text and image challenges.
+	Answer string
+
+	// Client the flows are run with. Defaults to the client using SessionConfiguration.
+	Client *Client
+}
+
+/*
+Return a TestMode configured for the DAG Site.
+*/
+func NewTestMode() *TestMode {
+	return &TestMode{InstitutionId: TestInstitutionId, Password: "go", Answer: "test"}
+}
+
+/*
+Discover accounts at the test institution using the given scenario, returning the MFA challenge it forces, if any.
+*/
+func (t *TestMode) Discover(scenario TestScenario) ([]TypedAccount, *ChallengeSession, error) {
+	return t.client().DiscoverAndAddAccounts(t.InstitutionId, string(scenario), t.Password, "", "")
+}
+
+func (t *TestMode) client() *Client {
+	if t.Client != nil {
+		return t.Client
+	}
+
+	return defaultClient()
+}
+
+/*
+Answer every challenge in the session with the canned answer, choosing the first option for choice challenges, and respond with the client the session was started with.
+*/
+func (t *TestMode) Respond(session *ChallengeSession) (interface{}, error) {
+	session.Answers = make([]Answer, len(session.Challenges))
+	for i, c := range session.Challenges {
+		if c.Kind() == ChoiceChallenge {
+			session.Answers[i] = ChoiceAnswer(c.Choices[0])
+		} else {
+			session.Answers[i] = TextAnswer(t.Answer)
+		}
+	}
+
+	return respondToChallenge(session)
+}
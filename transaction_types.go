@@ -0,0 +1,76 @@
+package intuit
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+TransactionType is the kind of a transaction, as reported by the institution.
+*/
+type TransactionType string
+
+const (
+	TransactionCredit        TransactionType = "CREDIT"
+	TransactionDebit         TransactionType = "DEBIT"
+	TransactionInterest      TransactionType = "INT"
+	TransactionDividend      TransactionType = "DIV"
+	TransactionFee           TransactionType = "FEE"
+	TransactionServiceCharge TransactionType = "SRVCHG"
+	TransactionDeposit       TransactionType = "DEP"
+	TransactionATM           TransactionType = "ATM"
+	TransactionPointOfSale   TransactionType = "POS"
+	TransactionTransfer      TransactionType = "XFER"
+	TransactionCheck         TransactionType = "CHECK"
+	TransactionPayment       TransactionType = "PAYMENT"
+	TransactionCash          TransactionType = "CASH"
+	TransactionDirectDeposit TransactionType = "DIRECTDEP"
+	TransactionDirectDebit   TransactionType = "DIRECTDEBIT"
+	TransactionRepeatPayment TransactionType = "REPEATPMT"
+	TransactionOther         TransactionType = "OTHER"
+)
+
+var transactionTypes = []TransactionType{
+	TransactionCredit, TransactionDebit, TransactionInterest, TransactionDividend, TransactionFee, TransactionServiceCharge,
+	TransactionDeposit, TransactionATM, TransactionPointOfSale, TransactionTransfer, TransactionCheck, TransactionPayment,
+	TransactionCash, TransactionDirectDeposit, TransactionDirectDebit, TransactionRepeatPayment, TransactionOther,
+}
+
+/*
+Parse a transaction type, ignoring case.
+*/
+func ParseTransactionType(s string) (TransactionType, error) {
+	t := TransactionType(strings.ToUpper(strings.TrimSpace(s)))
+
+	for _, known := range transactionTypes {
+		if t == known {
+			return t, nil
+		}
+	}
+
+	return "", fmt.Errorf("intuit: unknown transaction type %q", s)
+}
+
+/*
+CorrectionAction is how a correcting transaction modifies the transaction it refers to.
+*/
+type CorrectionAction string
+
+const (
+	CorrectionReplace CorrectionAction = "REPLACE"
+	CorrectionDelete  CorrectionAction = "DELETE"
+)
+
+/*
+Parse a correction action, ignoring case.
+*/
+func ParseCorrectionAction(s string) (CorrectionAction, error) {
+	a := CorrectionAction(strings.ToUpper(strings.TrimSpace(s)))
+
+	switch a {
+	case CorrectionReplace, CorrectionDelete:
+		return a, nil
+	}
+
+	return "", fmt.Errorf("intuit: unknown correction action %q", s)
+}
@@ -0,0 +1,330 @@
+package intuit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const transactionDateFormat = "2006-01-02"
+
+/*
+The longest span, in days including both ends, that CAD accepts in a single transaction query.
+*/
+const MaxTransactionRangeDays = 180
+
+type Transaction struct {
+	Id                       json.Number     `json:"id"`
+	AccountId                string          `json:"-"`
+	AccountType              string          `json:"-"`
+	Type                     TransactionType `json:"type"`
+	CurrencyType             string          `json:"currencyType"`
+	InstitutionTransactionId string          `json:"institutionTransactionId"`
+	PayeeName                string          `json:"payeeName"`
+	Memo                     string          `json:"memo"`
+	PostedDate               Date            `json:"postedDate"`
+	UserDate                 Date            `json:"userDate"`
+	Amount                   Decimal         `json:"amount"`
+	Pending                  bool            `json:"pending"`
+
+	// Intuit's categorization of the transaction, when provided. See Category.
+	Categorization *Categorization `json:"categorization,omitempty"`
+
+	// Set on a transaction which corrects a previously delivered one, identified by its institution transaction Id.
+	CorrectionAction                   CorrectionAction `json:"correctionAction,omitempty"`
+	CorrectionInstitutionTransactionId string           `json:"correctionInstitutionTransactionId,omitempty"`
+
+	IntuitTid string `json:"-"`
+}
+
+/*
+Categorization is Intuit's clean-up of a transaction's payee and its spending category, each proposed by one or more sources.
+*/
+type Categorization struct {
+	Common  CategorizationCommon    `json:"common"`
+	Context []CategorizationContext `json:"context,omitempty"`
+}
+
+type CategorizationCommon struct {
+	NormalizedPayeeName string `json:"normalizedPayeeName,omitempty"`
+	Merchant            string `json:"merchant,omitempty"`
+	SIC                 string `json:"sic,omitempty"`
+}
+
+type CategorizationContext struct {
+	// Who proposed the category, such as "AI" or "USER".
+	Source       string `json:"source,omitempty"`
+	CategoryName string `json:"categoryName,omitempty"`
+	ContextType  string `json:"contextType,omitempty"`
+	ScheduleC    string `json:"scheduleC,omitempty"`
+}
+
+/*
+Return the transaction's category, preferring one set by the user to Intuit's own, or empty when uncategorized.
+*/
+func (t Transaction) Category() string {
+	if t.Categorization == nil {
+		return ""
+	}
+
+	category := ""
+	for _, c := range t.Categorization.Context {
+		if strings.EqualFold(c.Source, "USER") && c.CategoryName != "" {
+			return c.CategoryName
+		}
+		if category == "" {
+			category = c.CategoryName
+		}
+	}
+
+	return category
+}
+
+/*
+Return the payee name cleaned up by Intuit, falling back to the name the institution reported.
+*/
+func (t Transaction) NormalizedPayeeName() string {
+	if t.Categorization != nil && t.Categorization.Common.NormalizedPayeeName != "" {
+		return t.Categorization.Common.NormalizedPayeeName
+	}
+
+	return t.PayeeName
+}
+
+/*
+Apply the corrections in a newer batch of transactions to a previously fetched set, returning the corrected set.
+
+A REPLACE correction takes the place of the transaction it refers to and a DELETE correction removes it. Other transactions in the batch replace any existing transaction with the same institution transaction Id, or are appended. The order of the existing transactions is preserved.
+*/
+func ApplyCorrections(existing []Transaction, batch []Transaction) []Transaction {
+	result := make([]Transaction, len(existing))
+	copy(result, existing)
+
+	index := func(institutionTransactionId string) int {
+		if institutionTransactionId == "" {
+			return -1
+		}
+		for i, t := range result {
+			if t.InstitutionTransactionId == institutionTransactionId {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, t := range batch {
+		switch t.CorrectionAction {
+		case CorrectionDelete:
+			if i := index(t.CorrectionInstitutionTransactionId); i >= 0 {
+				result = append(result[:i], result[i+1:]...)
+			}
+		case CorrectionReplace:
+			if i := index(t.CorrectionInstitutionTransactionId); i >= 0 {
+				result[i] = t
+			} else {
+				result = append(result, t)
+			}
+		default:
+			if i := index(t.InstitutionTransactionId); i >= 0 {
+				result[i] = t
+			} else {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result
+}
+
+/*
+TransactionQuery filters the transactions returned for an account.
+*/
+type TransactionQuery struct {
+	Start time.Time
+	End   time.Time
+}
+
+/*
+Return the query's parameters, with dates taken in the configuration's Location.
+*/
+func (q TransactionQuery) params(configuration *Configuration) map[string]string {
+	params := make(map[string]string)
+	if !q.Start.IsZero() {
+		params["txnStartDate"] = configuration.formatQueryDate(q.Start)
+	}
+	if !q.End.IsZero() {
+		end := "txnEndDate"
+		if configuration != nil && configuration.Compat.LegacyEndDateParam {
+			end = "tnxEndDate"
+		}
+		params[end] = configuration.formatQueryDate(q.End)
+	}
+
+	return params
+}
+
+/*
+RangeError is returned for a transaction query spanning more than MaxTransactionRangeDays when DisableRangeSplitting is set.
+*/
+type RangeError struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("intuit: transaction range %s to %s spans %d days, more than the %d CAD allows in one query", e.Start.Format(transactionDateFormat), e.End.Format(transactionDateFormat), rangeDays(e.Start, e.End), MaxTransactionRangeDays)
+}
+
+/*
+Return the queries needed to cover q within CAD's range limit: q itself when it fits, otherwise consecutive windows of at most MaxTransactionRangeDays. A query without a start date is left to the API's default range. An open end is taken to be today.
+*/
+func (q TransactionQuery) split(configuration *Configuration, now time.Time) ([]TransactionQuery, error) {
+	if q.Start.IsZero() {
+		return []TransactionQuery{q}, nil
+	}
+
+	end := q.End
+	if end.IsZero() {
+		end = now
+	}
+	if rangeDays(q.Start, end) <= MaxTransactionRangeDays {
+		return []TransactionQuery{q}, nil
+	}
+
+	if configuration.DisableRangeSplitting {
+		return nil, &RangeError{Start: q.Start, End: end}
+	}
+
+	chunks := DayChunker(MaxTransactionRangeDays)(q.Start, end)
+	queries := make([]TransactionQuery, len(chunks))
+	for i, c := range chunks {
+		queries[i] = TransactionQuery{Start: c.Start, End: c.End}
+	}
+
+	return queries, nil
+}
+
+func rangeDays(start time.Time, end time.Time) int {
+	s := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	e := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(e.Sub(s).Hours()/24) + 1
+}
+
+/*
+Stream the transactions for an account as they are decoded from the response.
+
+Transactions are delivered on an unbuffered channel, so the response is only read as fast as the caller consumes it, allowing very large histories to be processed without holding them in memory. Both channels are closed once the response has been fully read, the context is cancelled or an error occurs; at most one error is delivered.
+
+Queries spanning more than MaxTransactionRangeDays are split into consecutive requests, oldest first, unless DisableRangeSplitting is set.
+*/
+func TransactionsChan(ctx context.Context, accountId string, q TransactionQuery) (<-chan Transaction, <-chan error) {
+	transactions := make(chan Transaction)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(transactions)
+		defer close(errs)
+
+		queries, err := q.split(configurationFor(ctx), time.Now())
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		for _, query := range queries {
+			if err := sendTransactions(ctx, accountId, query, transactions); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	return transactions, errs
+}
+
+func sendTransactions(ctx context.Context, accountId string, q TransactionQuery, out chan<- Transaction) error {
+	endpoint := fmt.Sprintf("accounts/%s/transactions", accountId)
+	res, err := send(ctx, GET, endpoint, nil, q.params(configurationFor(ctx)), nil)
+	if err != nil {
+		return notFound("account", accountId, err)
+	}
+	defer res.Body.Close()
+
+	tid := intuitTid(res.Header)
+	configuration := configurationFor(ctx)
+	err = streamTransactions(ctx, json.NewDecoder(res.Body), accountId, tid, configuration.location(), configuration.AmountSigns, out)
+	if err != nil && ctx.Err() == nil {
+		err = &DecodeError{Method: GET, Endpoint: endpoint, StatusCode: res.StatusCode, Err: err, IntuitTid: tid, RequestId: requestId(res)}
+	}
+
+	return err
+}
+
+/*
+Walk a transaction list response, decoding each element of the per-account-type transaction arrays (bankingTransactions, creditCardTransactions, etc.) one at a time, placing its dates without a zone in loc and signing its amount according to signs.
+*/
+func streamTransactions(ctx context.Context, d *json.Decoder, accountId string, tid string, loc *time.Location, signs SignPolicy, out chan<- Transaction) error {
+	if err := expectDelim(d, '{'); err != nil {
+		return err
+	}
+
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := t.(string)
+
+		if !strings.HasSuffix(key, "Transactions") {
+			var skip json.RawMessage
+			if err := d.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(d, '['); err != nil {
+			return err
+		}
+
+		for d.More() {
+			var txn Transaction
+			if err := d.Decode(&txn); err != nil {
+				return err
+			}
+			txn.AccountId = accountId
+			txn.AccountType = strings.TrimSuffix(key, "Transactions")
+			txn.IntuitTid = tid
+			anchorDates(reflect.ValueOf(&txn), loc)
+			txn = signs.Apply(txn)
+
+			select {
+			case out <- txn:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err := expectDelim(d, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(d, '}')
+}
+
+func expectDelim(d *json.Decoder, delim json.Delim) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	if t != delim {
+		return fmt.Errorf("unexpected token %v, expected %v", t, delim)
+	}
+
+	return nil
+}
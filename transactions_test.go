@@ -0,0 +1,144 @@
+package intuit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamTransactions(t *testing.T) {
+	body := `{
+		"notRefreshedReason": "NOT_NECESSARY",
+		"bankingTransactions": [
+			{"id": 1, "amount": -12.5, "payeeName": "Coffee", "postedDate": "2014-05-01T00:00:00-07:00"},
+			{"id": 2, "amount": 100, "payeeName": "Payroll", "postedDate": "2014-05-02T00:00:00-07:00"}
+		],
+		"creditCardTransactions": [
+			{"id": 3, "amount": 40, "payeeName": "Books", "pending": true}
+		]
+	}`
+
+	out := make(chan Transaction)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- streamTransactions(context.Background(), json.NewDecoder(strings.NewReader(body)), "5", "tid", time.UTC, SignAsIs, out)
+		close(out)
+	}()
+
+	var txns []Transaction
+	for txn := range out {
+		txns = append(txns, txn)
+	}
+
+	assert.NoError(t, <-errs)
+	assert.Equal(t, 3, len(txns))
+	assert.Equal(t, "banking", txns[0].AccountType)
+	assert.Equal(t, MustParseDecimal("-12.5"), txns[0].Amount)
+	assert.Equal(t, "creditCard", txns[2].AccountType)
+	assert.True(t, txns[2].Pending)
+	assert.Equal(t, "tid", txns[2].IntuitTid)
+	assert.Equal(t, "5", txns[2].AccountId)
+}
+
+func TestApplyCorrections(t *testing.T) {
+	existing := []Transaction{
+		{InstitutionTransactionId: "a", Amount: MustParseDecimal("10")},
+		{InstitutionTransactionId: "b", Amount: MustParseDecimal("20")},
+		{InstitutionTransactionId: "c", Amount: MustParseDecimal("30")},
+	}
+
+	batch := []Transaction{
+		{InstitutionTransactionId: "b2", Amount: MustParseDecimal("25"), CorrectionAction: CorrectionReplace, CorrectionInstitutionTransactionId: "b"},
+		{InstitutionTransactionId: "c2", CorrectionAction: CorrectionDelete, CorrectionInstitutionTransactionId: "c"},
+		{InstitutionTransactionId: "a", Amount: MustParseDecimal("11")},
+		{InstitutionTransactionId: "d", Amount: MustParseDecimal("40")},
+	}
+
+	result := ApplyCorrections(existing, batch)
+	assert.Equal(t, 3, len(result))
+	assert.Equal(t, MustParseDecimal("11"), result[0].Amount)
+	assert.Equal(t, "b2", result[1].InstitutionTransactionId)
+	assert.Equal(t, MustParseDecimal("25"), result[1].Amount)
+	assert.Equal(t, "d", result[2].InstitutionTransactionId)
+	assert.Equal(t, MustParseDecimal("30"), existing[2].Amount)
+}
+
+func TestDecodeTransactionEnums(t *testing.T) {
+	var txn Transaction
+	assert.NoError(t, json.Unmarshal([]byte(`{"type": "DEBIT", "correctionAction": "REPLACE"}`), &txn))
+	assert.Equal(t, TransactionDebit, txn.Type)
+	assert.Equal(t, CorrectionReplace, txn.CorrectionAction)
+
+	typ, err := ParseTransactionType("srvchg")
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionServiceCharge, typ)
+
+	_, err = ParseCorrectionAction("UPDATE")
+	assert.Error(t, err)
+}
+
+func TestTransactionsChanSplitsLongRanges(t *testing.T) {
+	ranges := make([]string, 0)
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		ranges = append(ranges, q.Get("txnStartDate")+"/"+q.Get("txnEndDate"))
+		fmt.Fprintf(w, `{"bankingTransactions": [{"id": %d}]}`, len(ranges))
+	})
+	defer done()
+
+	q := TransactionQuery{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)}
+	all, err := collectTransactions(context.Background(), "1", q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2024-01-01/2024-06-28", "2024-06-29/2024-12-25", "2024-12-26/2024-12-31"}, ranges)
+	assert.Equal(t, 3, len(all))
+
+	SessionConfiguration.DisableRangeSplitting = true
+	_, err = collectTransactions(context.Background(), "1", q)
+	assert.IsType(t, &RangeError{}, err)
+	assert.Equal(t, "intuit: transaction range 2024-01-01 to 2024-12-31 spans 366 days, more than the 180 CAD allows in one query", err.Error())
+	assert.Equal(t, 3, len(ranges))
+
+	// Ranges within the limit are sent as is.
+	_, err = collectTransactions(context.Background(), "1", TransactionQuery{Start: q.Start, End: q.Start.AddDate(0, 0, MaxTransactionRangeDays-1)})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-01/2024-06-28", ranges[3])
+}
+
+func TestTransactionsTyped(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2014-06-01", r.URL.Query().Get("txnStartDate"))
+		w.Write([]byte(`{"creditCardTransactions": [
+			{"id": 1, "amount": 64.3, "payeeName": "THE CORNER BISTRO #12", "postedDate": "2014-06-10", "pending": true,
+				"categorization": {"common": {"normalizedPayeeName": "The Corner Bistro"}, "context": [
+					{"source": "AI", "categoryName": "Restaurants"},
+					{"source": "USER", "categoryName": "Business Meals"}
+				]}},
+			{"id": 2, "amount": -600, "payeeName": "PAYMENT THANK YOU", "postedDate": "2014-06-02",
+				"categorization": {"context": [{"source": "AI", "categoryName": "Credit Card Payment"}]}},
+			{"id": 3, "amount": 5, "payeeName": "FEE"}
+		]}`))
+	})
+	defer done()
+
+	start := time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC)
+	transactions, err := Transactions("1", start, start.AddDate(0, 0, 29))
+	assert.NoError(t, err)
+	if !assert.Len(t, transactions, 3) {
+		return
+	}
+
+	assert.Equal(t, "creditCard", transactions[0].AccountType)
+	assert.Equal(t, "64.3", transactions[0].Amount.String())
+	assert.True(t, transactions[0].Pending)
+	assert.Equal(t, 10, transactions[0].PostedDate.Day())
+	assert.Equal(t, "Business Meals", transactions[0].Category())
+	assert.Equal(t, "The Corner Bistro", transactions[0].NormalizedPayeeName())
+	assert.Equal(t, "Credit Card Payment", transactions[1].Category())
+	assert.Equal(t, "PAYMENT THANK YOU", transactions[1].NormalizedPayeeName())
+	assert.Equal(t, "", transactions[2].Category())
+}
@@ -0,0 +1,57 @@
+package intuit
+
+import (
+	"context"
+	"reflect"
+)
+
+/*
+Perform a GET through the middleware pipeline and decode the JSON response into a T, so an endpoint can be wrapped in one line:
+
+	func Institution(ctx context.Context, id string) (*InstitutionDetails, error) {
+		return intuit.Get[*InstitutionDetails](ctx, "institutions/"+id, nil)
+	}
+
+The customer is taken from ctx, as set with WithCustomer. Decoding follows the rules of the built-in calls, including StrictDecoding, and failures are returned as the usual TransportError, APIError and DecodeError values. A pointer T is allocated before decoding.
+*/
+func Get[T any](ctx context.Context, endpoint string, params map[string]string) (T, error) {
+	return call[T](ctx, GET, endpoint, nil, params)
+}
+
+/*
+Perform a POST with body encoded as XML, and decode the JSON response into a T. See Get.
+*/
+func Post[T any](ctx context.Context, endpoint string, body interface{}, params map[string]string) (T, error) {
+	return call[T](ctx, POST, endpoint, body, params)
+}
+
+/*
+Perform a PUT with body encoded as XML, and decode the JSON response into a T. See Get.
+*/
+func Put[T any](ctx context.Context, endpoint string, body interface{}, params map[string]string) (T, error) {
+	return call[T](ctx, PUT, endpoint, body, params)
+}
+
+/*
+Perform a DELETE, and decode the JSON response, if any, into a T. Use struct{} when no response is expected. See Get.
+*/
+func Delete[T any](ctx context.Context, endpoint string, params map[string]string) (T, error) {
+	return call[T](ctx, DELETE, endpoint, nil, params)
+}
+
+func call[T any](ctx context.Context, method string, endpoint string, body interface{}, params map[string]string) (T, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var v T
+	target := interface{}(&v)
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Ptr {
+		// Decode into a fresh value, so that types recording their Intuit transaction Id see it.
+		reflect.ValueOf(&v).Elem().Set(reflect.New(t.Elem()))
+		target = v
+	}
+
+	err := fetch(ctx, method, endpoint, body, params, nil, target)
+	return v, err
+}
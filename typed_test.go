@@ -0,0 +1,37 @@
+package intuit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestTypedHelpers(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case GET:
+			assert.Equal(t, "/institutions/100000", r.URL.Path)
+			assert.Equal(t, "1", r.URL.Query().Get("v"))
+			w.Header().Set("intuit_tid", "tid-1")
+			w.Write([]byte(`{"institutionId": 100000, "institutionName": "CCBank-Beavca"}`))
+		case DELETE:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer done()
+
+	institution, err := Get[*InstitutionDetails](context.Background(), "institutions/100000", map[string]string{"v": "1"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, institution) {
+		assert.Equal(t, "CCBank-Beavca", institution.InstitutionName)
+		assert.Equal(t, "tid-1", institution.IntuitTid)
+	}
+
+	byValue, err := Get[map[string]interface{}](nil, "institutions/100000", map[string]string{"v": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "CCBank-Beavca", byValue["institutionName"])
+
+	_, err = Delete[struct{}](context.Background(), "accounts/1", nil)
+	assert.NoError(t, err)
+}
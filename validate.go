@@ -0,0 +1,100 @@
+package intuit
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// SAML provider Ids are dotted names such as "app.1.cc.dev-intuit.ipp.prod".
+var samlProviderIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+){2,}$`)
+
+/*
+ValidationError lists every problem found by Configuration.Validate.
+*/
+type ValidationError struct {
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		messages[i] = "  - " + p.Error()
+	}
+
+	return fmt.Sprintf("intuit: invalid configuration:\n%s", strings.Join(messages, "\n"))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Problems
+}
+
+/*
+Check the configuration for problems which would otherwise surface as opaque authentication failures, returning a ValidationError listing all of them, or nil.
+*/
+func (c *Configuration) Validate() error {
+	problems := make([]error, 0)
+	problem := func(format string, v ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, v...))
+	}
+
+	if c.CertificatePath == "" {
+		problem("CertificatePath is not set; it must point to the PEM-encoded private key registered with the application")
+	} else if _, err := os.Stat(c.CertificatePath); err != nil {
+		problem("certificate %s cannot be read: %v", c.CertificatePath, err)
+	} else if key, err := loadPrivateKey(c.CertificatePath); err != nil {
+		problem("certificate %s is not a usable RSA private key: %v", c.CertificatePath, err)
+	} else if c.PublicCertificatePath != "" {
+		if publicKey, err := loadCertificateKey(c.PublicCertificatePath); err != nil {
+			problem("public certificate %s cannot be used: %v", c.PublicCertificatePath, err)
+		} else if !key.PublicKey.Equal(publicKey) {
+			problem("public certificate %s does not match the signing key at %s", c.PublicCertificatePath, c.CertificatePath)
+		}
+	}
+
+	if strings.TrimSpace(c.OAuthConsumerKey) == "" {
+		problem("OAuthConsumerKey is not set; copy it from the application's keys in the developer portal")
+	}
+	if strings.TrimSpace(c.OAuthConsumerSecret) == "" {
+		problem("OAuthConsumerSecret is not set; copy it from the application's keys in the developer portal")
+	}
+
+	if c.SamlProviderId == "" {
+		problem("SamlProviderId is not set")
+	} else if !samlProviderIdPattern.MatchString(c.SamlProviderId) {
+		problem("SamlProviderId %q does not look like a SAML provider Id, which is a dotted name such as \"app.1.cc.dev-intuit.ipp.prod\"", c.SamlProviderId)
+	}
+
+	if c.CustomerId == "" {
+		problem("CustomerId is not set; call Scope with the customer's Id before making requests")
+	}
+
+	for _, u := range []struct{ name, value string }{{"BaseURL", c.BaseURL}, {"TokenURL", c.TokenURL}} {
+		if u.value == "" {
+			continue
+		}
+		if parsed, err := url.Parse(u.value); err != nil || !parsed.IsAbs() {
+			problem("%s %q is not an absolute URL", u.name, u.value)
+		}
+	}
+
+	switch c.SignatureMethod {
+	case "", HMACSHA1, RSASHA1, PLAINTEXT:
+	default:
+		problem("SignatureMethod %q is not supported", c.SignatureMethod)
+	}
+
+	switch c.AmountSigns {
+	case "", SignAsIs, SignDebitNegative, SignDebitPositive:
+	default:
+		problem("AmountSigns %q is not supported", c.AmountSigns)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Check fetched data for quality problems which point to upstream aggregation issues, such as an account whose balance was never updated or transactions Intuit returned for the wrong dates.
+
+	snapshot, _ := intuit.CustomerSnapshot(&q)
+	for _, w := range validate.Snapshot(snapshot, &q) {
+		log.Println(w)
+	}
+
+Problems are reported as warnings rather than errors: the data is still usable, but should be watched or reported to Intuit.
+*/
+package validate
+
+import (
+	"fmt"
+	"github.com/MattNewberry/intuit"
+	"time"
+)
+
+/*
+Kind identifies a quality problem.
+*/
+type Kind string
+
+const (
+	// An account has a zero balance but transactions posted within RecentActivity.
+	ZeroBalanceWithActivity Kind = "ZERO_BALANCE_WITH_ACTIVITY"
+
+	// More than one account has the same Id.
+	DuplicateAccount Kind = "DUPLICATE_ACCOUNT"
+
+	// A transaction was posted outside the query's date range.
+	TransactionOutOfRange Kind = "TRANSACTION_OUT_OF_RANGE"
+
+	// An account has no currency code.
+	MissingCurrency Kind = "MISSING_CURRENCY"
+)
+
+/*
+How recently a transaction must have posted to make a zero balance suspicious.
+*/
+var RecentActivity = 30 * 24 * time.Hour
+
+/*
+Warning is a single quality problem, with the account and transaction it was found in.
+*/
+type Warning struct {
+	Kind          Kind
+	AccountId     string
+	TransactionId string
+	Message       string
+}
+
+func (w Warning) String() string {
+	if w.TransactionId != "" {
+		return fmt.Sprintf("%s: account %s, transaction %s: %s", w.Kind, w.AccountId, w.TransactionId, w.Message)
+	}
+
+	return fmt.Sprintf("%s: account %s: %s", w.Kind, w.AccountId, w.Message)
+}
+
+/*
+Check a list of accounts for duplicate Ids and missing currencies.
+*/
+func Accounts(accounts []intuit.CustomerAccount) []Warning {
+	warnings := make([]Warning, 0)
+	seen := make(map[string]int)
+
+	for _, a := range accounts {
+		id := a.AccountId.String()
+		seen[id]++
+		if seen[id] == 2 {
+			warnings = append(warnings, Warning{Kind: DuplicateAccount, AccountId: id, Message: "account appears more than once"})
+		}
+
+		if a.CurrencyCode == "" {
+			warnings = append(warnings, Warning{Kind: MissingCurrency, AccountId: id, Message: "account has no currency code"})
+		}
+	}
+
+	return warnings
+}
+
+/*
+Check an account's transactions, fetched with q as of now: each must have posted within the query's range, and the account should not have a zero balance if any posted within RecentActivity. An open end is taken to be now. Transactions without a posted date, such as pending ones, are not checked against the range.
+*/
+func Transactions(account intuit.CustomerAccount, transactions []intuit.Transaction, q intuit.TransactionQuery, now time.Time) []Warning {
+	const day = "2006-01-02"
+	warnings := make([]Warning, 0)
+	id := account.AccountId.String()
+
+	end := q.End
+	if end.IsZero() {
+		end = now
+	}
+
+	recent := false
+	for _, t := range transactions {
+		if t.PostedDate.IsZero() {
+			continue
+		}
+
+		posted := t.PostedDate.UTC().Format(day)
+		if (!q.Start.IsZero() && posted < q.Start.UTC().Format(day)) || posted > end.UTC().Format(day) {
+			warnings = append(warnings, Warning{
+				Kind:          TransactionOutOfRange,
+				AccountId:     id,
+				TransactionId: t.Id.String(),
+				Message:       fmt.Sprintf("posted %s, outside the requested range", posted),
+			})
+		}
+
+		if now.Sub(t.PostedDate.Time) <= RecentActivity {
+			recent = true
+		}
+	}
+
+	if recent && account.BalanceAmount == 0 {
+		warnings = append(warnings, Warning{Kind: ZeroBalanceWithActivity, AccountId: id, Message: "balance is zero despite recent transactions"})
+	}
+
+	return warnings
+}
+
+/*
+Check every account in a snapshot and, when q is the query the snapshot's transactions were fetched with, their transactions.
+*/
+func Snapshot(s *intuit.Snapshot, q *intuit.TransactionQuery) []Warning {
+	accounts := make([]intuit.CustomerAccount, 0)
+	for _, l := range s.Logins {
+		for _, a := range l.Accounts {
+			accounts = append(accounts, a.CustomerAccount)
+		}
+	}
+
+	warnings := Accounts(accounts)
+	if q == nil {
+		return warnings
+	}
+
+	for _, l := range s.Logins {
+		for _, a := range l.Accounts {
+			warnings = append(warnings, Transactions(a.CustomerAccount, a.Transactions, *q, s.CreatedAt)...)
+		}
+	}
+
+	return warnings
+}
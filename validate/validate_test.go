@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"github.com/MattNewberry/intuit"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func date(s string) intuit.Date {
+	d, _ := intuit.ParseDate(s)
+	return d
+}
+
+func TestAccounts(t *testing.T) {
+	warnings := Accounts([]intuit.CustomerAccount{
+		{AccountId: "1", CurrencyCode: "USD"},
+		{AccountId: "2"},
+		{AccountId: "1", CurrencyCode: "USD"},
+		{AccountId: "1", CurrencyCode: "USD"},
+	})
+
+	assert.Equal(t, []Warning{
+		{Kind: MissingCurrency, AccountId: "2", Message: "account has no currency code"},
+		{Kind: DuplicateAccount, AccountId: "1", Message: "account appears more than once"},
+	}, warnings)
+}
+
+func TestSnapshot(t *testing.T) {
+	now := time.Date(2014, 6, 30, 12, 0, 0, 0, time.UTC)
+	q := &intuit.TransactionQuery{Start: time.Date(2014, 6, 1, 9, 0, 0, 0, time.UTC)}
+
+	s := &intuit.Snapshot{CreatedAt: now, Logins: []intuit.LoginSnapshot{{Accounts: []intuit.AccountSnapshot{
+		{
+			CustomerAccount: intuit.CustomerAccount{AccountId: "1", CurrencyCode: "USD"},
+			Transactions: []intuit.Transaction{
+				{Id: "10", PostedDate: date("2014-06-01")},
+				{Id: "11", PostedDate: date("2014-05-31")},
+				{Id: "12", Pending: true},
+			},
+		},
+		{
+			CustomerAccount: intuit.CustomerAccount{AccountId: "2", CurrencyCode: "USD", BalanceAmount: 10},
+			Transactions:    []intuit.Transaction{{Id: "20", PostedDate: date("2014-06-20")}},
+		},
+	}}}}
+
+	warnings := Snapshot(s, q)
+	assert.Equal(t, 2, len(warnings))
+	assert.Equal(t, TransactionOutOfRange, warnings[0].Kind)
+	assert.Equal(t, "11", warnings[0].TransactionId)
+	assert.Equal(t, ZeroBalanceWithActivity, warnings[1].Kind)
+	assert.Equal(t, "ZERO_BALANCE_WITH_ACTIVITY: account 1: balance is zero despite recent transactions", warnings[1].String())
+
+	assert.Equal(t, 0, len(Snapshot(s, nil)))
+}
@@ -0,0 +1,40 @@
+package intuit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestValidateConfiguration(t *testing.T) {
+	_, done := configureStubTokenServer(t, nil)
+	defer done()
+
+	valid := *SessionConfiguration
+	valid.OAuthConsumerSecret = "secret"
+	valid.SamlProviderId = "app.1.cc.dev-intuit.ipp.prod"
+	assert.NoError(t, valid.Validate())
+
+	err := (&Configuration{SamlProviderId: "https://example.com", BaseURL: "relative/"}).Validate()
+	validation, ok := err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, 6, len(validation.Problems), err.Error())
+	assert.Contains(t, err.Error(), "CertificatePath is not set")
+	assert.Contains(t, err.Error(), "does not look like a SAML provider Id")
+	assert.Contains(t, err.Error(), "BaseURL")
+
+	f, _ := ioutil.TempFile("", "intuit-bad-key")
+	f.WriteString("not a key")
+	f.Close()
+	defer os.Remove(f.Name())
+
+	invalid := valid
+	invalid.CertificatePath = f.Name()
+	err = invalid.Validate()
+	assert.Contains(t, err.Error(), "is not a usable RSA private key")
+
+	invalid.CertificatePath = f.Name() + ".missing"
+	err = invalid.Validate()
+	assert.Contains(t, err.Error(), "cannot be read")
+}
@@ -0,0 +1,162 @@
+package intuit
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+/*
+Default time between cache warming passes.
+*/
+const DefaultWarmInterval = 6 * time.Hour
+
+/*
+CacheWarmer keeps the institution cache warm in long-running services, refreshing the details of popular institutions and of every institution already cached on a schedule, so they are rarely fetched while a user waits.
+
+	warmer := &intuit.CacheWarmer{Interval: time.Hour, Jitter: 5 * time.Minute}
+	go warmer.Run(ctx)
+
+Passes are spread by a random jitter, so instances started together do not refresh at once. A pass which fails is retried after an exponentially growing delay, starting at RetryDelay, until it succeeds or the next scheduled pass is due.
+*/
+type CacheWarmer struct {
+	// Time between passes. Defaults to DefaultWarmInterval.
+	Interval time.Duration
+
+	// Maximum random delay added to each pass.
+	Jitter time.Duration
+
+	// Delay before the first retry of a failed pass. Defaults to a minute.
+	RetryDelay time.Duration
+
+	// Institutions to refresh in addition to those already cached. Defaults to the Ids of PopularInstitutions.
+	InstitutionIds func() []string
+
+	// Institutions refreshed concurrently. Defaults to InstitutionLookupConcurrency.
+	Concurrency int
+
+	// Receives the error of each failed pass, typically a BatchError listing the institutions which could not be refreshed.
+	OnError func(error)
+
+	// Client fetching the institutions. Defaults to the one used by the package-level functions.
+	Client *Client
+}
+
+/*
+Warm the cache immediately, then on schedule until the context is cancelled.
+*/
+func (w *CacheWarmer) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWarmInterval
+	}
+	retry := w.RetryDelay
+	if retry <= 0 {
+		retry = time.Minute
+	}
+
+	delay := time.Duration(0)
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+
+		delay = interval + w.jitter()
+		if err := w.Warm(); err != nil {
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+
+			if retry < interval {
+				delay = retry + w.jitter()
+				retry *= 2
+				continue
+			}
+		}
+
+		retry = w.RetryDelay
+		if retry <= 0 {
+			retry = time.Minute
+		}
+	}
+}
+
+func (w *CacheWarmer) jitter() time.Duration {
+	if w.Jitter <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(w.Jitter)))
+}
+
+/*
+Refresh the details of every institution to warm in a single pass, replacing cached entries whether or not they are stale. Institutions which fail keep their cached details and are listed in the returned BatchError.
+*/
+func (w *CacheWarmer) Warm() error {
+	client := w.Client
+	if client == nil {
+		client = defaultClient()
+	}
+	ctx := client.background()
+	ids := w.institutionIds(client.Configuration())
+
+	concurrency := w.Concurrency
+	if concurrency <= 0 {
+		concurrency = InstitutionLookupConcurrency
+	}
+
+	batch := NewBatchError("warm institution cache", len(ids))
+	jobs := make(chan int)
+	done := make(chan struct{})
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			for j := range jobs {
+				institution, err := fetchInstitution(ctx, ids[j])
+				if err != nil {
+					batch.Add(j, ids[j], err)
+					continue
+				}
+				institutionCache.put(client.Configuration(), ids[j], institution)
+			}
+			done <- struct{}{}
+		}()
+	}
+
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	for i := 0; i < concurrency; i++ {
+		<-done
+	}
+
+	return batch.Err()
+}
+
+func (w *CacheWarmer) institutionIds(configuration *Configuration) []string {
+	var ids []string
+	if w.InstitutionIds != nil {
+		ids = w.InstitutionIds()
+	} else {
+		for _, p := range PopularInstitutions() {
+			ids = append(ids, p.InstitutionId)
+		}
+	}
+
+	seen := make(map[string]bool)
+	unique := make([]string, 0)
+	for _, id := range append(ids, institutionCache.keys(configuration)...) {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Strings(unique)
+
+	return unique
+}
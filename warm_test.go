@@ -0,0 +1,57 @@
+package intuit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheWarmer(t *testing.T) {
+	var mutex sync.Mutex
+	fetched := make(map[string]int)
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		fetched[r.URL.Path]++
+		mutex.Unlock()
+
+		if r.URL.Path == "/institutions/3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"institutionId": 1}`))
+	})
+	defer done()
+	ClearInstitutionCache()
+	defer ClearInstitutionCache()
+
+	_, err := cachedInstitution(context.Background(), "2")
+	assert.NoError(t, err)
+
+	warmer := &CacheWarmer{InstitutionIds: func() []string { return []string{TestInstitutionId, "3"} }}
+	err = warmer.Warm()
+	assert.Equal(t, []string{"3"}, err.(*BatchError).Ids())
+	assert.Equal(t, 2, fetched["/institutions/2"])
+	assert.Equal(t, 1, fetched["/institutions/"+TestInstitutionId])
+
+	// Warmed entries are served from the cache.
+	_, err = cachedInstitution(context.Background(), TestInstitutionId)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fetched["/institutions/"+TestInstitutionId])
+
+	// Failed passes are retried with growing delays.
+	var errs []error
+	ctx, cancel := context.WithCancel(context.Background())
+	warmer.RetryDelay = time.Millisecond
+	warmer.OnError = func(err error) {
+		errs = append(errs, err)
+		if len(errs) == 3 {
+			cancel()
+		}
+	}
+	assert.Equal(t, context.Canceled, warmer.Run(ctx))
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, 4, fetched["/institutions/3"])
+}
@@ -0,0 +1,116 @@
+package intuit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+/*
+Replaces redacted values in the wire log.
+*/
+const Redacted = "[REDACTED]"
+
+// Credential values and challenge answers in request bodies.
+var wireSecretElements = regexp.MustCompile(`(?s)(<(?:\w+:)?(?:value|response)\b[^>]*>).*?(</(?:\w+:)?(?:value|response)>)`)
+
+// Headers carrying OAuth tokens and signatures or MFA session state.
+var wireSecretHeaders = map[string]bool{
+	"authorization":      true,
+	"challengesessionid": true,
+}
+
+var wireMutex sync.Mutex
+
+/*
+Write an entry to the wire log as a single write, so entries of concurrent requests are not interleaved.
+*/
+func writeWire(w io.Writer, entry *bytes.Buffer) {
+	wireMutex.Lock()
+	defer wireMutex.Unlock()
+
+	w.Write(entry.Bytes())
+}
+
+func wireHeaders(b *bytes.Buffer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, v := range header[name] {
+			if wireSecretHeaders[normalizeHeaderName(name)] {
+				v = Redacted
+			}
+			fmt.Fprintf(b, "%s: %s\n", name, v)
+		}
+	}
+}
+
+/*
+Log a signed request exactly as sent, with credentials, challenge answers and tokens redacted.
+*/
+func logWireRequest(w io.Writer, req *Request, httpReq *http.Request, payload []byte) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, ">>> %s %s request_id=%s\n", httpReq.Method, httpReq.URL, req.RequestId)
+	wireHeaders(&b, httpReq.Header)
+	if len(payload) > 0 {
+		b.WriteString("\n")
+		b.Write(wireSecretElements.ReplaceAll(payload, []byte("${1}"+Redacted+"${2}")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	writeWire(w, &b)
+}
+
+/*
+Log a response exactly as received.
+*/
+func logWireResponse(w io.Writer, req *Request, res *http.Response, body []byte) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<<< %s %s request_id=%s\n", res.Status, req.Endpoint, req.RequestId)
+	wireHeaders(&b, res.Header)
+	if len(body) > 0 {
+		b.WriteString("\n")
+		b.Write(body)
+		if !bytes.HasSuffix(body, []byte("\n")) {
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	writeWire(w, &b)
+}
+
+/*
+Read a successful response's body for the wire log, leaving it readable for decoding.
+*/
+func readWireBody(res *http.Response) []byte {
+	b, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(b), errReader{err}))
+	return b
+}
+
+/*
+errReader reports a read error, preserving a failure reading the body for the decoder. It reads as EOF when err is nil.
+*/
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	return 0, io.EOF
+}
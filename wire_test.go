@@ -0,0 +1,42 @@
+package intuit
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWireLog(t *testing.T) {
+	done := configureStubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accounts": []}`))
+	})
+	defer done()
+
+	var wire bytes.Buffer
+	SessionConfiguration.WireLog = &wire
+
+	_, _, err := DiscoverAndAddAccountsWithCredentials("100000", []Credential{{Name: "Banking Userid", Value: "user"}, {Name: "Banking Password", Value: "hunter2"}})
+	assert.NoError(t, err)
+
+	session := &ChallengeSession{InstitutionId: "100000", SessionId: "secret-session", NodeId: "node", contextType: discoverAndAddType}
+	session.Challenges = []Challenge{{Question: "Favorite color?"}}
+	session.Answers = []Answer{TextAnswer("blue")}
+	_, _, err = session.Respond()
+	assert.NoError(t, err)
+
+	log := wire.String()
+	assert.Contains(t, log, ">>> POST "+SessionConfiguration.BaseURL+"institutions/100000/logins request_id=")
+	assert.Contains(t, log, "<name>Banking Password</name>")
+	assert.Contains(t, log, "<value>[REDACTED]</value>")
+	assert.Contains(t, log, ">[REDACTED]</v11:response>")
+	assert.Contains(t, log, "Authorization: [REDACTED]")
+	assert.Contains(t, log, "Challengesessionid: [REDACTED]")
+	assert.Contains(t, log, "<<< 200 OK institutions/100000/logins")
+	assert.Contains(t, log, `{"accounts": []}`)
+
+	for _, secret := range []string{"hunter2", ">user<", "blue", "secret-session", "oauth_signature"} {
+		assert.False(t, strings.Contains(log, secret), secret)
+	}
+}